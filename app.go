@@ -1,14 +1,37 @@
 package main
 
 import (
+	"encoding/json"
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/grpcrequest"
 	"grpc-gui/internal/models"
+	"grpc-gui/internal/search"
 	"grpc-gui/internal/storage"
 	"log"
+	"sync"
 )
 
 type App struct {
-	storage    *storage.SQLiteStorage
-	tabStorage *storage.TabStorage
+	storage     *storage.SQLiteStorage
+	tabStorage  *storage.TabStorage
+	methodCache *grpcreflect.MethodCache
+
+	// emit отправляет событие во фронтенд, подключается в main после создания приложения
+	emit func(name string, data any)
+
+	mu          sync.Mutex
+	openServers map[uint]bool
+	// searchIndex пересобирается, когда меняется набор серверов или их схемы
+	searchIndex *search.Index
+	searchKey   string
+	// healthWatches - подписки на статус открытых серверов
+	healthWatches map[uint]*healthWatch
+	// resolvingSchemas - серверы, полная схема которых сейчас загружается в фоне
+	resolvingSchemas map[uint]bool
+	schemaResolves   sync.WaitGroup
+	// extractedVars - значения, извлеченные правилами из ответов и заданные скриптами, живут до перезапуска
+	extractedVars map[string]string
 }
 
 func NewApp(dbPath string) *App {
@@ -17,18 +40,48 @@ func NewApp(dbPath string) *App {
 		log.Fatalf("failed to create storage: %v", err)
 	}
 
-	err = sqliteStorage.AutoMigrate(&models.Server{}, &models.History{})
+	err = sqliteStorage.AutoMigrate(
+		&models.Server{}, &models.History{}, &models.HealthCheck{}, &models.Environment{},
+		&models.ExtractionRule{}, &models.MethodScript{}, &models.Assertion{},
+		&models.Collection{}, &models.Folder{}, &models.SavedRequest{},
+	)
 	if err != nil {
 		log.Fatalf("failed to migrate database: %v", err)
 	}
 
+	if _, err := sqliteStorage.MigrateHistoryContextValues(convertLegacyContextValues); err != nil {
+		log.Printf("failed to migrate history context values: %v", err)
+	}
+
 	tabStorage, err := storage.NewTabStorage()
 	if err != nil {
 		log.Fatalf("failed to create tab storage: %v", err)
 	}
 
 	return &App{
-		storage:    sqliteStorage,
-		tabStorage: tabStorage,
+		storage:     sqliteStorage,
+		tabStorage:  tabStorage,
+		methodCache: grpcreflect.NewMethodCache(consts.ReflectionCacheTTL),
+		emit:        func(string, any) {},
+	}
+}
+
+// convertLegacyContextValues переводит произвольные значения контекста из старой истории
+// в параметры вызова, которые действительно отправляются на сервер.
+func convertLegacyContextValues(data string) (string, error) {
+	var values map[string]string
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return "", err
+	}
+
+	reqContext := grpcrequest.RequestContextFromValues(values)
+	if reqContext.IsZero() {
+		return "", nil
+	}
+
+	converted, err := json.Marshal(reqContext)
+	if err != nil {
+		return "", err
 	}
+	return string(converted), nil
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/models"
+)
+
+// GetAssertions возвращает проверки метода в порядке выполнения.
+func (a *App) GetAssertions(serverId uint, service, method string) ([]assertion.Assertion, error) {
+	stored, err := a.storage.GetAssertions(serverId, service, method)
+	if err != nil {
+		return nil, err
+	}
+
+	assertions := make([]assertion.Assertion, 0, len(stored))
+	for _, item := range stored {
+		assertions = append(assertions, assertion.Assertion{
+			Kind:     assertion.Kind(item.Kind),
+			Path:     item.Path,
+			Operator: assertion.Operator(item.Operator),
+			Expected: item.Expected,
+		})
+	}
+	return assertions, nil
+}
+
+// SaveAssertions проверяет и заменяет проверки метода. Пустой список удаляет все проверки.
+func (a *App) SaveAssertions(serverId uint, service, method string, assertions []assertion.Assertion) error {
+	stored := make([]models.Assertion, 0, len(assertions))
+	for i, item := range assertions {
+		item.Path = strings.TrimSpace(item.Path)
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("assertion %d: %w", i+1, err)
+		}
+
+		stored = append(stored, models.Assertion{
+			ServerID: serverId,
+			Service:  service,
+			Method:   method,
+			Position: i,
+			Kind:     string(item.Kind),
+			Path:     item.Path,
+			Operator: string(item.Operator),
+			Expected: item.Expected,
+		})
+	}
+	return a.storage.ReplaceAssertions(serverId, service, method, stored)
+}
+
+// assertionsJSON готовит результаты проверок для истории.
+func assertionsJSON(results []assertion.Result) (string, *bool) {
+	if len(results) == 0 {
+		return "", nil
+	}
+	data, _ := json.Marshal(results)
+	passed := assertion.Passed(results)
+	return string(data), &passed
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_Assertions(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	assertions := []assertion.Assertion{
+		{Kind: assertion.KindStatus, Operator: assertion.OpEquals, Expected: "OK"},
+		{Kind: assertion.KindBody, Path: " $.count ", Operator: assertion.OpGreater, Expected: "0"},
+	}
+	if err := app.SaveAssertions(1, "svc", "Method", assertions); err != nil {
+		t.Fatalf("SaveAssertions failed: %v", err)
+	}
+
+	got, err := app.GetAssertions(1, "svc", "Method")
+	if err != nil || len(got) != 2 || got[0] != assertions[0] || got[1].Path != "$.count" {
+		t.Fatalf("expected saved assertions in order, got %+v %v", got, err)
+	}
+
+	invalid := []assertion.Assertion{{Kind: assertion.KindDuration, Operator: assertion.OpLess, Expected: "soon"}}
+	if err := app.SaveAssertions(1, "svc", "Method", invalid); err == nil || !strings.Contains(err.Error(), "assertion 1") {
+		t.Errorf("expected validation error, got %v", err)
+	}
+
+	if err := app.SaveAssertions(1, "svc", "Method", nil); err != nil {
+		t.Fatalf("SaveAssertions failed: %v", err)
+	}
+	if got, _ := app.GetAssertions(1, "svc", "Method"); len(got) != 0 {
+		t.Errorf("expected assertions to be removed, got %+v", got)
+	}
+}
+
+func TestApp_DoGRPCRequest_Assertions(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	result, err := app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUsers", `{}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.Assertions != nil || result.AssertionsPassed != nil {
+		t.Errorf("expected no assertion results without assertions, got %+v", result.Assertions)
+	}
+
+	err = app.SaveAssertions(id, "testserver.AnotherService", "GetUsers", []assertion.Assertion{
+		{Kind: assertion.KindStatus, Operator: assertion.OpEquals, Expected: "OK"},
+		{Kind: assertion.KindBody, Path: "$.count", Operator: assertion.OpGreater, Expected: "0"},
+		{Kind: assertion.KindBody, Path: "$.users[*].name", Operator: assertion.OpContains, Expected: "User2"},
+		{Kind: assertion.KindDuration, Operator: assertion.OpLess, Expected: "5s"},
+		{Kind: assertion.KindSchema, Expected: `{"type": "object", "required": ["users"]}`},
+		{Kind: assertion.KindHeader, Path: "x-version", Operator: assertion.OpExists},
+	})
+	if err != nil {
+		t.Fatalf("SaveAssertions failed: %v", err)
+	}
+
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUsers", `{}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if len(result.Assertions) != 6 || result.AssertionsPassed == nil || *result.AssertionsPassed {
+		t.Fatalf("expected failed assertions, got %+v", result.Assertions)
+	}
+	for i, r := range result.Assertions[:5] {
+		if !r.Passed {
+			t.Errorf("assertion %d: expected to pass, got %+v", i, r)
+		}
+	}
+	if result.Assertions[5].Passed {
+		t.Error("expected missing header assertion to fail")
+	}
+
+	history, err := app.GetHistory(id, 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	var stored []assertion.Result
+	if err := json.Unmarshal([]byte(history[0].Assertions), &stored); err != nil || len(stored) != 6 {
+		t.Errorf("expected assertion results in history, got %q", history[0].Assertions)
+	}
+	if history[0].AssertionsPassed == nil || *history[0].AssertionsPassed {
+		t.Errorf("expected failed assertions flag in history, got %v", history[0].AssertionsPassed)
+	}
+
+	// Неотправленный запрос проваливает все проверки
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUsers", `{"x": "{{missing}}"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if len(result.Assertions) != 6 || result.Assertions[0].Passed || !strings.Contains(result.Assertions[0].Message, "not sent") {
+		t.Errorf("expected not sent assertions, got %+v", result.Assertions)
+	}
+}
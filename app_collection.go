@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/grpcrequest"
+	"grpc-gui/internal/models"
+)
+
+// CollectionItems - содержимое коллекции плоскими списками, дерево строит фронтенд по ParentID и FolderID.
+type CollectionItems struct {
+	Collection models.Collection     `json:"collection"`
+	Folders    []models.Folder       `json:"folders"`
+	Requests   []models.SavedRequest `json:"requests"`
+}
+
+func (a *App) GetCollections() ([]models.Collection, error) {
+	return a.storage.GetCollections()
+}
+
+func (a *App) GetCollectionItems(id uint) (*CollectionItems, error) {
+	collection, err := a.storage.GetCollection(id)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := a.storage.GetFolders(id)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := a.storage.GetSavedRequests(id)
+	if err != nil {
+		return nil, err
+	}
+	return &CollectionItems{Collection: *collection, Folders: folders, Requests: requests}, nil
+}
+
+func (a *App) CreateCollection(name, description string) (uint, error) {
+	name, err := requiredName("collection", name)
+	if err != nil {
+		return 0, err
+	}
+
+	collection := &models.Collection{Name: name, Description: strings.TrimSpace(description)}
+	if err := a.storage.CreateCollection(collection); err != nil {
+		return 0, err
+	}
+	return collection.ID, nil
+}
+
+func (a *App) UpdateCollection(id uint, name, description string) error {
+	name, err := requiredName("collection", name)
+	if err != nil {
+		return err
+	}
+
+	collection, err := a.storage.GetCollection(id)
+	if err != nil {
+		return err
+	}
+	collection.Name = name
+	collection.Description = strings.TrimSpace(description)
+	return a.storage.UpdateCollection(collection)
+}
+
+// DeleteCollection удаляет коллекцию вместе с папками и запросами.
+func (a *App) DeleteCollection(id uint) error {
+	return a.storage.DeleteCollection(id)
+}
+
+// DuplicateCollection копирует коллекцию со всеми папками и запросами.
+func (a *App) DuplicateCollection(id uint) (uint, error) {
+	collection, err := a.storage.GetCollection(id)
+	if err != nil {
+		return 0, err
+	}
+	duplicate, err := a.storage.DuplicateCollection(id, copyName(collection.Name))
+	if err != nil {
+		return 0, err
+	}
+	return duplicate.ID, nil
+}
+
+// CreateFolder создает папку в конце родителя. parentId = 0 - корень коллекции.
+func (a *App) CreateFolder(collectionId, parentId uint, name string) (uint, error) {
+	name, err := requiredName("folder", name)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := a.storage.GetCollection(collectionId); err != nil {
+		return 0, err
+	}
+	if parentId != 0 {
+		parent, err := a.storage.GetFolder(parentId)
+		if err != nil {
+			return 0, err
+		}
+		if parent.CollectionID != collectionId {
+			return 0, fmt.Errorf("folder %d belongs to another collection", parentId)
+		}
+	}
+
+	folder := &models.Folder{CollectionID: collectionId, ParentID: parentId, Name: name}
+	if err := a.storage.CreateFolder(folder); err != nil {
+		return 0, err
+	}
+	return folder.ID, nil
+}
+
+func (a *App) RenameFolder(id uint, name string) error {
+	name, err := requiredName("folder", name)
+	if err != nil {
+		return err
+	}
+
+	folder, err := a.storage.GetFolder(id)
+	if err != nil {
+		return err
+	}
+	folder.Name = name
+	return a.storage.UpdateFolder(folder)
+}
+
+// DeleteFolder удаляет папку со всеми вложенными папками и запросами.
+func (a *App) DeleteFolder(id uint) error {
+	return a.storage.DeleteFolder(id)
+}
+
+// MoveFolder переносит папку в parentId на позицию position среди его папок и запросов.
+func (a *App) MoveFolder(id, parentId uint, position int) error {
+	return a.storage.MoveFolder(id, parentId, position)
+}
+
+func (a *App) GetSavedRequest(id uint) (*models.SavedRequest, error) {
+	return a.storage.GetSavedRequest(id)
+}
+
+// CreateSavedRequest сохраняет запрос в конец папки request.FolderID коллекции request.CollectionID.
+func (a *App) CreateSavedRequest(request models.SavedRequest) (uint, error) {
+	if err := a.validateSavedRequest(&request); err != nil {
+		return 0, err
+	}
+	if _, err := a.storage.GetCollection(request.CollectionID); err != nil {
+		return 0, err
+	}
+
+	request.ID = 0
+	if err := a.storage.CreateSavedRequest(&request); err != nil {
+		return 0, err
+	}
+	return request.ID, nil
+}
+
+// UpdateSavedRequest меняет содержимое запроса, коллекция, папка и позиция не меняются.
+func (a *App) UpdateSavedRequest(request models.SavedRequest) error {
+	if err := a.validateSavedRequest(&request); err != nil {
+		return err
+	}
+	if _, err := a.storage.GetSavedRequest(request.ID); err != nil {
+		return err
+	}
+	return a.storage.UpdateSavedRequest(&request)
+}
+
+func (a *App) DeleteSavedRequest(id uint) error {
+	return a.storage.DeleteSavedRequest(id)
+}
+
+// DuplicateSavedRequest создает копию запроса сразу после оригинала.
+func (a *App) DuplicateSavedRequest(id uint) (uint, error) {
+	request, err := a.storage.GetSavedRequest(id)
+	if err != nil {
+		return 0, err
+	}
+	duplicate, err := a.storage.DuplicateSavedRequest(id, copyName(request.Name))
+	if err != nil {
+		return 0, err
+	}
+	return duplicate.ID, nil
+}
+
+// MoveSavedRequest переносит запрос в папку folderId на позицию position среди ее папок и запросов.
+func (a *App) MoveSavedRequest(id, folderId uint, position int) error {
+	return a.storage.MoveSavedRequest(id, folderId, position)
+}
+
+// DoSavedRequest отправляет сохраненный запрос с правками из вкладки: сервер и метод берутся из
+// сохраненного запроса, вместе с проверками метода выполняются проверки самого запроса.
+func (a *App) DoSavedRequest(id uint, address, payload string, requestHeaders map[string]string, reqContext *grpcrequest.RequestContext) (*RequestResult, error) {
+	saved, err := a.storage.GetSavedRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	return a.doRequest(appScope{a}, saved.ServerID, address, saved.Service, saved.Method, payload, requestHeaders, reqContext, saved)
+}
+
+func (a *App) validateSavedRequest(request *models.SavedRequest) error {
+	request.Service = strings.TrimSpace(request.Service)
+	request.Method = strings.TrimSpace(request.Method)
+	if request.Service == "" || request.Method == "" {
+		return fmt.Errorf("service and method are required")
+	}
+
+	name := request.Name
+	if strings.TrimSpace(name) == "" {
+		name = request.Method
+	}
+	name, err := requiredName("request", name)
+	if err != nil {
+		return err
+	}
+	request.Name = name
+
+	if _, err := a.storage.GetServer(request.ServerID); err != nil {
+		return fmt.Errorf("server %d: %w", request.ServerID, err)
+	}
+
+	headers := make(map[string]string, len(request.Headers))
+	for key, value := range request.Headers {
+		if key = strings.TrimSpace(key); key != "" {
+			headers[key] = value
+		}
+	}
+	request.Headers = headers
+	if savedRequestContext(request.Context).IsZero() {
+		request.Context = nil
+	}
+
+	for i, item := range savedAssertions(request.Assertions) {
+		if err := item.Validate(); err != nil {
+			return fmt.Errorf("assertion %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func requiredName(kind, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%s name is required", kind)
+	}
+	return name, nil
+}
+
+func copyName(name string) string {
+	return name + " (копия)"
+}
+
+// savedRequestContext переводит параметры вызова сохраненного запроса в grpcrequest.
+func savedRequestContext(saved *models.SavedRequestContext) *grpcrequest.RequestContext {
+	if saved == nil {
+		return nil
+	}
+
+	rc := &grpcrequest.RequestContext{
+		Timeout:      saved.Timeout,
+		Compression:  saved.Compression,
+		WaitForReady: saved.WaitForReady,
+		Metadata:     saved.Metadata,
+	}
+	if saved.Credentials != nil {
+		rc.Credentials = &grpcrequest.CallCredentials{
+			Type:     saved.Credentials.Type,
+			Token:    saved.Credentials.Token,
+			Username: saved.Credentials.Username,
+			Password: saved.Credentials.Password,
+		}
+	}
+	return rc
+}
+
+func savedAssertions(saved []models.SavedAssertion) []assertion.Assertion {
+	assertions := make([]assertion.Assertion, 0, len(saved))
+	for _, item := range saved {
+		assertions = append(assertions, assertion.Assertion{
+			Kind:     assertion.Kind(item.Kind),
+			Path:     item.Path,
+			Operator: assertion.Operator(item.Operator),
+			Expected: item.Expected,
+		})
+	}
+	return assertions
+}
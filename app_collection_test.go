@@ -0,0 +1,214 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/testutil"
+)
+
+// itemOrder - имена папок и запросов родителя в порядке Position, папки с префиксом "/".
+func itemOrder(t *testing.T, app *App, collectionID, parentID uint) string {
+	t.Helper()
+
+	items, err := app.GetCollectionItems(collectionID)
+	if err != nil {
+		t.Fatalf("GetCollectionItems failed: %v", err)
+	}
+
+	names := make(map[int]string)
+	for _, folder := range items.Folders {
+		if folder.ParentID == parentID {
+			names[folder.Position] = "/" + folder.Name
+		}
+	}
+	for _, request := range items.Requests {
+		if request.FolderID == parentID {
+			names[request.Position] = request.Name
+		}
+	}
+
+	order := make([]string, 0, len(names))
+	for i := 0; i < len(names); i++ {
+		order = append(order, names[i])
+	}
+	return strings.Join(order, ",")
+}
+
+func TestApp_Collections(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	serverID, err := app.CreateServer("Test Server", "localhost:50051", false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	if _, err := app.CreateCollection(" ", ""); err == nil {
+		t.Error("expected empty name error")
+	}
+	collectionID, err := app.CreateCollection("Users API", "smoke checks")
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+
+	authID, err := app.CreateFolder(collectionID, 0, "auth")
+	if err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+	nestedID, err := app.CreateFolder(collectionID, authID, "tokens")
+	if err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+
+	create := func(name string, folderID uint) uint {
+		t.Helper()
+		id, err := app.CreateSavedRequest(savedRequest(collectionID, folderID, serverID, name))
+		if err != nil {
+			t.Fatalf("CreateSavedRequest failed: %v", err)
+		}
+		return id
+	}
+	getID := create("get", 0)
+	listID := create("list", 0)
+	loginID := create("login", authID)
+	create("refresh", nestedID)
+
+	if order := itemOrder(t, app, collectionID, 0); order != "/auth,get,list" {
+		t.Errorf("unexpected root order %q", order)
+	}
+
+	// Перенос в начало корня и между папками
+	if err := app.MoveSavedRequest(listID, 0, 0); err != nil {
+		t.Fatalf("MoveSavedRequest failed: %v", err)
+	}
+	if order := itemOrder(t, app, collectionID, 0); order != "list,/auth,get" {
+		t.Errorf("unexpected root order after move %q", order)
+	}
+	if err := app.MoveSavedRequest(getID, authID, 0); err != nil {
+		t.Fatalf("MoveSavedRequest failed: %v", err)
+	}
+	if order := itemOrder(t, app, collectionID, authID); order != "get,/tokens,login" {
+		t.Errorf("unexpected auth order %q", order)
+	}
+	if err := app.MoveFolder(authID, nestedID, 0); err == nil {
+		t.Error("expected error when moving folder into its descendant")
+	}
+
+	duplicateID, err := app.DuplicateSavedRequest(loginID)
+	if err != nil {
+		t.Fatalf("DuplicateSavedRequest failed: %v", err)
+	}
+	if order := itemOrder(t, app, collectionID, authID); order != "get,/tokens,login,login (копия)" {
+		t.Errorf("expected duplicate right after original, got %q", order)
+	}
+	if _, err := app.DuplicateSavedRequest(getID); err != nil {
+		t.Fatalf("DuplicateSavedRequest failed: %v", err)
+	}
+	if order := itemOrder(t, app, collectionID, authID); order != "get,get (копия),/tokens,login,login (копия)" {
+		t.Errorf("expected duplicate before the following items, got %q", order)
+	}
+	duplicate, err := app.GetSavedRequest(duplicateID)
+	if err != nil || duplicate.Payload != `{"message": "{{name}}"}` || duplicate.Headers["x-user"] != "{{name}}" ||
+		duplicate.Context.Timeout != "5s" || len(duplicate.Assertions) != 1 {
+		t.Errorf("expected duplicate to keep the request, got %+v %v", duplicate, err)
+	}
+
+	duplicate.Name = "login again"
+	duplicate.Payload = "{}"
+	duplicate.Assertions = []models.SavedAssertion{{Kind: string(assertion.KindDuration), Operator: string(assertion.OpLess), Expected: "later"}}
+	if err := app.UpdateSavedRequest(*duplicate); err == nil {
+		t.Error("expected invalid assertion error")
+	}
+	duplicate.Assertions = nil
+	if err := app.UpdateSavedRequest(*duplicate); err != nil {
+		t.Fatalf("UpdateSavedRequest failed: %v", err)
+	}
+	if updated, _ := app.GetSavedRequest(duplicateID); updated.Name != "login again" || updated.Payload != "{}" || updated.FolderID != authID {
+		t.Errorf("unexpected updated request %+v", updated)
+	}
+
+	copyID, err := app.DuplicateCollection(collectionID)
+	if err != nil {
+		t.Fatalf("DuplicateCollection failed: %v", err)
+	}
+	copied, err := app.GetCollectionItems(copyID)
+	if err != nil || copied.Collection.Name != "Users API (копия)" || len(copied.Folders) != 2 || len(copied.Requests) != 6 {
+		t.Fatalf("unexpected collection copy %+v %v", copied, err)
+	}
+	for _, request := range copied.Requests {
+		if request.Name == "refresh" && request.FolderID == nestedID {
+			t.Error("expected copied requests to point to copied folders")
+		}
+	}
+
+	if err := app.DeleteFolder(authID); err != nil {
+		t.Fatalf("DeleteFolder failed: %v", err)
+	}
+	items, _ := app.GetCollectionItems(collectionID)
+	if len(items.Folders) != 0 || len(items.Requests) != 1 || items.Requests[0].Name != "list" {
+		t.Errorf("expected folder subtree to be deleted, got %+v", items)
+	}
+
+	if err := app.DeleteCollection(collectionID); err != nil {
+		t.Fatalf("DeleteCollection failed: %v", err)
+	}
+	collections, err := app.GetCollections()
+	if err != nil || len(collections) != 1 || collections[0].ID != copyID {
+		t.Errorf("expected only the copy to remain, got %+v %v", collections, err)
+	}
+}
+
+func TestApp_DoSavedRequest(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	serverID, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+	collectionID, err := app.CreateCollection("Users API", "")
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+
+	request := savedRequest(collectionID, 0, serverID, "get user")
+	request.Assertions = []models.SavedAssertion{{Kind: string(assertion.KindBody), Path: "$.name", Operator: string(assertion.OpEquals), Expected: "bob"}}
+	id, err := app.CreateSavedRequest(request)
+	if err != nil {
+		t.Fatalf("CreateSavedRequest failed: %v", err)
+	}
+
+	result, err := app.DoSavedRequest(id, addr, `{"message": "alice"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoSavedRequest failed: %v", err)
+	}
+	if result.Code != 0 || len(result.Assertions) != 1 || result.Assertions[0].Passed || result.Assertions[0].Actual != "alice" {
+		t.Errorf("expected saved assertion to fail, got %d %+v", result.Code, result.Assertions)
+	}
+
+	history, err := app.GetHistory(serverID, 1)
+	if err != nil || len(history) != 1 || history[0].SavedRequestID != id {
+		t.Errorf("expected saved request id in history, got %+v %v", history, err)
+	}
+}
+
+func savedRequest(collectionID, folderID, serverID uint, name string) models.SavedRequest {
+	return models.SavedRequest{
+		CollectionID: collectionID,
+		FolderID:     folderID,
+		Name:         name,
+		ServerID:     serverID,
+		Service:      "testserver.AnotherService",
+		Method:       "GetUser",
+		Payload:      `{"message": "{{name}}"}`,
+		Headers:      map[string]string{"x-user": "{{name}}"},
+		Context:      &models.SavedRequestContext{Timeout: "5s"},
+		Assertions:   []models.SavedAssertion{{Kind: string(assertion.KindStatus), Operator: string(assertion.OpEquals), Expected: "OK"}},
+	}
+}
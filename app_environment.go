@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"grpc-gui/internal/grpcrequest"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/templating"
+	"grpc-gui/internal/utils"
+)
+
+// requestTemplate - поля запроса, в которых подставляются переменные {{name}}.
+type requestTemplate struct {
+	Address string                      `json:"address"`
+	Payload string                      `json:"payload"`
+	Headers map[string]string           `json:"headers,omitempty"`
+	Context *grpcrequest.RequestContext `json:"context,omitempty"`
+}
+
+func (t requestTemplate) render(r *templating.Renderer) requestTemplate {
+	resolved := requestTemplate{
+		Address: r.Render(t.Address),
+		Payload: r.Render(t.Payload),
+	}
+
+	if t.Headers != nil {
+		resolved.Headers = make(map[string]string, len(t.Headers))
+		for key, value := range t.Headers {
+			resolved.Headers[key] = r.Render(value)
+		}
+	}
+
+	if t.Context != nil {
+		reqContext := *t.Context
+		reqContext.Timeout = r.Render(reqContext.Timeout)
+		if t.Context.Metadata != nil {
+			reqContext.Metadata = make(map[string]string, len(t.Context.Metadata))
+			for key, value := range t.Context.Metadata {
+				reqContext.Metadata[key] = r.Render(value)
+			}
+		}
+		if t.Context.Credentials != nil {
+			creds := *t.Context.Credentials
+			creds.Token = r.Render(creds.Token)
+			creds.Username = r.Render(creds.Username)
+			creds.Password = r.Render(creds.Password)
+			reqContext.Credentials = &creds
+		}
+		resolved.Context = &reqContext
+	}
+
+	return resolved
+}
+
+// variableScope - откуда запрос берет переменные и куда пишет извлеченные значения и vars.set из скриптов.
+type variableScope interface {
+	variables(serverId uint) (map[string]string, error)
+	set(vars map[string]string)
+}
+
+// appScope - переменные GUI: активные окружения и значения, извлеченные за время работы приложения.
+type appScope struct {
+	app *App
+}
+
+func (s appScope) variables(serverId uint) (map[string]string, error) {
+	return s.app.GetVariables(serverId)
+}
+
+func (s appScope) set(vars map[string]string) {
+	s.app.setExtractedVariables(vars)
+}
+
+// resolveRequest подставляет переменные и функции шаблонов. used - были ли в запросе подстановки.
+func (a *App) resolveRequest(scope variableScope, serverId uint, template requestTemplate) (resolved requestTemplate, used bool, err error) {
+	vars, err := scope.variables(serverId)
+	if err != nil {
+		return template, false, err
+	}
+
+	renderer := templating.NewRenderer(vars)
+	// Относительные пути file:"..." в GUI считаются от домашнего каталога
+	renderer.BaseDir, _ = utils.GetUserHomeDir()
+	resolved = template.render(renderer)
+	if err := renderer.Err(); err != nil {
+		return template, true, err
+	}
+	return resolved, renderer.Used(), nil
+}
+
+func (a *App) GetEnvironments() ([]models.Environment, error) {
+	return a.storage.GetEnvironments()
+}
+
+// CreateEnvironment создает окружение. serverId = 0 - глобальное окружение.
+func (a *App) CreateEnvironment(name string, serverId uint, variables map[string]string) (uint, error) {
+	name, variables, err := validateEnvironment(name, variables)
+	if err != nil {
+		return 0, err
+	}
+
+	if serverId != 0 {
+		if _, err := a.storage.GetServer(serverId); err != nil {
+			return 0, err
+		}
+	}
+
+	env := &models.Environment{Name: name, ServerID: serverId, Variables: variables}
+	if err := a.storage.CreateEnvironment(env); err != nil {
+		return 0, err
+	}
+	return env.ID, nil
+}
+
+func (a *App) UpdateEnvironment(id uint, name string, variables map[string]string) error {
+	name, variables, err := validateEnvironment(name, variables)
+	if err != nil {
+		return err
+	}
+
+	env, err := a.storage.GetEnvironment(id)
+	if err != nil {
+		return err
+	}
+	env.Name = name
+	env.Variables = variables
+	return a.storage.UpdateEnvironment(env)
+}
+
+func (a *App) DeleteEnvironment(id uint) error {
+	return a.storage.DeleteEnvironment(id)
+}
+
+// SetActiveEnvironment включает окружение, остальные окружения той же области выключаются.
+func (a *App) SetActiveEnvironment(id uint, active bool) error {
+	return a.storage.SetActiveEnvironment(id, active)
+}
+
+// GetVariables возвращает переменные, которые увидит запрос к серверу:
+// активное глобальное окружение, поверх него активное окружение сервера,
+// поверх всего значения, извлеченные из предыдущих ответов.
+func (a *App) GetVariables(serverId uint) (map[string]string, error) {
+	vars, err := a.activeVariables(serverId)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range a.GetExtractedVariables() {
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// activeVariables - переменные активных окружений сервера без извлеченных значений.
+func (a *App) activeVariables(serverId uint) (map[string]string, error) {
+	envs, err := a.storage.GetActiveEnvironments(serverId)
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]string)
+	for _, env := range envs {
+		for key, value := range env.Variables {
+			vars[key] = value
+		}
+	}
+	return vars, nil
+}
+
+func validateEnvironment(name string, variables map[string]string) (string, map[string]string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, fmt.Errorf("environment name is required")
+	}
+
+	cleaned := make(map[string]string, len(variables))
+	for key, value := range variables {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if !templating.ValidName(key) {
+			return "", nil, fmt.Errorf("invalid variable name %q", key)
+		}
+		cleaned[key] = value
+	}
+	return name, cleaned, nil
+}
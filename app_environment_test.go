@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"grpc-gui/internal/grpcrequest"
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_Environments(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	serverID, err := app.CreateServer("Test Server", "localhost:50051", false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	devID, err := app.CreateEnvironment("dev", 0, map[string]string{"token": "dev-token", "tenant": "t1", " ": "skip"})
+	if err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+	prodID, err := app.CreateEnvironment("prod", 0, map[string]string{"token": "prod-token"})
+	if err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+	localID, err := app.CreateEnvironment("local", serverID, map[string]string{"tenant": "local"})
+	if err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+
+	if _, err := app.CreateEnvironment("bad", 0, map[string]string{"has space": "x"}); err == nil {
+		t.Error("expected invalid variable name error")
+	}
+	if _, err := app.CreateEnvironment(" ", 0, nil); err == nil {
+		t.Error("expected empty name error")
+	}
+
+	vars, err := app.GetVariables(serverID)
+	if err != nil || len(vars) != 0 {
+		t.Fatalf("expected no variables without active environments, got %v %v", vars, err)
+	}
+
+	for _, id := range []uint{devID, prodID, localID} {
+		if err := app.SetActiveEnvironment(id, true); err != nil {
+			t.Fatalf("SetActiveEnvironment failed: %v", err)
+		}
+	}
+
+	// prod вытеснил dev, серверное окружение переопределяет глобальное
+	vars, _ = app.GetVariables(serverID)
+	if vars["token"] != "prod-token" || vars["tenant"] != "local" {
+		t.Errorf("unexpected variables: %v", vars)
+	}
+	if vars, _ := app.GetVariables(serverID + 1); vars["tenant"] != "" {
+		t.Errorf("expected server environment only for its server, got %v", vars)
+	}
+
+	envs, err := app.GetEnvironments()
+	if err != nil || len(envs) != 3 {
+		t.Fatalf("expected 3 environments, got %d: %v", len(envs), err)
+	}
+	for _, env := range envs {
+		if env.ID == devID && env.Active {
+			t.Error("expected dev to be deactivated")
+		}
+		if env.ID == devID && len(env.Variables) != 2 {
+			t.Errorf("expected blank variable names to be dropped, got %v", env.Variables)
+		}
+	}
+
+	if err := app.UpdateEnvironment(prodID, "prod", map[string]string{"token": "rotated"}); err != nil {
+		t.Fatalf("UpdateEnvironment failed: %v", err)
+	}
+	if err := app.DeleteEnvironment(localID); err != nil {
+		t.Fatalf("DeleteEnvironment failed: %v", err)
+	}
+	vars, _ = app.GetVariables(serverID)
+	if vars["token"] != "rotated" || vars["tenant"] != "" {
+		t.Errorf("unexpected variables after update: %v", vars)
+	}
+}
+
+func TestApp_DoGRPCRequest_Variables(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	envID, err := app.CreateEnvironment("dev", 0, map[string]string{"addr": addr, "name": "Alice", "token": "secret"})
+	if err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+	if err := app.SetActiveEnvironment(envID, true); err != nil {
+		t.Fatalf("SetActiveEnvironment failed: %v", err)
+	}
+
+	reqContext := &grpcrequest.RequestContext{
+		Timeout:     "5s",
+		Credentials: &grpcrequest.CallCredentials{Type: grpcrequest.CredentialsBearer, Token: "{{token}}"},
+	}
+	result, err := app.DoGRPCRequest(id, "{{addr}}", "testserver.AnotherService", "GetUser",
+		`{"message": "{{name}}"}`, map[string]string{"x-user": "{{name}}"}, reqContext)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.Code != 0 || !strings.Contains(result.Response, `"name":"Alice"`) {
+		t.Fatalf("expected resolved request to succeed, got %d %s %s", result.Code, result.Error, result.Response)
+	}
+
+	history, err := app.GetHistory(id, 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	item := history[0]
+	if item.Request != `{"message": "Alice"}` || item.Address != addr || !strings.Contains(item.RequestContext, `"token":"secret"`) {
+		t.Errorf("expected resolved request in history, got %q %q %q", item.Request, item.Address, item.RequestContext)
+	}
+
+	var template requestTemplate
+	if err := json.Unmarshal([]byte(item.RequestTemplate), &template); err != nil {
+		t.Fatalf("failed to unmarshal template %q: %v", item.RequestTemplate, err)
+	}
+	if template.Address != "{{addr}}" || template.Payload != `{"message": "{{name}}"}` ||
+		template.Headers["x-user"] != "{{name}}" || template.Context.Credentials.Token != "{{token}}" {
+		t.Errorf("expected template in history, got %+v", template)
+	}
+
+	// Неизвестные переменные - ошибка до отправки, тоже с записью в истории
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", `{"message": "{{missing}}"}`, map[string]string{"x": "{{other}}"}, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.ErrorKind != "template" || !strings.Contains(result.Error, "missing, other") {
+		t.Errorf("expected unresolved variables error, got %q %q", result.ErrorKind, result.Error)
+	}
+
+	history, _ = app.GetHistory(id, 1)
+	if history[0].ErrorKind != "template" || history[0].RequestTemplate == "" {
+		t.Errorf("expected failed template request in history, got %+v", history[0])
+	}
+}
+
+func TestApp_DoGRPCRequest_TemplateFunctions(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	// Функции работают и без активного окружения
+	result, err := app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser",
+		`{"message": "{{now+1h | date}}"}`, map[string]string{"x-request-id": "{{uuid}}"}, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.Code != 0 || !strings.Contains(result.Response, time.Now().Add(time.Hour).UTC().Format(time.DateOnly)) {
+		t.Errorf("expected rendered date in response, got %d %s %s", result.Code, result.Error, result.Response)
+	}
+
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser",
+		"{\n  \"message\": \"{{randomInt 10}}\"\n}", nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.ErrorKind != "template" || !strings.Contains(result.Error, "{{randomInt 10}} at line 2, column 15") {
+		t.Errorf("expected error pointing at placeholder, got %q %q", result.ErrorKind, result.Error)
+	}
+}
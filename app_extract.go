@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"grpc-gui/internal/extract"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/templating"
+)
+
+// GetExtractionRules возвращает правила извлечения метода в порядке выполнения.
+func (a *App) GetExtractionRules(serverId uint, service, method string) ([]extract.Rule, error) {
+	stored, err := a.storage.GetExtractionRules(serverId, service, method)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]extract.Rule, 0, len(stored))
+	for _, rule := range stored {
+		rules = append(rules, extract.Rule{
+			Variable:   rule.Variable,
+			Source:     extract.Source(rule.Source),
+			Expression: rule.Expression,
+		})
+	}
+	return rules, nil
+}
+
+// SaveExtractionRules заменяет правила метода. Пустой список удаляет все правила.
+func (a *App) SaveExtractionRules(serverId uint, service, method string, rules []extract.Rule) error {
+	stored := make([]models.ExtractionRule, 0, len(rules))
+	for i, rule := range rules {
+		rule.Variable = strings.TrimSpace(rule.Variable)
+		rule.Expression = strings.TrimSpace(rule.Expression)
+		if !templating.ValidName(rule.Variable) {
+			return fmt.Errorf("rule %d: invalid variable name %q", i+1, rule.Variable)
+		}
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+
+		stored = append(stored, models.ExtractionRule{
+			ServerID:   serverId,
+			Service:    service,
+			Method:     method,
+			Position:   i,
+			Variable:   rule.Variable,
+			Source:     string(rule.Source),
+			Expression: rule.Expression,
+		})
+	}
+	return a.storage.ReplaceExtractionRules(serverId, service, method, stored)
+}
+
+// GetExtractedVariables возвращает значения, извлеченные из ответов и заданные скриптами за время работы приложения.
+func (a *App) GetExtractedVariables() map[string]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	vars := make(map[string]string, len(a.extractedVars))
+	for key, value := range a.extractedVars {
+		vars[key] = value
+	}
+	return vars
+}
+
+func (a *App) setExtractedVariables(vars map[string]string) {
+	if len(vars) == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.extractedVars == nil {
+		a.extractedVars = make(map[string]string)
+	}
+	for key, value := range vars {
+		a.extractedVars[key] = value
+	}
+}
+
+func (a *App) ClearExtractedVariables() {
+	a.mu.Lock()
+	a.extractedVars = nil
+	a.mu.Unlock()
+}
+
+// applyExtractionRules выполняет правила метода над ответом и запоминает удачные значения.
+// Возвращает результаты правил и их JSON для истории.
+func (a *App) applyExtractionRules(scope variableScope, serverId uint, service, method string, resp extract.Response) ([]extract.Value, string, error) {
+	rules, err := a.GetExtractionRules(serverId, service, method)
+	if err != nil || len(rules) == 0 {
+		return nil, "", err
+	}
+
+	values := extract.Apply(rules, resp)
+
+	vars := make(map[string]string, len(values))
+	for _, value := range values {
+		if value.Error == "" {
+			vars[value.Variable] = value.Value
+		}
+	}
+	scope.set(vars)
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return values, "", err
+	}
+	return values, string(data), nil
+}
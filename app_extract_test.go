@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/extract"
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_ExtractionRules(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	rules := []extract.Rule{
+		{Variable: "user_name", Source: extract.SourceBody, Expression: "$.name"},
+		{Variable: "request_id", Source: extract.SourceHeader, Expression: "x-request-id"},
+	}
+	if err := app.SaveExtractionRules(1, "svc", "Method", rules); err != nil {
+		t.Fatalf("SaveExtractionRules failed: %v", err)
+	}
+
+	got, err := app.GetExtractionRules(1, "svc", "Method")
+	if err != nil || len(got) != 2 || got[0] != rules[0] || got[1] != rules[1] {
+		t.Fatalf("expected saved rules in order, got %+v %v", got, err)
+	}
+	if other, _ := app.GetExtractionRules(1, "svc", "Other"); len(other) != 0 {
+		t.Errorf("expected rules only for their method, got %+v", other)
+	}
+
+	invalid := [][]extract.Rule{
+		{{Variable: "bad name", Source: extract.SourceBody, Expression: "$.a"}},
+		{{Variable: "ok", Source: extract.SourceBody, Expression: "$..a"}},
+		{{Variable: "ok", Source: "cookie", Expression: "a"}},
+	}
+	for _, rules := range invalid {
+		if err := app.SaveExtractionRules(1, "svc", "Method", rules); err == nil {
+			t.Errorf("expected validation error for %+v", rules)
+		}
+	}
+
+	if err := app.SaveExtractionRules(1, "svc", "Method", nil); err != nil {
+		t.Fatalf("SaveExtractionRules failed: %v", err)
+	}
+	if got, _ := app.GetExtractionRules(1, "svc", "Method"); len(got) != 0 {
+		t.Errorf("expected rules to be removed, got %+v", got)
+	}
+}
+
+func TestApp_DoGRPCRequest_ExtractsVariables(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	err = app.SaveExtractionRules(id, "testserver.AnotherService", "GetUser", []extract.Rule{
+		{Variable: "session_id", Source: extract.SourceBody, Expression: ".name"},
+		{Variable: "missing", Source: extract.SourceBody, Expression: "$.nope"},
+	})
+	if err != nil {
+		t.Fatalf("SaveExtractionRules failed: %v", err)
+	}
+	err = app.SaveExtractionRules(id, "testserver.TestService", "ScheduleTask", []extract.Rule{
+		{Variable: "request_id", Source: extract.SourceHeader, Expression: "X-Request-Id"},
+		{Variable: "reason", Source: extract.SourceTrailer, Expression: "x-retry-reason"},
+	})
+	if err != nil {
+		t.Fatalf("SaveExtractionRules failed: %v", err)
+	}
+
+	result, err := app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", `{"message": "s-42"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if len(result.Extracted) != 2 || result.Extracted[0].Value != "s-42" || result.Extracted[1].Error == "" {
+		t.Fatalf("unexpected extracted values: %+v", result.Extracted)
+	}
+
+	history, err := app.GetHistory(id, 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	var stored []extract.Value
+	if err := json.Unmarshal([]byte(history[0].Extracted), &stored); err != nil || len(stored) != 2 || stored[0].Value != "s-42" {
+		t.Errorf("expected extracted values in history, got %q", history[0].Extracted)
+	}
+
+	// Следующий запрос использует извлеченное значение
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", `{"message": "{{session_id}}-next"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if !strings.Contains(result.Response, `"name":"s-42-next"`) {
+		t.Errorf("expected extracted variable in request, got %s %s", result.Error, result.Response)
+	}
+
+	// Заголовки и трейлеры извлекаются и из ответа с ошибкой
+	result, err = app.DoGRPCRequest(id, addr, "testserver.TestService", "ScheduleTask", `{}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	vars := app.GetExtractedVariables()
+	if vars["request_id"] != "1,2" || vars["reason"] != "quota" || vars["session_id"] != "s-42-next" {
+		t.Errorf("unexpected extracted variables: %v", vars)
+	}
+	if _, ok := vars["missing"]; ok {
+		t.Error("expected failed rule not to set a variable")
+	}
+
+	app.ClearExtractedVariables()
+	if vars := app.GetExtractedVariables(); len(vars) != 0 {
+		t.Errorf("expected no extracted variables after clear, got %v", vars)
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/health"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/utils"
+)
+
+// ServerHealth - текущий статус сервера и история проверок для доски статусов.
+type ServerHealth struct {
+	ServerID  uint       `json:"serverId"`
+	Status    string     `json:"status"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	// Uptime - доля проверок со статусом SERVING, от 0 до 1. Серверы без grpc.health.v1 не учитываются
+	Uptime  float64              `json:"uptime"`
+	History []models.HealthCheck `json:"history"`
+}
+
+type healthWatch struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// CheckServerHealth сразу опрашивает grpc.health.v1.Health/Check сервера.
+func (a *App) CheckServerHealth(serverId uint) (*models.HealthCheck, error) {
+	server, err := a.storage.GetServer(serverId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.HealthCheckTimeout)
+	defer cancel()
+
+	check := a.probeServerHealth(ctx, *server)
+	return &check, nil
+}
+
+// GetServerHealth возвращает статус сервера и последние проверки, новые первыми.
+func (a *App) GetServerHealth(serverId uint, limit int) (*ServerHealth, error) {
+	server, err := a.storage.GetServer(serverId)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := a.storage.GetHealthChecks(serverId, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ServerHealth{
+		ServerID: serverId,
+		Status:   server.HealthStatus,
+		History:  checks,
+	}
+	if !server.HealthCheckedAt.IsZero() {
+		result.CheckedAt = &server.HealthCheckedAt
+	}
+
+	var counted, serving int
+	for _, check := range checks {
+		if check.Status == string(health.StatusUnimplemented) {
+			continue
+		}
+		counted++
+		if check.Status == string(health.StatusServing) {
+			serving++
+		}
+	}
+	if counted > 0 {
+		result.Uptime = float64(serving) / float64(counted)
+	}
+
+	return result, nil
+}
+
+// SetServerHealthService задает имя сервиса для проверок, пустое - статус сервера целиком.
+func (a *App) SetServerHealthService(serverId uint, service string) error {
+	if err := a.storage.UpdateHealthService(serverId, service); err != nil {
+		return err
+	}
+
+	// Подписку открытого сервера перезапускаем уже с новым сервисом
+	a.restartHealthWatch(serverId)
+	return nil
+}
+
+// restartHealthWatch отписывается от статуса сервера и подписывается заново, если сервер открыт.
+func (a *App) restartHealthWatch(serverID uint) {
+	a.mu.Lock()
+	watch, ok := a.healthWatches[serverID]
+	delete(a.healthWatches, serverID)
+	a.mu.Unlock()
+
+	// Иначе старый Watch успеет записать проверку с прежним адресом или сервисом уже после нового
+	if ok {
+		watch.cancel()
+		<-watch.done
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.syncHealthWatches()
+}
+
+// stopHealthWatch закрывает сервер для подписок и дожидается завершения его Watch.
+func (a *App) stopHealthWatch(serverID uint) {
+	a.mu.Lock()
+	watch, ok := a.healthWatches[serverID]
+	delete(a.healthWatches, serverID)
+	delete(a.openServers, serverID)
+	a.mu.Unlock()
+
+	if ok {
+		watch.cancel()
+		<-watch.done
+	}
+}
+
+func (a *App) probeServerHealth(ctx context.Context, server models.Server) models.HealthCheck {
+	conn, err := utils.CreateGRPCConnect(server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
+	if err != nil {
+		return a.recordHealth(server, health.Result{
+			Status: health.StatusUnreachable,
+			Error:  utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure),
+		})
+	}
+	defer conn.Close()
+
+	return a.recordHealth(server, health.Check(ctx, conn, server.HealthService))
+}
+
+func (a *App) recordHealth(server models.Server, result health.Result) models.HealthCheck {
+	check := models.HealthCheck{
+		CreatedAt: time.Now(),
+		ServerID:  server.ID,
+		Service:   server.HealthService,
+		Status:    string(result.Status),
+		LatencyMs: int32(result.Latency.Milliseconds()),
+		Error:     result.Error,
+	}
+
+	_ = a.storage.RecordHealthCheck(&check, consts.MaxHealthChecksPerServer)
+	a.emit(consts.EventServerHealth, check)
+
+	return check
+}
+
+// checkServersHealth опрашивает серверы параллельно, пропуская те, за которыми уже следит Watch.
+func (a *App) checkServersHealth() {
+	servers, err := a.storage.GetServers()
+	if err != nil {
+		return
+	}
+
+	workers := make(chan struct{}, consts.ReflectionRefreshWorkers)
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		if a.isHealthWatched(server.ID) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(server models.Server) {
+			defer wg.Done()
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), consts.HealthCheckTimeout)
+			defer cancel()
+
+			a.probeServerHealth(ctx, server)
+		}(server)
+	}
+	wg.Wait()
+}
+
+// startHealthScheduler периодически проверяет доступность всех серверов.
+func (a *App) startHealthScheduler(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		a.checkServersHealth()
+		for {
+			select {
+			case <-ticker.C:
+				a.checkServersHealth()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+		a.stopHealthWatches()
+	}
+}
+
+func (a *App) isHealthWatched(serverID uint) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	watch, ok := a.healthWatches[serverID]
+	if !ok {
+		return false
+	}
+	select {
+	case <-watch.done:
+		// Сервер не поддерживает Watch - проверяется по расписанию
+		return false
+	default:
+		return true
+	}
+}
+
+// syncHealthWatches подписывается на статус открытых серверов и отписывается от закрытых.
+// Вызывается под a.mu.
+func (a *App) syncHealthWatches() {
+	if a.healthWatches == nil {
+		a.healthWatches = make(map[uint]*healthWatch)
+	}
+
+	for id, watch := range a.healthWatches {
+		if !a.openServers[id] {
+			watch.cancel()
+			delete(a.healthWatches, id)
+		}
+	}
+
+	for id := range a.openServers {
+		if _, ok := a.healthWatches[id]; ok {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		watch := &healthWatch{cancel: cancel, done: make(chan struct{})}
+		a.healthWatches[id] = watch
+
+		go func(id uint) {
+			defer close(watch.done)
+			a.watchServerHealth(ctx, id)
+		}(id)
+	}
+}
+
+func (a *App) watchServerHealth(ctx context.Context, serverID uint) {
+	for {
+		server, err := a.storage.GetServer(serverID)
+		if err != nil {
+			return
+		}
+
+		conn, err := utils.CreateGRPCConnect(server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
+		if err == nil {
+			err = health.Watch(ctx, conn, server.HealthService, func(result health.Result) {
+				a.recordHealth(*server, result)
+			})
+			conn.Close()
+		}
+
+		if ctx.Err() != nil || errors.Is(err, health.ErrUnimplemented) {
+			return
+		}
+
+		// Поток оборвался - переподключаемся, пока сервер открыт
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(consts.HealthWatchRetryDelay):
+		}
+	}
+}
+
+func (a *App) stopHealthWatches() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for id, watch := range a.healthWatches {
+		watch.cancel()
+		delete(a.healthWatches, id)
+	}
+}
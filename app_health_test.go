@@ -0,0 +1,231 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/health"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/testutil"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestApp_CheckServerHealth(t *testing.T) {
+	addr, healthServer, stop := testutil.StartHealthTestServer(t)
+	defer stop()
+	healthServer.SetServingStatus("testserver.TestService", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+	recorded := recordEvents(app)
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	check, err := app.CheckServerHealth(id)
+	if err != nil || check.Status != string(health.StatusServing) {
+		t.Fatalf("expected SERVING, got %+v, %v", check, err)
+	}
+
+	if err := app.SetServerHealthService(id, "testserver.TestService"); err != nil {
+		t.Fatalf("SetServerHealthService failed: %v", err)
+	}
+	check, err = app.CheckServerHealth(id)
+	if err != nil || check.Status != string(health.StatusNotServing) || check.Service != "testserver.TestService" {
+		t.Fatalf("expected NOT_SERVING for service, got %+v, %v", check, err)
+	}
+
+	// Статус виден в списке серверов
+	servers, err := app.GetServers()
+	if err != nil || len(servers) != 1 {
+		t.Fatalf("GetServers failed: %v", err)
+	}
+	if servers[0].HealthStatus != string(health.StatusNotServing) || servers[0].HealthCheckedAt.IsZero() {
+		t.Errorf("expected health status in server list, got %q at %v", servers[0].HealthStatus, servers[0].HealthCheckedAt)
+	}
+
+	summary, err := app.GetServerHealth(id, 0)
+	if err != nil {
+		t.Fatalf("GetServerHealth failed: %v", err)
+	}
+	if len(summary.History) != 2 || summary.History[0].Status != string(health.StatusNotServing) {
+		t.Errorf("expected two checks with newest first, got %+v", summary.History)
+	}
+	if summary.Uptime != 0.5 {
+		t.Errorf("expected uptime 0.5, got %v", summary.Uptime)
+	}
+
+	if events := recorded.get(consts.EventServerHealth); len(events) != 2 {
+		t.Errorf("expected an event per check, got %d", len(events))
+	}
+}
+
+func TestApp_CheckServerHealth_Unavailable(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	check, err := app.CheckServerHealth(id)
+	if err != nil || check.Status != string(health.StatusUnimplemented) {
+		t.Errorf("expected UNIMPLEMENTED, got %+v, %v", check, err)
+	}
+
+	stop()
+
+	check, err = app.CheckServerHealth(id)
+	if err != nil || check.Status != string(health.StatusUnreachable) || check.Error == "" {
+		t.Errorf("expected UNREACHABLE with error, got %+v, %v", check, err)
+	}
+
+	// Серверы без grpc.health.v1 не портят аптайм
+	summary, err := app.GetServerHealth(id, 0)
+	if err != nil || summary.Uptime != 0 || len(summary.History) != 2 {
+		t.Errorf("unexpected summary: %+v, %v", summary, err)
+	}
+}
+
+func TestApp_HealthHistoryLimit(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	server := models.Server{ID: 1}
+	for i := 0; i < consts.MaxHealthChecksPerServer+5; i++ {
+		app.recordHealth(server, health.Result{Status: health.StatusServing})
+	}
+
+	checks, err := app.storage.GetHealthChecks(1, 0)
+	if err != nil {
+		t.Fatalf("GetHealthChecks failed: %v", err)
+	}
+	if len(checks) != consts.MaxHealthChecksPerServer {
+		t.Errorf("expected %d checks, got %d", consts.MaxHealthChecksPerServer, len(checks))
+	}
+}
+
+func TestApp_HealthWatchOpenServers(t *testing.T) {
+	addr, healthServer, stop := testutil.StartHealthTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+	recorded := recordEvents(app)
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	waitStatus := func(expected health.Status) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			events := recorded.get(consts.EventServerHealth)
+			if len(events) > 0 && events[len(events)-1].(models.HealthCheck).Status == string(expected) {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("expected %s from watch, got %v", expected, recorded.get(consts.EventServerHealth))
+	}
+
+	app.SetOpenServers([]uint{id})
+	waitStatus(health.StatusServing)
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	waitStatus(health.StatusNotServing)
+
+	// Пока сервер под подпиской, плановая проверка его пропускает
+	before := len(recorded.get(consts.EventServerHealth))
+	app.checkServersHealth()
+	if after := len(recorded.get(consts.EventServerHealth)); after != before {
+		t.Errorf("expected watched server to be skipped, got %d new events", after-before)
+	}
+
+	app.SetOpenServers(nil)
+	if app.isHealthWatched(id) {
+		t.Error("expected watch to stop for closed server")
+	}
+	app.checkServersHealth()
+	if after := len(recorded.get(consts.EventServerHealth)); after != before+1 {
+		t.Errorf("expected scheduled check after closing, got %d new events", after-before)
+	}
+}
+
+func TestApp_HealthWatchUpdateAndDelete(t *testing.T) {
+	addr, _, stop := testutil.StartHealthTestServer(t)
+	defer stop()
+	newAddr, newHealthServer, newStop := testutil.StartHealthTestServer(t)
+	defer newStop()
+	newHealthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+	recorded := recordEvents(app)
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	waitStatus := func(expected health.Status) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			events := recorded.get(consts.EventServerHealth)
+			if len(events) > 0 && events[len(events)-1].(models.HealthCheck).Status == string(expected) {
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		t.Fatalf("expected %s from watch, got %v", expected, recorded.get(consts.EventServerHealth))
+	}
+
+	app.SetOpenServers([]uint{id})
+	waitStatus(health.StatusServing)
+
+	// После смены адреса подписка переходит на новый сервер
+	if err := app.UpdateServer(id, "Test Server", newAddr, false, false); err != nil {
+		t.Fatalf("UpdateServer failed: %v", err)
+	}
+	waitStatus(health.StatusNotServing)
+	if !app.isHealthWatched(id) {
+		t.Error("expected watch to restart for open server")
+	}
+
+	// После смены сервиса старая подписка больше ничего не записывает
+	newHealthServer.SetServingStatus("testserver.TestService", healthpb.HealthCheckResponse_SERVING)
+	if err := app.SetServerHealthService(id, "testserver.TestService"); err != nil {
+		t.Fatalf("SetServerHealthService failed: %v", err)
+	}
+	changed := len(recorded.get(consts.EventServerHealth))
+	waitStatus(health.StatusServing)
+	for _, event := range recorded.get(consts.EventServerHealth)[changed:] {
+		if check := event.(models.HealthCheck); check.Service != "testserver.TestService" {
+			t.Errorf("expected checks of the new service only, got %+v", check)
+		}
+	}
+
+	if err := app.DeleteServer(id); err != nil {
+		t.Fatalf("DeleteServer failed: %v", err)
+	}
+	if app.isHealthWatched(id) {
+		t.Error("expected watch to stop for deleted server")
+	}
+
+	before := len(recorded.get(consts.EventServerHealth))
+	newHealthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	time.Sleep(100 * time.Millisecond)
+	if after := len(recorded.get(consts.EventServerHealth)); after != before {
+		t.Errorf("expected no checks for deleted server, got %d new events", after-before)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/runner"
+)
+
+// RunOptions - настройки прогона коллекции.
+type RunOptions struct {
+	// EnvironmentID - окружение прогона, 0 - активные окружения, как при обычной отправке
+	EnvironmentID uint `json:"environmentId"`
+	// DataName и Data - имя и содержимое файла данных (.csv или .json), каждая строка - итерация
+	DataName      string `json:"dataName"`
+	Data          string `json:"data"`
+	StopOnFailure bool   `json:"stopOnFailure"`
+}
+
+// RunProgress - событие о выполненном запросе прогона.
+type RunProgress struct {
+	CollectionID uint          `json:"collectionId"`
+	Iteration    int           `json:"iteration"`
+	Result       runner.Result `json:"result"`
+}
+
+// RunCollection прогоняет запросы коллекции по порядку, результаты приходят событиями по мере выполнения.
+func (a *App) RunCollection(collectionId uint, opts RunOptions) (*runner.Report, error) {
+	var data []map[string]string
+	if opts.Data != "" {
+		var err error
+		data, err = runner.ParseData(opts.DataName, []byte(opts.Data))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a.runCollection(context.Background(), collectionId, opts.EnvironmentID, runner.Options{
+		Data:          data,
+		StopOnFailure: opts.StopOnFailure,
+		OnResult: func(iteration int, result runner.Result) {
+			a.emit(consts.EventCollectionRun, RunProgress{CollectionID: collectionId, Iteration: iteration, Result: result})
+		},
+	})
+}
+
+// FormatRunReport печатает отчет прогона в формате "junit" или "json".
+func (a *App) FormatRunReport(report *runner.Report, format string) (string, error) {
+	var buf bytes.Buffer
+	if err := report.Write(&buf, runner.Format(format)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (a *App) runCollection(ctx context.Context, collectionID, environmentID uint, opts runner.Options) (*runner.Report, error) {
+	collection, err := a.storage.GetCollection(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := a.storage.GetFolders(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := a.storage.GetSavedRequests(collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := &collectionExecutor{app: a}
+	if environmentID != 0 {
+		if executor.environment, err = a.storage.GetEnvironment(environmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	return runner.Run(ctx, collection.Name, runner.Plan(folders, requests), executor, opts), nil
+}
+
+// collectionExecutor отправляет запросы прогона тем же путем, что и GUI: с историей, скриптами и проверками.
+type collectionExecutor struct {
+	app *App
+	// environment - выбранное окружение прогона, nil - активные окружения сервера
+	environment *models.Environment
+}
+
+func (e *collectionExecutor) Execute(request *models.SavedRequest, vars map[string]string) (*runner.Outcome, error) {
+	scope := &runScope{app: e.app, environment: e.environment, vars: make(map[string]string, len(vars)), updated: make(map[string]string)}
+	for key, value := range vars {
+		scope.vars[key] = value
+	}
+
+	result, err := e.app.doRequest(scope, request.ServerID, request.Address, request.Service, request.Method, request.Payload, request.Headers, savedRequestContext(request.Context), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runner.Outcome{
+		Code:          codes.Code(result.Code),
+		StatusMessage: result.StatusMessage,
+		ErrorKind:     result.ErrorKind,
+		Error:         result.Error,
+		Response:      result.Response,
+		Duration:      time.Duration(result.ExecutionTime) * time.Millisecond,
+		Assertions:    result.Assertions,
+		Vars:          scope.updated,
+	}, nil
+}
+
+// runScope - переменные прогона: окружение прогона, поверх него строка данных и значения,
+// извлеченные из предыдущих ответов прогона. Извлеченные значения GUI в прогон не попадают.
+type runScope struct {
+	app         *App
+	environment *models.Environment
+	vars        map[string]string
+	// updated - значения, заданные во время запроса, они передаются следующим запросам
+	updated map[string]string
+}
+
+func (s *runScope) variables(serverId uint) (map[string]string, error) {
+	vars := make(map[string]string)
+	if s.environment != nil {
+		for key, value := range s.environment.Variables {
+			vars[key] = value
+		}
+	} else {
+		active, err := s.app.activeVariables(serverId)
+		if err != nil {
+			return nil, err
+		}
+		vars = active
+	}
+
+	for key, value := range s.vars {
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+func (s *runScope) set(vars map[string]string) {
+	for key, value := range vars {
+		s.vars[key] = value
+		s.updated[key] = value
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/extract"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/runner"
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_RunCollection(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	serverID, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+	err = app.SaveExtractionRules(serverID, "testserver.AnotherService", "GetUser", []extract.Rule{
+		{Variable: "session", Source: extract.SourceBody, Expression: "$.name"},
+	})
+	if err != nil {
+		t.Fatalf("SaveExtractionRules failed: %v", err)
+	}
+
+	collectionID, err := app.CreateCollection("Users API", "")
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	folderID, err := app.CreateFolder(collectionID, 0, "users")
+	if err != nil {
+		t.Fatalf("CreateFolder failed: %v", err)
+	}
+
+	// login сохраняет session, запросы из папки его используют
+	login := savedRequest(collectionID, 0, serverID, "login")
+	next := savedRequest(collectionID, folderID, serverID, "next")
+	next.Payload = `{"message": "{{session}}-2"}`
+	next.Assertions = []models.SavedAssertion{{Kind: string(assertion.KindBody), Path: "$.name", Operator: string(assertion.OpMatches), Expected: "^[a-z]+-2$"}}
+	broken := savedRequest(collectionID, folderID, serverID, "broken")
+	broken.Assertions = []models.SavedAssertion{{Kind: string(assertion.KindBody), Path: "$.name", Operator: string(assertion.OpEquals), Expected: "nobody"}}
+	last := savedRequest(collectionID, folderID, serverID, "last")
+	for _, request := range []models.SavedRequest{next, broken, last, login} {
+		if _, err := app.CreateSavedRequest(request); err != nil {
+			t.Fatalf("CreateSavedRequest failed: %v", err)
+		}
+	}
+
+	report, err := app.RunCollection(collectionID, RunOptions{DataName: "users.csv", Data: "name\nalice\nbob\n"})
+	if err != nil {
+		t.Fatalf("RunCollection failed: %v", err)
+	}
+	if report.Summary != (runner.Summary{Total: 8, Passed: 4, Failed: 4}) || report.Stopped || len(report.Iterations) != 2 {
+		t.Fatalf("unexpected summary: %+v stopped=%v", report.Summary, report.Stopped)
+	}
+	results := report.Iterations[1].Results
+	if results[0].Path != "users / next" || results[3].Path != "login" {
+		t.Errorf("expected folder before root request, got %q ... %q", results[0].Path, results[3].Path)
+	}
+	// Первый запрос папки идет раньше login, поэтому session в нем еще не задана
+	if results[0].Status != runner.StatusFailed || results[0].ErrorKind != "template" {
+		t.Errorf("expected template error without session, got %+v", results[0])
+	}
+	if !strings.Contains(results[3].Response, `"name":"bob"`) {
+		t.Errorf("expected data row in request, got %s", results[3].Response)
+	}
+
+	// Переменные извлекаются внутри прогона и не попадают в переменные GUI
+	if vars := app.GetExtractedVariables(); len(vars) != 0 {
+		t.Errorf("expected run variables to stay in the run, got %v", vars)
+	}
+
+	if err := app.MoveFolder(folderID, 0, 1); err != nil {
+		t.Fatalf("MoveFolder failed: %v", err)
+	}
+	envID, err := app.CreateEnvironment("ci", 0, map[string]string{"name": "carol"})
+	if err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+	report, err = app.RunCollection(collectionID, RunOptions{EnvironmentID: envID, StopOnFailure: true})
+	if err != nil {
+		t.Fatalf("RunCollection failed: %v", err)
+	}
+	if report.Summary != (runner.Summary{Total: 4, Passed: 2, Failed: 1, Skipped: 1}) || !report.Stopped {
+		t.Fatalf("expected run to stop on broken, got %+v", report.Summary)
+	}
+	results = report.Iterations[0].Results
+	if !strings.Contains(results[1].Response, `"name":"carol-2"`) || results[2].Assertions[0].Actual != "carol" {
+		t.Errorf("expected chained session from environment, got %+v", results)
+	}
+
+	history, err := app.GetHistory(serverID, 100)
+	if err != nil || len(history) != 11 {
+		t.Errorf("expected run requests in history, got %d %v", len(history), err)
+	}
+
+	junit, err := app.FormatRunReport(report, "junit")
+	if err != nil || !strings.Contains(junit, `<testcase name="users / broken" classname="testserver.AnotherService/GetUser"`) {
+		t.Errorf("unexpected junit report: %s %v", junit, err)
+	}
+	if _, err := app.FormatRunReport(report, "html"); err == nil {
+		t.Error("expected unknown format error")
+	}
+	if _, err := app.RunCollection(collectionID, RunOptions{DataName: "users.txt", Data: "name"}); err == nil {
+		t.Error("expected unsupported data file error")
+	}
+}
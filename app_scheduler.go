@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/models"
+)
+
+// SchemaChange - событие об изменении схемы сервера после обновления рефлексии.
+type SchemaChange struct {
+	ServerID   uint                   `json:"serverId"`
+	ServerName string                 `json:"serverName"`
+	OldHash    string                 `json:"oldHash"`
+	NewHash    string                 `json:"newHash"`
+	Diff       grpcreflect.SchemaDiff `json:"diff"`
+}
+
+// detectSchemaChange сравнивает новую схему с закешированной.
+// Детали методов сбрасываются только если схема реально поменялась.
+func (a *App) detectSchemaChange(server models.Server, current *grpcreflect.ServicesInfo) {
+	var previous grpcreflect.ServicesInfo
+	if server.ReflectionCache == "" || json.Unmarshal([]byte(server.ReflectionCache), &previous) != nil {
+		a.methodCache.Invalidate(server.ID)
+		return
+	}
+
+	if previous.SchemaHash == current.SchemaHash {
+		return
+	}
+
+	a.methodCache.Invalidate(server.ID)
+
+	// Кеш старого формата без хешей сравнивать не с чем
+	if previous.SchemaHash == "" {
+		return
+	}
+
+	diff := grpcreflect.DiffServices(&previous, current)
+	if diff.IsEmpty() {
+		return
+	}
+
+	a.emit(consts.EventSchemaChanged, SchemaChange{
+		ServerID:   server.ID,
+		ServerName: server.Name,
+		OldHash:    previous.SchemaHash,
+		NewHash:    current.SchemaHash,
+		Diff:       diff,
+	})
+}
+
+// SetOpenServers сообщает, какие серверы открыты во вкладках.
+// Их схема, как и схема избранных серверов, обновляется в фоне, а статус отслеживается через Health/Watch.
+func (a *App) SetOpenServers(ids []uint) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.openServers = make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		a.openServers[id] = true
+	}
+	a.syncHealthWatches()
+}
+
+func (a *App) watchedServers() ([]models.Server, error) {
+	servers, err := a.storage.GetServers()
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var watched []models.Server
+	for _, server := range servers {
+		if server.Favorite || a.openServers[server.ID] {
+			watched = append(watched, server)
+		}
+	}
+	return watched, nil
+}
+
+func (a *App) refreshWatchedServers() {
+	servers, err := a.watchedServers()
+	if err != nil || len(servers) == 0 {
+		return
+	}
+	a.refreshServersReflection(servers, true)
+}
+
+// startReflectionScheduler периодически обновляет рефлексию открытых и избранных серверов.
+func (a *App) startReflectionScheduler(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				a.refreshWatchedServers()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+	}
+}
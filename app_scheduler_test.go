@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/testutil"
+)
+
+type recordedEvents struct {
+	mu     sync.Mutex
+	events map[string][]any
+}
+
+func recordEvents(app *App) *recordedEvents {
+	recorded := &recordedEvents{events: make(map[string][]any)}
+	app.emit = func(name string, data any) {
+		recorded.mu.Lock()
+		defer recorded.mu.Unlock()
+		recorded.events[name] = append(recorded.events[name], data)
+	}
+	return recorded
+}
+
+func (r *recordedEvents) get(name string) []any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events[name]
+}
+
+func TestApp_SchemaChangeEvent(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+	recorded := recordEvents(app)
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	result := loadServerSchema(t, app, id)
+	if result.Status != ReflectionStatusOK {
+		t.Fatalf("expected fresh reflection, got %+v", result)
+	}
+
+	// Повторное обновление без изменений события не дает
+	loadServerSchema(t, app, id)
+	if events := recorded.get(consts.EventSchemaChanged); len(events) != 0 {
+		t.Fatalf("expected no schema change events, got %v", events)
+	}
+
+	// Подменяем кеш так, будто раньше у SimpleCall была другая схема, а ComplexCall не было
+	previous := *result.Reflection
+	previous.SchemaHash = "old"
+	previous.Services = nil
+	for _, service := range result.Reflection.Services {
+		var methods []grpcreflect.MethodInfo
+		for _, method := range service.Methods {
+			switch {
+			case service.Name == "testserver.TestService" && method.Name == "SimpleCall":
+				method.SchemaHash = "old"
+			case service.Name == "testserver.TestService" && method.Name == "ComplexCall":
+				continue
+			}
+			methods = append(methods, method)
+		}
+		service.Methods = methods
+		previous.Services = append(previous.Services, service)
+	}
+	cache, _ := json.Marshal(previous)
+	if err := app.storage.UpdateReflectionCache(id, string(cache), nil); err != nil {
+		t.Fatalf("UpdateReflectionCache failed: %v", err)
+	}
+
+	loadServerSchema(t, app, id)
+
+	events := recorded.get(consts.EventSchemaChanged)
+	if len(events) != 1 {
+		t.Fatalf("expected one schema change event, got %d", len(events))
+	}
+	change := events[0].(SchemaChange)
+	if change.ServerID != id || change.OldHash != "old" || change.NewHash != result.Reflection.SchemaHash {
+		t.Errorf("unexpected change: %+v", change)
+	}
+	if len(change.Diff.ChangedMethods) != 1 || change.Diff.ChangedMethods[0] != "testserver.TestService/SimpleCall" {
+		t.Errorf("expected SimpleCall to be changed, got %v", change.Diff.ChangedMethods)
+	}
+	if len(change.Diff.AddedMethods) != 1 || change.Diff.AddedMethods[0] != "testserver.TestService/ComplexCall" {
+		t.Errorf("expected ComplexCall to be added, got %v", change.Diff.AddedMethods)
+	}
+}
+
+func TestApp_RefreshWatchedServers(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+	recorded := recordEvents(app)
+
+	openID, _ := app.CreateServer("Open", addr, false, false)
+	favoriteID, _ := app.CreateServer("Favorite", addr, false, false)
+	if _, err := app.CreateServer("Idle", addr, false, false); err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+	if err := app.ToggleFavoriteServer(favoriteID); err != nil {
+		t.Fatalf("ToggleFavoriteServer failed: %v", err)
+	}
+
+	app.SetOpenServers([]uint{openID})
+	app.refreshWatchedServers()
+
+	refreshed := make(map[uint]bool)
+	for _, event := range recorded.get(consts.EventServerReflection) {
+		refreshed[event.(ServerWithReflection).Server.ID] = true
+	}
+
+	if len(refreshed) != 2 || !refreshed[openID] || !refreshed[favoriteID] {
+		t.Errorf("expected only open and favorite servers to be refreshed, got %v", refreshed)
+	}
+}
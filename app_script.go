@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/scripting"
+)
+
+// GetMethodScript возвращает скрипты метода, пустые, если они не заданы.
+func (a *App) GetMethodScript(serverId uint, service, method string) (*models.MethodScript, error) {
+	return a.storage.GetMethodScript(serverId, service, method)
+}
+
+// SaveMethodScript проверяет синтаксис и сохраняет скрипты метода. Два пустых скрипта удаляют запись.
+func (a *App) SaveMethodScript(serverId uint, service, method, preRequest, postResponse string) error {
+	if err := scripting.Check(preRequest); err != nil {
+		return fmt.Errorf("pre-request script: %w", err)
+	}
+	if err := scripting.Check(postResponse); err != nil {
+		return fmt.Errorf("post-response script: %w", err)
+	}
+
+	return a.storage.SaveMethodScript(&models.MethodScript{
+		ServerID:     serverId,
+		Service:      service,
+		Method:       method,
+		PreRequest:   preRequest,
+		PostResponse: postResponse,
+	})
+}
+
+// runScript выполняет скрипт с текущими переменными сервера. Переменные, заданные через vars.set,
+// сохраняются только при успешном выполнении. Ошибка скрипта добавляется в логи.
+func (a *App) runScript(scope variableScope, serverId uint, phase scripting.Phase, source string, env *scripting.Env) ([]scripting.LogEntry, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	vars, err := scope.variables(serverId)
+	if err != nil {
+		return nil, err
+	}
+	env.Vars = vars
+
+	outcome, err := scripting.Run(phase, source, env, scripting.DefaultTimeout)
+	if err != nil {
+		err = fmt.Errorf("%s script: %w", phase, err)
+		return append(outcome.Logs, scripting.LogEntry{Phase: phase, Level: "error", Message: err.Error()}), err
+	}
+
+	scope.set(outcome.Vars)
+	return outcome.Logs, nil
+}
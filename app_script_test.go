@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/scripting"
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_MethodScript(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	if err := app.SaveMethodScript(1, "svc", "Method", "function (", ""); err == nil {
+		t.Error("expected syntax error in pre-request script")
+	}
+	if err := app.SaveMethodScript(1, "svc", "Method", "console.log(1)", "console.log(2)"); err != nil {
+		t.Fatalf("SaveMethodScript failed: %v", err)
+	}
+	// Повторное сохранение заменяет скрипты
+	if err := app.SaveMethodScript(1, "svc", "Method", "", "console.log(3)"); err != nil {
+		t.Fatalf("SaveMethodScript failed: %v", err)
+	}
+
+	script, err := app.GetMethodScript(1, "svc", "Method")
+	if err != nil || script.PreRequest != "" || script.PostResponse != "console.log(3)" {
+		t.Fatalf("unexpected script: %+v %v", script, err)
+	}
+
+	if err := app.SaveMethodScript(1, "svc", "Method", "", ""); err != nil {
+		t.Fatalf("SaveMethodScript failed: %v", err)
+	}
+	if script, _ := app.GetMethodScript(1, "svc", "Method"); script.ID != 0 {
+		t.Errorf("expected empty scripts to remove the record, got %+v", script)
+	}
+}
+
+func TestApp_DoGRPCRequest_Scripts(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	preRequest := `
+		const body = request.json();
+		body.message = body.message + "-" + vars.get("suffix");
+		request.setJSON(body);
+		request.metadata["x-signature"] = crypto.hmac("sha256", "key", request.payload);
+		console.log("payload", request.payload);
+	`
+	postResponse := `
+		const user = response.json();
+		vars.set("user_name", user.name);
+		console.info(response.status, user.name);
+	`
+	if err := app.SaveMethodScript(id, "testserver.AnotherService", "GetUser", preRequest, postResponse); err != nil {
+		t.Fatalf("SaveMethodScript failed: %v", err)
+	}
+
+	envID, err := app.CreateEnvironment("dev", 0, map[string]string{"suffix": "signed"})
+	if err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+	if err := app.SetActiveEnvironment(envID, true); err != nil {
+		t.Fatalf("SetActiveEnvironment failed: %v", err)
+	}
+
+	result, err := app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", `{"message": "alice"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.Code != 0 || !strings.Contains(result.Response, `"name":"alice-signed"`) {
+		t.Fatalf("expected script to modify the payload, got %d %s %s", result.Code, result.Error, result.Response)
+	}
+	if len(result.ScriptLogs) != 2 || result.ScriptLogs[0].Phase != scripting.PhasePreRequest ||
+		result.ScriptLogs[1].Message != "OK alice-signed" {
+		t.Errorf("unexpected script logs: %+v", result.ScriptLogs)
+	}
+	if vars := app.GetExtractedVariables(); vars["user_name"] != "alice-signed" {
+		t.Errorf("expected post-response script to set a variable, got %v", vars)
+	}
+
+	history, err := app.GetHistory(id, 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	item := history[0]
+	if item.Request != `{"message":"alice-signed"}` || !strings.Contains(item.RequestHeaders, "x-signature") {
+		t.Errorf("expected modified request in history, got %q %q", item.Request, item.RequestHeaders)
+	}
+	var logs []scripting.LogEntry
+	if err := json.Unmarshal([]byte(item.ScriptLogs), &logs); err != nil || len(logs) != 2 {
+		t.Errorf("expected script logs in history, got %q", item.ScriptLogs)
+	}
+
+	// Ошибка pre-request скрипта останавливает запрос
+	if err := app.SaveMethodScript(id, "testserver.AnotherService", "GetUser", `throw new Error("no token")`, ""); err != nil {
+		t.Fatalf("SaveMethodScript failed: %v", err)
+	}
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", `{"message": "bob"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.ErrorKind != "script" || !strings.Contains(result.Error, "no token") || result.Response != "" {
+		t.Errorf("expected script error, got %q %q %q", result.ErrorKind, result.Error, result.Response)
+	}
+	if len(result.ScriptLogs) != 1 || result.ScriptLogs[0].Level != "error" {
+		t.Errorf("expected script error in logs, got %+v", result.ScriptLogs)
+	}
+
+	// Ошибка post-response скрипта попадает в логи, но не ломает ответ
+	if err := app.SaveMethodScript(id, "testserver.AnotherService", "GetUser", "", `response.missing.field`); err != nil {
+		t.Fatalf("SaveMethodScript failed: %v", err)
+	}
+	result, err = app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", `{"message": "carol"}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.Code != 0 || result.Error != "" || len(result.ScriptLogs) != 1 || result.ScriptLogs[0].Level != "error" {
+		t.Errorf("expected successful call with script error in logs, got %d %q %+v", result.Code, result.Error, result.ScriptLogs)
+	}
+}
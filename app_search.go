@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/search"
+)
+
+// SearchSymbols ищет сервисы, методы, сообщения, поля и перечисления по всем серверам.
+// Используется закэшированная рефлексия, сами серверы не опрашиваются.
+func (a *App) SearchSymbols(query string, limit int) ([]search.Hit, error) {
+	if limit <= 0 {
+		limit = consts.SearchDefaultLimit
+	}
+
+	index, err := a.symbolIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Search(query, limit), nil
+}
+
+func (a *App) symbolIndex() (*search.Index, error) {
+	servers, err := a.storage.GetServers()
+	if err != nil {
+		return nil, err
+	}
+
+	key := searchIndexKey(servers)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.searchIndex != nil && a.searchKey == key {
+		return a.searchIndex, nil
+	}
+
+	index := search.NewIndex()
+	for _, server := range servers {
+		if server.ReflectionCache == "" {
+			continue
+		}
+
+		var services grpcreflect.ServicesInfo
+		if err := json.Unmarshal([]byte(server.ReflectionCache), &services); err != nil {
+			continue
+		}
+		index.AddServer(server.ID, server.Name, &services, server.ReflectionDescriptors)
+	}
+
+	a.searchIndex = index
+	a.searchKey = key
+	return index, nil
+}
+
+// searchIndexKey меняется при добавлении, удалении, переименовании сервера и обновлении его схемы.
+func searchIndexKey(servers []models.Server) string {
+	var key strings.Builder
+	for _, server := range servers {
+		fmt.Fprintf(&key, "%d:%s:%d;", server.ID, server.Name, server.ReflectionSchemaAt.UnixNano())
+	}
+	return key.String()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"grpc-gui/internal/search"
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_SearchSymbols(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Users", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	// Без закэшированной схемы искать нечего
+	hits, err := app.SearchSymbols("GetUser", 0)
+	if err != nil || len(hits) != 0 {
+		t.Fatalf("expected no hits before reflection, got %+v, %v", hits, err)
+	}
+
+	loadServerSchema(t, app, id)
+
+	hits, err = app.SearchSymbols("GetUser", 0)
+	if err != nil {
+		t.Fatalf("SearchSymbols failed: %v", err)
+	}
+	if len(hits) == 0 {
+		t.Fatal("expected hits after reflection")
+	}
+	top := hits[0].Entry
+	if top.Kind != search.KindMethod || top.ServerID != id || top.ServerName != "Users" ||
+		top.Service != "testserver.AnotherService" || top.Method != "GetUser" {
+		t.Errorf("unexpected top hit: %+v", top)
+	}
+
+	// Поля доступны из сохраненных дескрипторов
+	hits, _ = app.SearchSymbols("signed_points", 1)
+	if len(hits) != 1 || hits[0].Entry.Kind != search.KindField {
+		t.Errorf("expected field hit, got %+v", hits)
+	}
+
+	// Переименование сервера пересобирает индекс
+	if err := app.UpdateServer(id, "Accounts", addr, false, false); err != nil {
+		t.Fatalf("UpdateServer failed: %v", err)
+	}
+	hits, _ = app.SearchSymbols("GetUser", 1)
+	if len(hits) != 1 || hits[0].Entry.ServerName != "Accounts" {
+		t.Errorf("expected renamed server in hits, got %+v", hits)
+	}
+
+	if err := app.DeleteServer(id); err != nil {
+		t.Fatalf("DeleteServer failed: %v", err)
+	}
+	hits, _ = app.SearchSymbols("GetUser", 0)
+	if len(hits) != 0 {
+		t.Errorf("expected no hits after delete, got %+v", hits)
+	}
+}
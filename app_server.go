@@ -3,13 +3,22 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"log"
+	"sync"
 	"time"
 
+	"grpc-gui/internal/assertion"
 	"grpc-gui/internal/consts"
+	"grpc-gui/internal/extract"
 	"grpc-gui/internal/grpcreflect"
 	"grpc-gui/internal/grpcrequest"
 	"grpc-gui/internal/models"
+	"grpc-gui/internal/openapi"
+	"grpc-gui/internal/scripting"
 	"grpc-gui/internal/utils"
+
+	"google.golang.org/grpc/codes"
 )
 
 type ValidationStatus int
@@ -44,16 +53,45 @@ func (a *App) GetServers() ([]models.Server, error) {
 	return a.storage.GetServers()
 }
 
+// ReflectionStatus отличает свежую схему от устаревшего кеша и от сервера, который ни разу не ответил.
+type ReflectionStatus string
+
+const (
+	ReflectionStatusOK          ReflectionStatus = "ok"
+	ReflectionStatusStale       ReflectionStatus = "stale"
+	ReflectionStatusUnreachable ReflectionStatus = "unreachable"
+)
+
 type ServerWithReflection struct {
 	Server     *models.Server            `json:"server"`
 	Reflection *grpcreflect.ServicesInfo `json:"reflection"`
+	Status     ReflectionStatus          `json:"status"`
 	Error      string                    `json:"error,omitempty"`
+	// SchemaAt - время получения показанной схемы, для устаревшего кеша по нему видно ее возраст
+	SchemaAt *time.Time `json:"schemaAt,omitempty"`
+	// Pending - пока есть только список сервисов и методы из прошлого кеша, полная схема придет событием
+	Pending bool `json:"pending,omitempty"`
+	// Warning - часть сервисов получить не удалось, сохраненная схема оставлена прежней
+	Warning string `json:"warning,omitempty"`
 }
 
+// getServerReflection возвращает схему сервера вместе с сигнатурами всех методов, дожидаясь полной загрузки.
+// GUI вместо этого показывает быстрый список и догружает схему в фоне.
 func (a *App) getServerReflection(ctx context.Context, server models.Server, forceRefresh bool) ServerWithReflection {
+	result := a.listServerReflection(ctx, server, forceRefresh)
+	if !result.Pending {
+		return result
+	}
+	return a.resolveServerSchema(ctx, server, result)
+}
+
+// listServerReflection отдает схему из кеша, а при обновлении - только список сервисов, полученный
+// одним вызовом ListServices. Методы уже известных сервисов берутся из прошлого кеша.
+func (a *App) listServerReflection(ctx context.Context, server models.Server, forceRefresh bool) ServerWithReflection {
 	result := ServerWithReflection{
 		Server:     &server,
 		Reflection: &grpcreflect.ServicesInfo{Services: []grpcreflect.ServiceInfo{}},
+		Status:     ReflectionStatusOK,
 	}
 
 	needsRefresh := forceRefresh || 
@@ -63,11 +101,11 @@ func (a *App) getServerReflection(ctx context.Context, server models.Server, for
 
 	if !needsRefresh {
 		if server.ReflectionError != "" {
-			result.Error = server.ReflectionError
+			applyReflectionError(&result, server, server.ReflectionError)
 		} else {
-			var cachedReflection grpcreflect.ServicesInfo
-			if err := json.Unmarshal([]byte(server.ReflectionCache), &cachedReflection); err == nil {
-				result.Reflection = &cachedReflection
+			if cached, err := cachedReflection(server); err == nil {
+				result.Reflection = cached
+				result.SchemaAt = schemaTime(server)
 			} else {
 				needsRefresh = true
 			}
@@ -82,13 +120,13 @@ func (a *App) getServerReflection(ctx context.Context, server models.Server, for
 	reflector, err := grpcreflect.NewReflector(ctx, server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
 	if err != nil {
 		errorMsg := utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure)
-		result.Error = errorMsg
-		_ = a.storage.UpdateReflectionCache(server.ID, "", errorMsg)
+		applyReflectionError(&result, server, errorMsg)
+		_ = a.storage.UpdateReflectionError(server.ID, errorMsg)
 		return result
 	}
 	defer reflector.Close()
 
-	services, err := reflector.GetAllServicesInfo()
+	services, err := reflector.ListServicesInfo()
 	if err != nil {
 		errorMsg := ""
 		if utils.IsConnectionError(err) {
@@ -96,13 +134,144 @@ func (a *App) getServerReflection(ctx context.Context, server models.Server, for
 		} else {
 			errorMsg = utils.FormatReflectionError(err)
 		}
-		result.Error = errorMsg
-		_ = a.storage.UpdateReflectionCache(server.ID, "", errorMsg)
+		applyReflectionError(&result, server, errorMsg)
+		_ = a.storage.UpdateReflectionError(server.ID, errorMsg)
+		return result
+	}
+
+	result.Reflection = filterSystemServices(services)
+	result.Pending = true
+	if cached, err := cachedReflection(server); err == nil {
+		withCachedMethods(result.Reflection, cached)
+	}
+
+	return result
+}
+
+// resolveServerSchema загружает сигнатуры методов, хеши и дескрипторы сервисов из списка и сохраняет их в кеш.
+// Если часть сервисов получить не удалось, кеш и дескрипторы не меняются, а ошибка возвращается в Warning.
+func (a *App) resolveServerSchema(ctx context.Context, server models.Server, listed ServerWithReflection) ServerWithReflection {
+	result := listed
+	result.Pending = false
+
+	reflector, err := grpcreflect.NewReflector(ctx, server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
+	if err != nil {
+		result.Warning = utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure)
+		return result
+	}
+	defer reflector.Close()
+
+	serviceNames := make([]string, 0, len(listed.Reflection.Services))
+	for _, service := range listed.Reflection.Services {
+		serviceNames = append(serviceNames, service.Name)
+	}
+
+	services, descriptors, err := reflector.ResolveSchema(serviceNames)
+	if err != nil {
+		// Неполная схема дала бы ложные изменения и затерла бы дескрипторы офлайн-режима
+		log.Printf("server %d: failed to resolve schema: %v", server.ID, err)
+		result.Warning = utils.FormatReflectionError(err)
+		result.Reflection = withCachedMethods(services, listed.Reflection)
 		return result
 	}
 
+	result.Reflection = services
+	now := time.Now()
+	result.SchemaAt = &now
+
+	a.detectSchemaChange(server, services)
+
+	reflectionJSON, err := json.Marshal(services)
+	if err == nil {
+		_ = a.storage.UpdateReflectionCache(server.ID, string(reflectionJSON), descriptors)
+	}
+
+	return result
+}
+
+// resolveServerSchemaInBackground догружает полную схему после быстрого списка и отправляет ее событием.
+// Для одного сервера одновременно идет только одна загрузка.
+func (a *App) resolveServerSchemaInBackground(server models.Server, listed ServerWithReflection) {
+	a.mu.Lock()
+	if a.resolvingSchemas[server.ID] {
+		a.mu.Unlock()
+		return
+	}
+	if a.resolvingSchemas == nil {
+		a.resolvingSchemas = make(map[uint]bool)
+	}
+	a.resolvingSchemas[server.ID] = true
+	a.mu.Unlock()
+
+	a.schemaResolves.Add(1)
+	go func() {
+		defer a.schemaResolves.Done()
+		defer func() {
+			a.mu.Lock()
+			delete(a.resolvingSchemas, server.ID)
+			a.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), consts.ReflectionSchemaTimeout)
+		defer cancel()
+
+		a.emit(consts.EventServerReflection, a.resolveServerSchema(ctx, server, listed))
+	}()
+}
+
+func cachedReflection(server models.Server) (*grpcreflect.ServicesInfo, error) {
+	var cached grpcreflect.ServicesInfo
+	if err := json.Unmarshal([]byte(server.ReflectionCache), &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+// withCachedMethods подставляет методы из cached сервисам, у которых их пока нет.
+func withCachedMethods(services, cached *grpcreflect.ServicesInfo) *grpcreflect.ServicesInfo {
+	methods := make(map[string][]grpcreflect.MethodInfo, len(cached.Services))
+	for _, service := range cached.Services {
+		methods[service.Name] = service.Methods
+	}
+	for i, service := range services.Services {
+		if len(service.Methods) == 0 && len(methods[service.Name]) > 0 {
+			services.Services[i].Methods = methods[service.Name]
+		}
+	}
+	return services
+}
+
+func schemaTime(server models.Server) *time.Time {
+	at := server.ReflectionSchemaAt
+	if at.IsZero() {
+		// Кеш, сохраненный до появления отдельного времени схемы
+		at = server.ReflectionCachedAt
+	}
+	return &at
+}
+
+// applyReflectionError заполняет результат при неудачном обновлении.
+// Последняя удачная схема не теряется и отдается как устаревшая.
+func applyReflectionError(result *ServerWithReflection, server models.Server, errorMsg string) {
+	result.Error = errorMsg
+	result.Status = ReflectionStatusUnreachable
+
+	if server.ReflectionCache == "" {
+		return
+	}
+
+	if cached, err := cachedReflection(server); err == nil {
+		result.Reflection = cached
+		result.Status = ReflectionStatusStale
+		result.SchemaAt = schemaTime(server)
+	}
+}
+
+func filterSystemServices(services *grpcreflect.ServicesInfo) *grpcreflect.ServicesInfo {
 	filteredServices := &grpcreflect.ServicesInfo{
-		Services: []grpcreflect.ServiceInfo{},
+		Services:     []grpcreflect.ServiceInfo{},
+		MessageTypes: services.MessageTypes,
+		SchemaHash:   services.SchemaHash,
 	}
 
 	for _, service := range services.Services {
@@ -111,31 +280,120 @@ func (a *App) getServerReflection(ctx context.Context, server models.Server, for
 		}
 	}
 
-	result.Reflection = filteredServices
+	return filteredServices
+}
 
-	reflectionJSON, err := json.Marshal(filteredServices)
-	if err == nil {
-		_ = a.storage.UpdateReflectionCache(server.ID, string(reflectionJSON), "")
+func (a *App) GetServersWithReflection() ([]ServerWithReflection, error) {
+	servers, err := a.storage.GetServers()
+	if err != nil {
+		return nil, err
 	}
 
-	return result
+	return a.refreshServersReflection(servers, false), nil
 }
 
-func (a *App) GetServersWithReflection() ([]ServerWithReflection, error) {
+// refreshServersReflection обновляет рефлексию серверов параллельно
+// и отправляет результат каждого сервера событием сразу по готовности.
+func (a *App) refreshServersReflection(servers []models.Server, forceRefresh bool) []ServerWithReflection {
+	serversWithReflection := make([]ServerWithReflection, len(servers))
+	workers := make(chan struct{}, consts.ReflectionRefreshWorkers)
+
+	var wg sync.WaitGroup
+	for i, server := range servers {
+		wg.Add(1)
+		go func(i int, server models.Server) {
+			defer wg.Done()
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			// У каждого сервера свой таймаут, чтобы медленный сервер не съедал время остальных
+			ctx, cancel := context.WithTimeout(context.Background(), consts.ReflectionTimeout)
+			defer cancel()
+
+			serversWithReflection[i] = a.listServerReflection(ctx, server, forceRefresh)
+			a.emit(consts.EventServerReflection, serversWithReflection[i])
+			if serversWithReflection[i].Pending {
+				a.resolveServerSchemaInBackground(server, serversWithReflection[i])
+			}
+		}(i, server)
+	}
+	wg.Wait()
+
+	return serversWithReflection
+}
+
+// GetMethodInfo возвращает сообщения, примеры и схему метода.
+// Список сервисов содержит только сигнатуры, детали загружаются при выборе метода.
+func (a *App) GetMethodInfo(serverId uint, service, method string) (*grpcreflect.MethodInfo, error) {
+	if info, ok := a.methodCache.Get(serverId, service, method); ok {
+		return info, nil
+	}
+
+	server, err := a.storage.GetServer(serverId)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	servers, err := a.storage.GetServers()
+	reflector, err := grpcreflect.NewReflector(ctx, server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
+	if err != nil {
+		return nil, errors.New(utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure))
+	}
+	defer reflector.Close()
+
+	info, err := reflector.GetMethodInfo(service, method)
 	if err != nil {
+		// Сервер недоступен - строим описание по сохраненным дескрипторам
+		if offline, offlineErr := grpcreflect.MethodInfoFromDescriptorSet(server.ReflectionDescriptors, service, method); offlineErr == nil {
+			return offline, nil
+		}
+		if utils.IsConnectionError(err) {
+			return nil, errors.New(utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure))
+		}
 		return nil, err
 	}
 
-	serversWithReflection := make([]ServerWithReflection, 0, len(servers))
-	for _, server := range servers {
-		serversWithReflection = append(serversWithReflection, a.getServerReflection(ctx, server, false))
+	a.methodCache.Put(serverId, service, method, info)
+
+	return info, nil
+}
+
+// GetServiceInfo возвращает сигнатуры методов сервиса, пока полная схема сервера еще загружается.
+// Без связи с сервером методы берутся из последней сохраненной схемы.
+func (a *App) GetServiceInfo(serverId uint, service string) (*grpcreflect.ServiceInfo, error) {
+	server, err := a.storage.GetServer(serverId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReflectionTimeout)
+	defer cancel()
+
+	reflector, err := grpcreflect.NewReflector(ctx, server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
+	if err != nil {
+		return nil, errors.New(utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure))
+	}
+	defer reflector.Close()
+
+	info, err := reflector.GetServiceInfo(service)
+	if err != nil {
+		if cached, cacheErr := cachedReflection(*server); cacheErr == nil {
+			for i := range cached.Services {
+				if cached.Services[i].Name == service && len(cached.Services[i].Methods) > 0 {
+					return &cached.Services[i], nil
+				}
+			}
+		}
+		if utils.IsConnectionError(err) {
+			return nil, errors.New(utils.FormatConnectionError(err, server.Address, server.OptUseTLS, server.OptInsecure))
+		}
+		return nil, err
 	}
 
-	return serversWithReflection, nil
+	return info, nil
 }
 
 func (a *App) GetServerWithReflection(id uint) (*ServerWithReflection, error) {
@@ -144,14 +402,20 @@ func (a *App) GetServerWithReflection(id uint) (*ServerWithReflection, error) {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReflectionTimeout)
 	defer cancel()
 
-	result := a.getServerReflection(ctx, *server, true)
+	result := a.listServerReflection(ctx, *server, true)
+	if result.Pending {
+		a.resolveServerSchemaInBackground(*server, result)
+	}
 	return &result, nil
 }
 
 func (a *App) DeleteServer(id uint) error {
+	// Иначе Watch продолжит писать проверки удаленного сервера
+	a.stopHealthWatch(id)
+	a.methodCache.Invalidate(id)
 	return a.storage.DeleteServer(id)
 }
 
@@ -163,7 +427,14 @@ func (a *App) UpdateServer(id uint, name, address string, useTLS, insecure bool)
 		OptUseTLS:   useTLS,
 		OptInsecure: insecure,
 	}
-	return a.storage.UpdateServer(server)
+	a.methodCache.Invalidate(id)
+	if err := a.storage.UpdateServer(server); err != nil {
+		return err
+	}
+
+	// Подписка держит соединение со старым адресом
+	a.restartHealthWatch(id)
+	return nil
 }
 
 func (a *App) GetServerReflection(id uint) (*models.Server, error) {
@@ -193,56 +464,264 @@ func (a *App) GetJsonExample(msg *grpcreflect.MessageInfo) (string, error) {
 	return string(json), nil
 }
 
-func (a *App) DoGRPCRequest(serverId uint, address, service, method, payload string, requestHeaders, contextValues map[string]string) (string, int32, error) {
+func (a *App) GetFakeJsonExample(msg *grpcreflect.MessageInfo, seed int64) (string, error) {
+	json, err := grpcreflect.GenerateFakeJSONExample(msg, seed)
+	if err != nil {
+		return "{}", err
+	}
+
+	return string(json), nil
+}
+
+// GetJsonExampleForOneofs возвращает шаблон запроса с выбранными вариантами oneof.
+// Ключ выбора - "<полное имя сообщения>.<группа>", значение - имя поля.
+func (a *App) GetJsonExampleForOneofs(msg *grpcreflect.MessageInfo, selection map[string]string) string {
+	return grpcreflect.GenerateJSONExampleWithCommentsSelection(msg, selection)
+}
+
+// GetAnyTypeExample возвращает пример значения google.protobuf.Any для выбранного типа.
+// Тип ищется через рефлексию сервера, поэтому подходят и сообщения вне сигнатур методов.
+func (a *App) GetAnyTypeExample(serverId uint, typeName string) (string, error) {
 	server, err := a.storage.GetServer(serverId)
 	if err != nil {
-		return "", 0, err
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	reflector, err := grpcreflect.NewReflector(ctx, server.Address, &utils.GRPCConnectOptions{UseTLS: server.OptUseTLS, Insecure: server.OptInsecure})
+	if err != nil {
+		return "", err
+	}
+	defer reflector.Close()
+
+	msg, err := reflector.DescribeMessage(typeName)
+	if err != nil {
+		return "", err
+	}
+
+	json, err := grpcreflect.GenerateAnyExample(msg)
+	if err != nil {
+		return "", err
+	}
+
+	return string(json), nil
+}
+
+// GetServerOpenAPI строит документ по сохраненной схеме и дескрипторам, поэтому он воспроизводим
+// из того же кеша и доступен без сервера. К серверу обращаемся, только если схема еще не сохранялась.
+func (a *App) GetServerOpenAPI(id uint) (string, error) {
+	server, err := a.storage.GetServer(id)
+	if err != nil {
+		return "", err
+	}
+
+	files, listing, err := a.serverDescriptors(id)
+	if err != nil {
+		return "", err
+	}
+
+	document, err := openapi.Generate(server.Name, grpcreflect.ServicesInfoFromDescriptorSet(files, listing))
+	if err != nil {
+		return "", err
+	}
+
+	return string(document), nil
+}
+
+// RequestResult - результат вызова для фронтенда. Ошибка вызова не отклоняет промис,
+// а приходит вместе с кодом, деталями статуса и трейлерами.
+type RequestResult struct {
+	Response      string              `json:"response"`
+	Code          int32               `json:"code"`
+	Error         string              `json:"error,omitempty"`
+	Headers       map[string][]string `json:"headers,omitempty"`
+	Trailers      map[string][]string `json:"trailers,omitempty"`
+	StatusDetails json.RawMessage     `json:"statusDetails,omitempty"`
+	StatusMessage string              `json:"statusMessage,omitempty"`
+	ErrorKind     string              `json:"errorKind,omitempty"`
+	// BinaryMetadata - -bin заголовки и трейлеры в base64, hex и, если тип известен, в JSON.
+	// В Headers и Trailers такие значения тоже приходят в base64
+	BinaryMetadata []grpcrequest.BinaryMetadata `json:"binaryMetadata,omitempty"`
+	ExecutionTime  int32                        `json:"executionTime"`
+	// Extracted - результаты правил извлечения метода, удачные значения уже доступны как переменные
+	Extracted []extract.Value `json:"extracted,omitempty"`
+	// ScriptLogs - console.log и ошибки pre-request и post-response скриптов
+	ScriptLogs []scripting.LogEntry `json:"scriptLogs,omitempty"`
+	// Assertions - результаты проверок метода, AssertionsPassed пустой, если проверок нет
+	Assertions       []assertion.Result `json:"assertions,omitempty"`
+	AssertionsPassed *bool              `json:"assertionsPassed,omitempty"`
+}
+
+func (a *App) DoGRPCRequest(serverId uint, address, service, method, payload string, requestHeaders map[string]string, reqContext *grpcrequest.RequestContext) (*RequestResult, error) {
+	return a.doRequest(appScope{a}, serverId, address, service, method, payload, requestHeaders, reqContext, nil)
+}
+
+// doRequest выполняет вызов со всеми шагами: подстановка переменных, скрипты, извлечение, проверки и история.
+// saved - сохраненный запрос, из которого отправлен вызов: его проверки выполняются вместе с проверками метода.
+// Пустой address - адрес сервера.
+func (a *App) doRequest(scope variableScope, serverId uint, address, service, method, payload string, requestHeaders map[string]string, reqContext *grpcrequest.RequestContext, saved *models.SavedRequest) (*RequestResult, error) {
+	server, err := a.storage.GetServer(serverId)
+	if err != nil {
+		return nil, err
 	}
 
 	opts := &utils.GRPCConnectOptions{
 		UseTLS:   server.OptUseTLS,
 		Insecure: server.OptInsecure,
 	}
+	if address == "" {
+		address = server.Address
+	}
+
+	// Переменные окружений подставляются до отправки, в историю попадает и шаблон, и итоговый запрос
+	template := requestTemplate{Address: address, Payload: payload, Headers: requestHeaders, Context: reqContext}
+	resolved, templated, callErr := a.resolveRequest(scope, serverId, template)
 
-	resp, code, respHeaders, execTime, err := grpcrequest.DoGRPCRequest(address, service, method, payload, requestHeaders, contextValues, opts)
+	script, err := a.storage.GetMethodScript(serverId, service, method)
+	if err != nil {
+		return nil, err
+	}
+	assertions, err := a.GetAssertions(serverId, service, method)
+	if err != nil {
+		return nil, err
+	}
+	if saved != nil {
+		assertions = append(assertions, savedAssertions(saved.Assertions)...)
+	}
+
+	var reply *grpcrequest.Result
+	var scriptLogs []scripting.LogEntry
+	if callErr != nil {
+		reply = &grpcrequest.Result{Code: codes.InvalidArgument, ErrorKind: grpcrequest.ErrorKindTemplate, StatusMessage: callErr.Error()}
+	} else {
+		address, payload, requestHeaders, reqContext = resolved.Address, resolved.Payload, resolved.Headers, resolved.Context
+
+		// Pre-request скрипт видит уже подставленный запрос и может поменять тело и метаданные
+		req := &scripting.Request{Service: service, Method: method, Address: address, Payload: payload, Metadata: requestHeaders}
+		scriptLogs, callErr = a.runScript(scope, serverId, scripting.PhasePreRequest, script.PreRequest, &scripting.Env{Request: req})
+		if callErr != nil {
+			reply = &grpcrequest.Result{Code: codes.InvalidArgument, ErrorKind: grpcrequest.ErrorKindScript, StatusMessage: callErr.Error()}
+		} else {
+			payload, requestHeaders = req.Payload, req.Metadata
+			reply, callErr = grpcrequest.DoGRPCRequest(address, service, method, payload, requestHeaders, reqContext, opts)
+		}
+	}
+
+	result := &RequestResult{
+		Response:       reply.Response,
+		Code:           int32(reply.Code),
+		Headers:        grpcrequest.MetadataForDisplay(reply.Headers),
+		Trailers:       grpcrequest.MetadataForDisplay(reply.Trailers),
+		StatusMessage:  reply.StatusMessage,
+		ErrorKind:      string(reply.ErrorKind),
+		BinaryMetadata: reply.BinaryMetadata,
+		ExecutionTime:  reply.ExecutionTime,
+	}
+	if callErr != nil {
+		result.Error = callErr.Error()
+	}
+	if reply.StatusDetails != "" {
+		result.StatusDetails = json.RawMessage(reply.StatusDetails)
+	}
+
+	// Правила извлечения и post-response скрипт работают только для реально отправленного запроса
+	var extracted string
+	if reply.ErrorKind != grpcrequest.ErrorKindTemplate && reply.ErrorKind != grpcrequest.ErrorKindScript {
+		resp := extract.Response{Body: reply.Response, Headers: result.Headers, Trailers: result.Trailers}
+		result.Extracted, extracted, err = a.applyExtractionRules(scope, serverId, service, method, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		env := &scripting.Env{
+			Request: &scripting.Request{Service: service, Method: method, Address: address, Payload: payload, Metadata: requestHeaders},
+			Response: &scripting.Response{
+				Code:          int32(reply.Code),
+				Status:        reply.Code.String(),
+				Message:       reply.StatusMessage,
+				Body:          reply.Response,
+				Headers:       result.Headers,
+				Trailers:      result.Trailers,
+				ExecutionTime: reply.ExecutionTime,
+			},
+		}
+		// Ошибка post-response скрипта не меняет результат вызова, она попадает в логи
+		logs, _ := a.runScript(scope, serverId, scripting.PhasePostResponse, script.PostResponse, env)
+		scriptLogs = append(scriptLogs, logs...)
+
+		result.Assertions = assertion.Evaluate(assertions, assertion.Response{
+			Code:     reply.Code,
+			Body:     reply.Response,
+			Headers:  result.Headers,
+			Trailers: result.Trailers,
+			Duration: time.Duration(reply.ExecutionTime) * time.Millisecond,
+		})
+	} else {
+		result.Assertions = assertion.NotSent(assertions, reply.StatusMessage)
+	}
+	result.ScriptLogs = scriptLogs
+	assertionsResults, assertionsPassed := assertionsJSON(result.Assertions)
+	result.AssertionsPassed = assertionsPassed
 
 	var historyRecord models.History
 	historyRecord.ServerID = serverId
 	historyRecord.Service = service
 	historyRecord.Method = method
 	historyRecord.Request = payload
-	historyRecord.Response = resp
-	historyRecord.StatusCode = int32(code)
-	historyRecord.ExecutionTime = execTime
+	historyRecord.Address = address
+	historyRecord.Response = reply.Response
+	historyRecord.StatusCode = int32(reply.Code)
+	historyRecord.ExecutionTime = reply.ExecutionTime
+	historyRecord.StatusDetails = reply.StatusDetails
+	historyRecord.StatusMessage = reply.StatusMessage
+	historyRecord.ErrorKind = string(reply.ErrorKind)
+	historyRecord.Extracted = extracted
+
+	if saved != nil {
+		historyRecord.SavedRequestID = saved.ID
+	}
+	historyRecord.Assertions = assertionsResults
+	historyRecord.AssertionsPassed = assertionsPassed
+
+	if len(scriptLogs) > 0 {
+		logsJSON, _ := json.Marshal(scriptLogs)
+		historyRecord.ScriptLogs = string(logsJSON)
+	}
 
 	if len(requestHeaders) > 0 {
 		reqHeadersJSON, _ := json.Marshal(requestHeaders)
 		historyRecord.RequestHeaders = string(reqHeadersJSON)
 	}
 
-	if len(respHeaders) > 0 {
-		respHeadersMap := make(map[string]string)
-		for k, v := range respHeaders {
-			if len(v) > 0 {
-				respHeadersMap[k] = v[0]
-			}
-		}
-		respHeadersJSON, _ := json.Marshal(respHeadersMap)
+	if len(result.Headers) > 0 {
+		respHeadersJSON, _ := json.Marshal(result.Headers)
 		historyRecord.ResponseHeaders = string(respHeadersJSON)
 	}
 
-	if len(contextValues) > 0 {
-		contextJSON, _ := json.Marshal(contextValues)
-		historyRecord.ContextValues = string(contextJSON)
+	if len(result.Trailers) > 0 {
+		trailersJSON, _ := json.Marshal(result.Trailers)
+		historyRecord.ResponseTrailers = string(trailersJSON)
+	}
+
+	if !reqContext.IsZero() {
+		contextJSON, _ := json.Marshal(reqContext)
+		historyRecord.RequestContext = string(contextJSON)
+	}
+
+	if templated {
+		templateJSON, _ := json.Marshal(template)
+		historyRecord.RequestTemplate = string(templateJSON)
 	}
 
 	if err := a.storage.CreateHistory(&historyRecord); err != nil {
-		return "", int32(code), err
+		return nil, err
 	}
 
 	_ = a.storage.CleanupOldHistory(consts.MaxHistorySize)
 
-	return resp, int32(code), err
+	return result, nil
 }
 
 func (a *App) GetHistory(serverId uint, limit int) ([]models.History, error) {
@@ -275,7 +754,7 @@ func (a *App) ValidateServerAddress(address string, useTLS, insecure bool) Valid
 	}
 	defer reflection.Close()
 
-	services, err := reflection.GetAllServicesInfo()
+	services, err := reflection.ListServicesInfo()
 	if err != nil {
 		if utils.IsConnectionError(err) {
 			return ValidationResult{
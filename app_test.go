@@ -1,10 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"grpc-gui/internal/consts"
 	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/grpcrequest"
 	"grpc-gui/internal/models"
 	"grpc-gui/internal/testutil"
 )
@@ -24,6 +32,8 @@ func setupTestApp(t *testing.T) (*App, func()) {
 	}
 
 	return app, func() {
+		app.stopHealthWatches()
+		app.schemaResolves.Wait()
 		os.Remove(tmpFile.Name())
 	}
 }
@@ -210,6 +220,248 @@ func TestApp_GetServerReflection_InvalidServer(t *testing.T) {
 	}
 }
 
+func TestApp_GetServerWithReflection_LazyMethods(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	recorded := recordEvents(app)
+
+	// Список открывается одним вызовом ListServices, методы догружаются в фоне
+	result, err := app.GetServerWithReflection(id)
+	if err != nil {
+		t.Fatalf("GetServerWithReflection failed: %v", err)
+	}
+	if result.Error != "" || !result.Pending {
+		t.Fatalf("expected pending listing, got %+v", result)
+	}
+	for _, service := range result.Reflection.Services {
+		if len(service.Methods) != 0 {
+			t.Errorf("expected listing without methods, got %+v", service)
+		}
+	}
+
+	service, err := app.GetServiceInfo(id, "testserver.TestService")
+	if err != nil {
+		t.Fatalf("GetServiceInfo failed: %v", err)
+	}
+	listed := findMethodInfo(service.Methods, "SimpleCall")
+	if listed == nil || listed.Request != nil {
+		t.Fatalf("expected SimpleCall signature without request details, got %+v", listed)
+	}
+
+	app.schemaResolves.Wait()
+	events := recorded.get(consts.EventServerReflection)
+	if len(events) != 1 {
+		t.Fatalf("expected resolved schema event, got %d", len(events))
+	}
+	resolved := events[0].(ServerWithReflection)
+	if resolved.Pending || resolved.Reflection.SchemaHash == "" || resolved.Warning != "" {
+		t.Errorf("expected resolved schema, got %+v", resolved)
+	}
+
+	info, err := app.GetMethodInfo(id, "testserver.TestService", "SimpleCall")
+	if err != nil {
+		t.Fatalf("GetMethodInfo failed: %v", err)
+	}
+	if info.Request == nil || info.RequestExampleString == "" {
+		t.Errorf("expected method details, got %+v", info)
+	}
+
+	// Повторный запрос отдается из кеша даже без сервера
+	stop()
+	cached, err := app.GetMethodInfo(id, "testserver.TestService", "SimpleCall")
+	if err != nil || cached != info {
+		t.Errorf("expected cached method info, got %v, %v", cached, err)
+	}
+
+	if _, err := app.GetMethodInfo(id, "testserver.TestService", "Missing"); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
+
+func TestApp_ResolveServerSchema_PartialFailure(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+	loaded := loadServerSchema(t, app, id)
+
+	server, err := app.storage.GetServer(id)
+	if err != nil {
+		t.Fatalf("GetServer failed: %v", err)
+	}
+	descriptors := server.ReflectionDescriptors
+	if len(descriptors) == 0 {
+		t.Fatal("expected stored descriptors")
+	}
+
+	// Сервис пропал между ListServices и загрузкой схемы
+	listed := loaded
+	listed.Reflection = &grpcreflect.ServicesInfo{
+		Services: append(append([]grpcreflect.ServiceInfo{}, loaded.Reflection.Services...), grpcreflect.ServiceInfo{Name: "testserver.Missing"}),
+	}
+	result := app.resolveServerSchema(context.Background(), *server, listed)
+	if result.Warning == "" {
+		t.Errorf("expected warning for unresolved service, got %+v", result)
+	}
+	for _, service := range result.Reflection.Services {
+		if service.Name == "testserver.TestService" && findMethodInfo(service.Methods, "SimpleCall") == nil {
+			t.Errorf("expected resolved methods to be kept, got %+v", service)
+		}
+	}
+
+	after, err := app.storage.GetServer(id)
+	if err != nil {
+		t.Fatalf("GetServer failed: %v", err)
+	}
+	if !bytes.Equal(after.ReflectionDescriptors, descriptors) || after.ReflectionCache != server.ReflectionCache {
+		t.Error("expected stored schema and descriptors to stay unchanged")
+	}
+}
+
+func findMethodInfo(methods []grpcreflect.MethodInfo, name string) *grpcreflect.MethodInfo {
+	for i := range methods {
+		if methods[i].Name == name {
+			return &methods[i]
+		}
+	}
+	return nil
+}
+
+// loadServerSchema обновляет рефлексию как GUI, дожидается фоновой загрузки схемы и читает ее из кеша.
+func loadServerSchema(t *testing.T, app *App, id uint) ServerWithReflection {
+	t.Helper()
+
+	if _, err := app.GetServerWithReflection(id); err != nil {
+		t.Fatalf("GetServerWithReflection failed: %v", err)
+	}
+	app.schemaResolves.Wait()
+
+	server, err := app.storage.GetServer(id)
+	if err != nil {
+		t.Fatalf("GetServer failed: %v", err)
+	}
+	return app.getServerReflection(context.Background(), *server, false)
+}
+
+func TestApp_GetServersWithReflection_Parallel(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	var mu sync.Mutex
+	var events []ServerWithReflection
+	app.emit = func(name string, data any) {
+		mu.Lock()
+		defer mu.Unlock()
+		if name == consts.EventServerReflection {
+			events = append(events, data.(ServerWithReflection))
+		}
+	}
+
+	liveID, _ := app.CreateServer("Live", addr, false, false)
+	for i := 0; i < consts.ReflectionRefreshWorkers+1; i++ {
+		if _, err := app.CreateServer("Dead", "127.0.0.1:1", false, false); err != nil {
+			t.Fatalf("CreateServer failed: %v", err)
+		}
+	}
+
+	results, err := app.GetServersWithReflection()
+	if err != nil {
+		t.Fatalf("GetServersWithReflection failed: %v", err)
+	}
+	if len(results) != consts.ReflectionRefreshWorkers+2 {
+		t.Fatalf("expected all servers in result, got %d", len(results))
+	}
+
+	for _, result := range results {
+		if result.Server.ID == liveID {
+			if result.Status != ReflectionStatusOK || len(result.Reflection.Services) == 0 {
+				t.Errorf("expected live server to be refreshed, got %+v", result)
+			}
+			continue
+		}
+		if result.Status != ReflectionStatusUnreachable || result.Error == "" {
+			t.Errorf("expected dead server to be unreachable, got status %q", result.Status)
+		}
+	}
+
+	// Каждый сервер дает событие со списком, живой - еще одно с полной схемой
+	app.schemaResolves.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != len(results)+1 {
+		t.Errorf("expected an event per server plus the resolved schema, got %d", len(events))
+	}
+}
+
+func TestApp_GetServerWithReflection_StaleCache(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	if result := loadServerSchema(t, app, id); result.Status != ReflectionStatusOK {
+		t.Fatalf("expected fresh reflection, got %+v", result)
+	}
+
+	stop()
+
+	result, err := app.GetServerWithReflection(id)
+	if err != nil {
+		t.Fatalf("GetServerWithReflection failed: %v", err)
+	}
+	if result.Status != ReflectionStatusStale || result.Error == "" {
+		t.Errorf("expected stale status with error, got %q / %q", result.Status, result.Error)
+	}
+	if len(result.Reflection.Services) == 0 {
+		t.Error("expected last known services to be kept")
+	}
+	if result.SchemaAt == nil || time.Since(*result.SchemaAt) > time.Minute {
+		t.Errorf("expected timestamp of the cached schema, got %v", result.SchemaAt)
+	}
+
+	// Ошибка не затирает схему и при следующем чтении из кеша
+	server, err := app.storage.GetServer(id)
+	if err != nil {
+		t.Fatalf("GetServer failed: %v", err)
+	}
+	cached := app.getServerReflection(context.Background(), *server, false)
+	if cached.Status != ReflectionStatusStale || len(cached.Reflection.Services) == 0 {
+		t.Errorf("expected stale cached schema, got %q", cached.Status)
+	}
+
+	// Детали метода строятся по сохраненным дескрипторам
+	info, err := app.GetMethodInfo(id, "testserver.TestService", "ComplexCall")
+	if err != nil {
+		t.Fatalf("expected offline method info, got error: %v", err)
+	}
+	if info.Request == nil || info.RequestExampleString == "" {
+		t.Errorf("expected offline request details, got %+v", info)
+	}
+}
+
 func TestApp_DoGRPCRequest(t *testing.T) {
 	addr, stop := testutil.StartTestServer(t)
 	defer stop()
@@ -223,10 +475,11 @@ func TestApp_DoGRPCRequest(t *testing.T) {
 	}
 
 	payload := `{"message": "test", "value": 42}`
-	resp, code, err := app.DoGRPCRequest(id, addr, "testserver.TestService", "SimpleCall", payload, nil, nil)
+	result, err := app.DoGRPCRequest(id, addr, "testserver.TestService", "SimpleCall", payload, nil, nil)
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
+	resp, code := result.Response, result.Code
 
 	if code != 0 {
 		t.Errorf("expected status code 0, got %d", code)
@@ -252,15 +505,17 @@ func TestApp_DoGRPCRequest_WithHeaders(t *testing.T) {
 		"authorization": "Bearer token123",
 		"x-custom":      "value",
 	}
-	contextValues := map[string]string{
-		"user-id": "123",
+	reqContext := &grpcrequest.RequestContext{
+		Timeout:  "5s",
+		Metadata: map[string]string{"user-id": "123"},
 	}
 
 	payload := `{"message": "test"}`
-	resp, code, err := app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", payload, headers, contextValues)
+	result, err := app.DoGRPCRequest(id, addr, "testserver.AnotherService", "GetUser", payload, headers, reqContext)
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
+	resp, code := result.Response, result.Code
 
 	if code != 0 {
 		t.Errorf("expected status code 0, got %d", code)
@@ -268,6 +523,161 @@ func TestApp_DoGRPCRequest_WithHeaders(t *testing.T) {
 	if resp == "" {
 		t.Error("expected non-empty response")
 	}
+
+	history, err := app.GetHistory(id, 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if history[0].RequestContext != `{"timeout":"5s","metadata":{"user-id":"123"}}` {
+		t.Errorf("expected request context in history, got %s", history[0].RequestContext)
+	}
+}
+
+func TestApp_MigratesLegacyContextValues(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	legacy := []models.History{
+		{Service: "s", Method: "m", ContextValues: `{"timeout":"1s","authorization":"Bearer abc","user-id":"7"}`},
+		{Service: "s", Method: "m", ContextValues: `not json`},
+		{Service: "s", Method: "m"},
+	}
+	for i := range legacy {
+		if err := app.storage.CreateHistory(&legacy[i]); err != nil {
+			t.Fatalf("CreateHistory failed: %v", err)
+		}
+	}
+
+	migrated, err := app.storage.MigrateHistoryContextValues(convertLegacyContextValues)
+	if err != nil || migrated != 2 {
+		t.Fatalf("expected 2 migrated rows, got %d: %v", migrated, err)
+	}
+
+	item, err := app.GetHistoryItem(legacy[0].ID)
+	if err != nil {
+		t.Fatalf("GetHistoryItem failed: %v", err)
+	}
+	var reqContext grpcrequest.RequestContext
+	if err := json.Unmarshal([]byte(item.RequestContext), &reqContext); err != nil {
+		t.Fatalf("failed to unmarshal request context %q: %v", item.RequestContext, err)
+	}
+	if reqContext.Timeout != "1s" || reqContext.Credentials == nil || reqContext.Credentials.Token != "abc" ||
+		reqContext.Metadata["user-id"] != "7" {
+		t.Errorf("unexpected migrated context: %+v", reqContext)
+	}
+	if item.ContextValues != "" {
+		t.Errorf("expected legacy values to be cleared, got %q", item.ContextValues)
+	}
+
+	broken, _ := app.GetHistoryItem(legacy[1].ID)
+	if broken.RequestContext != "" || broken.ContextValues != "" {
+		t.Errorf("expected broken legacy values to be cleared, got %+v", broken)
+	}
+
+	// Повторный запуск ничего не трогает
+	if migrated, err := app.storage.MigrateHistoryContextValues(convertLegacyContextValues); err != nil || migrated != 0 {
+		t.Errorf("expected no rows on second run, got %d: %v", migrated, err)
+	}
+}
+
+func TestApp_DoGRPCRequest_StatusDetails(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	// Ошибка вызова приходит в результате, а не отклоняет промис
+	result, err := app.DoGRPCRequest(id, addr, "testserver.TestService", "ScheduleTask", `{}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.Code != 3 || !strings.Contains(result.Error, "task is invalid") {
+		t.Errorf("expected InvalidArgument with message, got %d %q", result.Code, result.Error)
+	}
+	if !strings.Contains(string(result.StatusDetails), `"@type":"type.googleapis.com/google.rpc.BadRequest"`) {
+		t.Errorf("expected decoded details, got %s", result.StatusDetails)
+	}
+	if len(result.Headers["x-request-id"]) != 2 || result.Trailers["x-retry-reason"][0] != "quota" {
+		t.Errorf("expected all header values and trailers, got %v / %v", result.Headers, result.Trailers)
+	}
+
+	history, err := app.GetHistory(id, 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	item := history[0]
+	if item.StatusCode != 3 || item.StatusDetails != string(result.StatusDetails) {
+		t.Errorf("expected details in history, got %d %q", item.StatusCode, item.StatusDetails)
+	}
+	if !strings.Contains(item.ResponseHeaders, `"x-request-id":["1","2"]`) || !strings.Contains(item.ResponseTrailers, `"x-retry-reason":["quota"]`) {
+		t.Errorf("expected multi-value metadata in history, got %s / %s", item.ResponseHeaders, item.ResponseTrailers)
+	}
+	// Бинарный трейлер с деталями сохраняется в base64, а не сырыми байтами
+	var trailers map[string][]string
+	if err := json.Unmarshal([]byte(item.ResponseTrailers), &trailers); err != nil {
+		t.Fatalf("failed to unmarshal trailers: %v", err)
+	}
+	if bin := trailers["grpc-status-details-bin"]; len(bin) != 1 || bin[0] != result.Trailers["grpc-status-details-bin"][0] {
+		t.Errorf("expected base64 binary trailer in history, got %v", bin)
+	}
+	if len(result.BinaryMetadata) != 1 || result.BinaryMetadata[0].Type != "google.rpc.Status" {
+		t.Errorf("expected decoded binary trailer, got %+v", result.BinaryMetadata)
+	}
+	if item.StatusMessage != "task is invalid" || item.ErrorKind != "status" {
+		t.Errorf("expected status message and kind in history, got %q %q", item.StatusMessage, item.ErrorKind)
+	}
+}
+
+func TestApp_DoGRPCRequest_FailuresInHistory(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	result, err := app.DoGRPCRequest(id, addr, "testserver.TestService", "SimpleCall", `{broken`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.ErrorKind != "payload" {
+		t.Errorf("expected payload error, got %q", result.ErrorKind)
+	}
+
+	// Сервер остановлен - ошибка подключения тоже попадает в историю
+	stop()
+	result, err = app.DoGRPCRequest(id, addr, "testserver.TestService", "SimpleCall", `{}`, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if result.ErrorKind != "dial" || result.Code != 14 {
+		t.Errorf("expected dial error, got %q %d", result.ErrorKind, result.Code)
+	}
+
+	history, err := app.GetHistory(id, 10)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("expected 2 history items, got %d: %v", len(history), err)
+	}
+	kinds := map[string]string{}
+	for _, item := range history {
+		kinds[item.ErrorKind] = item.StatusMessage
+	}
+	if !strings.Contains(kinds["payload"], "failed to parse payload") {
+		t.Errorf("expected payload error message in history, got %v", kinds)
+	}
+	if kinds["dial"] == "" {
+		t.Errorf("expected dial error message in history, got %v", kinds)
+	}
 }
 
 func TestApp_GetJsonExample(t *testing.T) {
@@ -292,6 +702,154 @@ func TestApp_GetJsonExample(t *testing.T) {
 	}
 }
 
+func TestApp_GetFakeJsonExample(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	msg := &grpcreflect.MessageInfo{
+		Name: "testserver.User",
+		Fields: []grpcreflect.FieldInfo{
+			{Name: "name", Type: "string"},
+			{Name: "email", Type: "string"},
+		},
+	}
+
+	first, err := app.GetFakeJsonExample(msg, 7)
+	if err != nil {
+		t.Fatalf("GetFakeJsonExample failed: %v", err)
+	}
+
+	second, err := app.GetFakeJsonExample(msg, 7)
+	if err != nil {
+		t.Fatalf("GetFakeJsonExample failed: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("expected deterministic output, got %s and %s", first, second)
+	}
+	if first == "{}" {
+		t.Error("expected non-empty JSON")
+	}
+}
+
+func TestApp_GetJsonExampleForOneofs(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	msg := &grpcreflect.MessageInfo{
+		Name: "testserver.OneofRequest",
+		Fields: []grpcreflect.FieldInfo{
+			{Name: "text", Type: "string", OneofGroup: "payload"},
+			{Name: "number", Type: "int32", OneofGroup: "payload"},
+		},
+	}
+
+	example := app.GetJsonExampleForOneofs(msg, map[string]string{"testserver.OneofRequest.payload": "number"})
+	if !strings.Contains(example, `  "number": 0`) || !strings.Contains(example, `// "text": ""`) {
+		t.Errorf("expected 'number' selected and 'text' commented out, got:\n%s", example)
+	}
+}
+
+func TestApp_GetAnyTypeExample(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	example, err := app.GetAnyTypeExample(id, "testserver.AuditRecord")
+	if err != nil {
+		t.Fatalf("GetAnyTypeExample failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(example), &result); err != nil {
+		t.Fatalf("failed to unmarshal example: %v", err)
+	}
+	if result["@type"] != "type.googleapis.com/testserver.AuditRecord" {
+		t.Errorf("unexpected @type in %s", example)
+	}
+	if _, ok := result["actor"]; !ok {
+		t.Errorf("expected AuditRecord fields in %s", example)
+	}
+
+	if _, err := app.GetAnyTypeExample(id, "testserver.Missing"); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+func TestApp_DoGRPCRequest_AnyFields(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	payload := `{"id": "evt-1", "payload": {"@type": "type.googleapis.com/testserver.AuditRecord", "actor": "me"}}`
+	result, err := app.DoGRPCRequest(id, addr, "testserver.EventService", "Publish", payload, nil, nil)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	resp, code := result.Response, result.Code
+	if code != 0 {
+		t.Errorf("expected status code 0, got %d", code)
+	}
+	if !strings.Contains(resp, `"actor":"me"`) || !strings.Contains(resp, `"action":"publish"`) {
+		t.Errorf("expected expanded Any values, got %s", resp)
+	}
+}
+
+func TestApp_GetServerOpenAPI(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	document, err := app.GetServerOpenAPI(id)
+	if err != nil {
+		t.Fatalf("GetServerOpenAPI failed: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		t.Fatalf("failed to unmarshal document: %v", err)
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected paths in document")
+	}
+	if _, ok := paths["/v1/users/{message}"]; !ok {
+		t.Error("expected path from google.api.http annotation")
+	}
+	if _, ok := paths["/testserver.TestService/SimpleCall"]; !ok {
+		t.Error("expected default path for SimpleCall")
+	}
+
+	// Документ строится из сохраненной схемы и воспроизводится без сервера
+	stop()
+	offline, err := app.GetServerOpenAPI(id)
+	if err != nil || offline != document {
+		t.Errorf("expected identical document from cache, got error %v", err)
+	}
+}
+
 func TestApp_ValidateServerAddress_Success(t *testing.T) {
 	addr, stop := testutil.StartTestServer(t)
 	defer stop()
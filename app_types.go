@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/grpcreflect"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// FindTypeUsages возвращает методы сервера, в запрос или ответ которых входит тип.
+// Помогает оценить, что заденет изменение общего сообщения.
+func (a *App) FindTypeUsages(serverId uint, typeName string) ([]grpcreflect.TypeUsage, error) {
+	files, listing, err := a.serverDescriptors(serverId)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]string, 0, len(listing.Services))
+	for _, service := range listing.Services {
+		services = append(services, service.Name)
+	}
+	return grpcreflect.FindTypeUsages(files, services, typeName)
+}
+
+// GetMethodTypeGraph возвращает граф типов запроса и ответа метода.
+func (a *App) GetMethodTypeGraph(serverId uint, service, method string) (*grpcreflect.TypeGraph, error) {
+	files, _, err := a.serverDescriptors(serverId)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcreflect.MethodTypeGraph(files, service, method)
+}
+
+// GetMethodTypeGraphDOT возвращает граф типов метода в формате Graphviz.
+func (a *App) GetMethodTypeGraphDOT(serverId uint, service, method string) (string, error) {
+	graph, err := a.GetMethodTypeGraph(serverId, service, method)
+	if err != nil {
+		return "", err
+	}
+
+	return graph.DOT(), nil
+}
+
+// serverDescriptors отдает сохраненные дескрипторы сервера и список его сервисов.
+// Если схема еще не сохранялась, она загружается с сервера целиком.
+func (a *App) serverDescriptors(serverId uint) (*protoregistry.Files, *grpcreflect.ServicesInfo, error) {
+	server, err := a.storage.GetServer(serverId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(server.ReflectionDescriptors) == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), consts.ReflectionSchemaTimeout)
+		defer cancel()
+
+		if result := a.getServerReflection(ctx, *server, true); result.Status != ReflectionStatusOK {
+			return nil, nil, errors.New(result.Error)
+		}
+
+		server, err = a.storage.GetServer(serverId)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	listing, err := cachedReflection(*server)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files, err := grpcreflect.FilesFromDescriptorSet(server.ReflectionDescriptors)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return files, listing, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/testutil"
+)
+
+func TestApp_FindTypeUsages(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Test Server", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	// Схема еще не сохранена - запрашивается у сервера
+	usages, err := app.FindTypeUsages(id, "testserver.Address")
+	if err != nil {
+		t.Fatalf("FindTypeUsages failed: %v", err)
+	}
+
+	methods := make(map[string]bool)
+	for _, usage := range usages {
+		methods[usage.Service+"/"+usage.Method] = true
+	}
+	for _, method := range []string{"testserver.TestService/ComplexCall", "testserver.AnotherService/GetUser"} {
+		if !methods[method] {
+			t.Errorf("expected %s in usages, got %v", method, methods)
+		}
+	}
+
+	// Дальше хватает сохраненных дескрипторов
+	stop()
+
+	dot, err := app.GetMethodTypeGraphDOT(id, "testserver.AnotherService", "GetUser")
+	if err != nil {
+		t.Fatalf("GetMethodTypeGraphDOT failed: %v", err)
+	}
+	if !strings.Contains(dot, `"testserver.User" -> "testserver.Address" [label="address"];`) {
+		t.Errorf("unexpected DOT:\n%s", dot)
+	}
+}
+
+func TestApp_FindTypeUsages_Unreachable(t *testing.T) {
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	id, err := app.CreateServer("Offline", "localhost:1", false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	if _, err := app.FindTypeUsages(id, "testserver.Address"); err == nil {
+		t.Error("expected error without schema")
+	}
+}
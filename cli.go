@@ -0,0 +1,461 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"grpc-gui/internal/consts"
+	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/grpcrequest"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/runner"
+)
+
+// Коды выхода консольного режима
+const (
+	exitOK = 0
+	// exitFailed - команда выполнилась, но вызов или прогон упали
+	exitFailed = 1
+	// exitError - неверные аргументы или ошибка до выполнения
+	exitError = 2
+)
+
+const cliUsage = `Использование: grpc-gui <команда> [аргументы]
+
+Без команды запускается окно приложения. Команды работают с той же базой,
+кешем рефлексии и настройками серверов, что и GUI, и печатают JSON.
+
+  list servers|collections|environments
+  describe <сервер> [<сервис>[/<метод>]]
+  call <сервер> <сервис>/<метод> [-d '{...}'|@payload.json|@-] [-H 'ключ: значение']... [-timeout 5s] [-env имя] [-address host:port]
+  history [-server <сервер>] [-limit 20]
+  run <коллекция> [-env имя] [-data data.csv] [-stop-on-failure] [-junit report.xml] [-json report.json]
+
+Сервер, коллекцию и окружение можно указать по имени или ID.
+call завершается с кодом 1, если сервер вернул ошибку или не прошли проверки, run - если упал хоть один запрос.
+`
+
+// cliCommands - команды консольного режима, остальные аргументы достаются GUI.
+var cliCommands = map[string]bool{
+	"list": true, "describe": true, "call": true, "history": true, "run": true,
+	"help": true, "-h": true, "-help": true, "--help": true,
+}
+
+// isCLI - запуск с консольной командой вместо окна.
+func isCLI(args []string) bool {
+	return len(args) > 0 && cliCommands[args[0]]
+}
+
+type cli struct {
+	app    *App
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// runCLI выполняет консольную команду и возвращает код выхода.
+func runCLI(app *App, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	c := &cli{app: app, stdin: stdin, stdout: stdout, stderr: stderr}
+
+	var command func([]string) (int, error)
+	switch args[0] {
+	case "list":
+		command = c.list
+	case "describe":
+		command = c.describe
+	case "call":
+		command = c.call
+	case "history":
+		command = c.history
+	case "run":
+		command = c.run
+	default:
+		fmt.Fprint(stdout, cliUsage)
+		return exitOK
+	}
+
+	code, err := command(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "grpc-gui %s: %v\n", args[0], err)
+		return exitError
+	}
+	return code
+}
+
+func (c *cli) list(args []string) (int, error) {
+	fs := c.flagSet("list")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitError, err
+	}
+	if len(positional) != 1 {
+		return exitError, fmt.Errorf("expected servers, collections or environments")
+	}
+
+	var result any
+	switch positional[0] {
+	case "servers":
+		result, err = c.app.GetServers()
+	case "collections":
+		result, err = c.app.GetCollections()
+	case "environments":
+		result, err = c.app.GetEnvironments()
+	default:
+		return exitError, fmt.Errorf("unknown list %q: expected servers, collections or environments", positional[0])
+	}
+	if err != nil {
+		return exitError, err
+	}
+	return exitOK, c.print(result)
+}
+
+// ServiceDescription - сервис с полным описанием методов для describe.
+type ServiceDescription struct {
+	Name    string                   `json:"name"`
+	Methods []grpcreflect.MethodInfo `json:"methods"`
+}
+
+func (c *cli) describe(args []string) (int, error) {
+	fs := c.flagSet("describe")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitError, err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return exitError, fmt.Errorf("expected <server> [<service>[/<method>]]")
+	}
+
+	server, err := c.findServer(positional[0])
+	if err != nil {
+		return exitError, err
+	}
+
+	// Схема берется из общего кеша рефлексии, при недоступном сервере - последняя удачная
+	ctx, cancel := context.WithTimeout(context.Background(), consts.ReflectionSchemaTimeout)
+	defer cancel()
+	reflection := c.app.getServerReflection(ctx, *server, false)
+
+	if len(positional) == 1 {
+		return exitOK, c.print(reflection)
+	}
+	if reflection.Status == ReflectionStatusUnreachable {
+		return exitError, errors.New(reflection.Error)
+	}
+
+	serviceName, methodName, _ := strings.Cut(positional[1], "/")
+	var service *grpcreflect.ServiceInfo
+	for i := range reflection.Reflection.Services {
+		if reflection.Reflection.Services[i].Name == serviceName {
+			service = &reflection.Reflection.Services[i]
+			break
+		}
+	}
+	if service == nil {
+		return exitError, fmt.Errorf("service %q not found on server %s", serviceName, server.Name)
+	}
+
+	description := ServiceDescription{Name: service.Name}
+	for _, method := range service.Methods {
+		if methodName != "" && method.Name != methodName {
+			continue
+		}
+		info, err := c.app.GetMethodInfo(server.ID, service.Name, method.Name)
+		if err != nil {
+			return exitError, err
+		}
+		if methodName != "" {
+			return exitOK, c.print(info)
+		}
+		description.Methods = append(description.Methods, *info)
+	}
+	if methodName != "" {
+		return exitError, fmt.Errorf("method %q not found in %s", methodName, service.Name)
+	}
+	return exitOK, c.print(description)
+}
+
+func (c *cli) call(args []string) (int, error) {
+	fs := c.flagSet("call")
+	data := fs.String("d", "{}", "тело запроса: JSON, @файл или @- для stdin")
+	headers := headerFlags{}
+	fs.Var(headers, "H", "заголовок 'ключ: значение', можно повторять")
+	timeout := fs.String("timeout", "", "дедлайн вызова, например 5s")
+	envName := fs.String("env", "", "окружение вместо активных")
+	address := fs.String("address", "", "адрес вместо адреса сервера")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitError, err
+	}
+	if len(positional) != 2 {
+		return exitError, fmt.Errorf("expected <server> <service>/<method>")
+	}
+
+	server, err := c.findServer(positional[0])
+	if err != nil {
+		return exitError, err
+	}
+	service, method, ok := strings.Cut(positional[1], "/")
+	if !ok || service == "" || method == "" {
+		return exitError, fmt.Errorf("expected <service>/<method>, got %q", positional[1])
+	}
+	payload, err := c.readPayload(*data)
+	if err != nil {
+		return exitError, err
+	}
+
+	scope := &runScope{app: c.app, vars: map[string]string{}, updated: map[string]string{}}
+	if *envName != "" {
+		if scope.environment, err = c.findEnvironment(*envName); err != nil {
+			return exitError, err
+		}
+	}
+
+	var reqContext *grpcrequest.RequestContext
+	if *timeout != "" {
+		reqContext = &grpcrequest.RequestContext{Timeout: *timeout}
+	}
+
+	result, err := c.app.doRequest(scope, server.ID, *address, service, method, payload, headers, reqContext, nil)
+	if err != nil {
+		return exitError, err
+	}
+	if err := c.print(result); err != nil {
+		return exitError, err
+	}
+
+	if result.Code != 0 || result.ErrorKind != "" || (result.AssertionsPassed != nil && !*result.AssertionsPassed) {
+		return exitFailed, nil
+	}
+	return exitOK, nil
+}
+
+func (c *cli) history(args []string) (int, error) {
+	fs := c.flagSet("history")
+	serverName := fs.String("server", "", "только запросы к серверу")
+	limit := fs.Int("limit", 20, "сколько последних записей показать, 0 - все")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitError, err
+	}
+	if len(positional) != 0 {
+		return exitError, fmt.Errorf("unexpected arguments %v", positional)
+	}
+
+	var serverID uint
+	if *serverName != "" {
+		server, err := c.findServer(*serverName)
+		if err != nil {
+			return exitError, err
+		}
+		serverID = server.ID
+	}
+
+	history, err := c.app.GetHistory(serverID, *limit)
+	if err != nil {
+		return exitError, err
+	}
+	return exitOK, c.print(history)
+}
+
+func (c *cli) run(args []string) (int, error) {
+	fs := c.flagSet("run")
+	envName := fs.String("env", "", "окружение прогона вместо активных")
+	dataPath := fs.String("data", "", "файл данных .csv или .json, каждая строка - итерация")
+	stopOnFailure := fs.Bool("stop-on-failure", false, "остановиться на первом упавшем запросе")
+	junitPath := fs.String("junit", "", "записать отчет JUnit XML в файл")
+	jsonPath := fs.String("json", "", "записать отчет JSON в файл")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return exitError, err
+	}
+	if len(positional) != 1 {
+		return exitError, fmt.Errorf("expected <collection>")
+	}
+
+	collection, err := c.findCollection(positional[0])
+	if err != nil {
+		return exitError, err
+	}
+
+	var environmentID uint
+	if *envName != "" {
+		env, err := c.findEnvironment(*envName)
+		if err != nil {
+			return exitError, err
+		}
+		environmentID = env.ID
+	}
+
+	opts := runner.Options{StopOnFailure: *stopOnFailure}
+	if *dataPath != "" {
+		data, err := os.ReadFile(*dataPath)
+		if err != nil {
+			return exitError, err
+		}
+		if opts.Data, err = runner.ParseData(*dataPath, data); err != nil {
+			return exitError, err
+		}
+	}
+
+	// Ctrl+C пропускает оставшиеся запросы, отчет все равно пишется
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := c.app.runCollection(ctx, collection.ID, environmentID, opts)
+	if err != nil {
+		return exitError, err
+	}
+
+	for _, file := range []struct {
+		path   string
+		format runner.Format
+	}{{*junitPath, runner.FormatJUnit}, {*jsonPath, runner.FormatJSON}} {
+		if file.path == "" {
+			continue
+		}
+		if err := writeReport(file.path, report, file.format); err != nil {
+			return exitError, err
+		}
+	}
+	if err := report.WriteJSON(c.stdout); err != nil {
+		return exitError, err
+	}
+
+	if !report.Passed() {
+		return exitFailed, nil
+	}
+	return exitOK, nil
+}
+
+func writeReport(path string, report *runner.Report, format runner.Format) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := report.Write(file, format); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (c *cli) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("grpc-gui "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+func (c *cli) print(value any) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// readPayload читает тело запроса: JSON как есть, @файл или @- для stdin.
+func (c *cli) readPayload(value string) (string, error) {
+	path, ok := strings.CutPrefix(value, "@")
+	if !ok {
+		return value, nil
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(c.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("read payload: %w", err)
+	}
+	return string(data), nil
+}
+
+// findServer ищет сервер по ID или имени.
+func (c *cli) findServer(ref string) (*models.Server, error) {
+	servers, err := c.app.GetServers()
+	if err != nil {
+		return nil, err
+	}
+	for i := range servers {
+		if matchesRef(ref, servers[i].ID, servers[i].Name) {
+			return c.app.storage.GetServer(servers[i].ID)
+		}
+	}
+	return nil, fmt.Errorf("server %q not found", ref)
+}
+
+// findCollection ищет коллекцию по ID или имени.
+func (c *cli) findCollection(ref string) (*models.Collection, error) {
+	if collection, err := c.app.storage.GetCollectionByName(ref); err == nil {
+		return collection, nil
+	}
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		if collection, err := c.app.storage.GetCollection(uint(id)); err == nil {
+			return collection, nil
+		}
+	}
+	return nil, fmt.Errorf("collection %q not found", ref)
+}
+
+// findEnvironment ищет окружение по ID или имени.
+func (c *cli) findEnvironment(ref string) (*models.Environment, error) {
+	envs, err := c.app.GetEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	for i := range envs {
+		if matchesRef(ref, envs[i].ID, envs[i].Name) {
+			return &envs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("environment %q not found", ref)
+}
+
+func matchesRef(ref string, id uint, name string) bool {
+	return ref == name || ref == strconv.FormatUint(uint64(id), 10)
+}
+
+// parseFlags разбирает флаги в любом месте командной строки, например после имени сервера,
+// и возвращает позиционные аргументы.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// headerFlags - повторяемый флаг -H 'ключ: значение'.
+type headerFlags map[string]string
+
+func (h headerFlags) String() string {
+	return ""
+}
+
+func (h headerFlags) Set(value string) error {
+	key, val, ok := strings.Cut(value, ":")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("expected 'key: value', got %q", value)
+	}
+	h[key] = strings.TrimSpace(val)
+	return nil
+}
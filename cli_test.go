@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"grpc-gui/internal/grpcreflect"
+	"grpc-gui/internal/models"
+	"grpc-gui/internal/runner"
+	"grpc-gui/internal/testutil"
+)
+
+// execCLI выполняет команду и возвращает код выхода, stdout и stderr.
+func execCLI(app *App, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := runCLI(app, args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestIsCLI(t *testing.T) {
+	for args, want := range map[string]bool{
+		"":                   false,
+		"-psn_0_12345":       false,
+		"list servers":       true,
+		"run smoke -env dev": true,
+		"--help":             true,
+	} {
+		if got := isCLI(strings.Fields(args)); got != want {
+			t.Errorf("isCLI(%q) = %v, want %v", args, got, want)
+		}
+	}
+}
+
+func TestCLI(t *testing.T) {
+	addr, stop := testutil.StartTestServer(t)
+	defer stop()
+
+	app, cleanup := setupTestApp(t)
+	defer cleanup()
+
+	serverID, err := app.CreateServer("local", addr, false, false)
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+	if _, err := app.CreateEnvironment("dev", 0, map[string]string{"name": "dana"}); err != nil {
+		t.Fatalf("CreateEnvironment failed: %v", err)
+	}
+
+	code, out, _ := execCLI(app, "", "list", "servers")
+	var servers []models.Server
+	if code != exitOK || json.Unmarshal([]byte(out), &servers) != nil || len(servers) != 1 || servers[0].Name != "local" {
+		t.Errorf("list servers = %d %s", code, out)
+	}
+
+	code, out, _ = execCLI(app, "", "describe", "local")
+	var described ServerWithReflection
+	if code != exitOK || json.Unmarshal([]byte(out), &described) != nil || len(described.Reflection.Services) == 0 {
+		t.Errorf("describe server = %d %s", code, out)
+	}
+
+	code, out, _ = execCLI(app, "", "describe", "local", "testserver.AnotherService")
+	var service ServiceDescription
+	if code != exitOK || json.Unmarshal([]byte(out), &service) != nil || len(service.Methods) == 0 || service.Methods[0].Request == nil {
+		t.Errorf("describe service = %d %s", code, out)
+	}
+
+	code, out, _ = execCLI(app, "", "describe", "1", "testserver.AnotherService/GetUser")
+	var method grpcreflect.MethodInfo
+	if code != exitOK || json.Unmarshal([]byte(out), &method) != nil || method.Name != "GetUser" {
+		t.Errorf("describe method = %d %s", code, out)
+	}
+
+	// Флаги идут после позиционных аргументов, тело читается из файла, переменные - из окружения
+	payloadPath := filepath.Join(t.TempDir(), "payload.json")
+	if err := os.WriteFile(payloadPath, []byte(`{"message": "{{name}}"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, out, _ = execCLI(app, "", "call", "local", "testserver.AnotherService/GetUser", "-d", "@"+payloadPath, "-H", "x-user: dana", "-env", "dev")
+	var result RequestResult
+	if code != exitOK || json.Unmarshal([]byte(out), &result) != nil || !strings.Contains(result.Response, `"name":"dana"`) {
+		t.Errorf("call = %d %s", code, out)
+	}
+
+	code, out, _ = execCLI(app, `{"message": "stdin"}`, "call", "local", "testserver.AnotherService/GetUser", "-d", "@-")
+	if code != exitOK || !strings.Contains(out, `\"name\":\"stdin\"`) {
+		t.Errorf("call with stdin = %d %s", code, out)
+	}
+
+	// Ответ с ошибкой печатается и дает код 1
+	code, out, _ = execCLI(app, "", "call", "local", "testserver.TestService/ScheduleTask")
+	if code != exitFailed || !strings.Contains(out, `"errorKind"`) {
+		t.Errorf("failed call = %d %s", code, out)
+	}
+
+	code, out, _ = execCLI(app, "", "history", "-server", "local", "-limit", "2")
+	var history []models.History
+	if code != exitOK || json.Unmarshal([]byte(out), &history) != nil || len(history) != 2 || history[0].Method != "ScheduleTask" {
+		t.Errorf("history = %d %s", code, out)
+	}
+
+	collectionID, err := app.CreateCollection("smoke", "")
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if _, err := app.CreateSavedRequest(savedRequest(collectionID, 0, serverID, "get user")); err != nil {
+		t.Fatalf("CreateSavedRequest failed: %v", err)
+	}
+	junitPath := filepath.Join(t.TempDir(), "report.xml")
+	code, out, _ = execCLI(app, "", "run", "smoke", "-env", "dev", "-junit", junitPath)
+	var report runner.Report
+	if code != exitOK || json.Unmarshal([]byte(out), &report) != nil || report.Summary.Passed != 1 {
+		t.Errorf("run = %d %s", code, out)
+	}
+	if junit, err := os.ReadFile(junitPath); err != nil || !strings.Contains(string(junit), `<testcase name="get user"`) {
+		t.Errorf("expected junit report, got %s %v", junit, err)
+	}
+
+	for _, args := range [][]string{
+		{"list", "tables"},
+		{"describe", "missing"},
+		{"describe", "local", "missing.Service"},
+		{"call", "local", "GetUser"},
+		{"call", "local", "testserver.AnotherService/GetUser", "-H", "broken"},
+		{"run", "missing"},
+		{"run", "smoke", "-data", "missing.csv"},
+	} {
+		if code, _, stderr := execCLI(app, "", args...); code != exitError || stderr == "" {
+			t.Errorf("%v = %d, expected usage error", args, code)
+		}
+	}
+
+	if code, out, _ := execCLI(app, "", "help"); code != exitOK || !strings.Contains(out, "grpc-gui <команда>") {
+		t.Errorf("help = %d %s", code, out)
+	}
+	if code, _, stderr := execCLI(app, "", "run", "-h"); code != exitOK || !strings.Contains(stderr, "-stop-on-failure") {
+		t.Errorf("run -h = %d %s", code, stderr)
+	}
+}
@@ -5,9 +5,20 @@
 // @ts-ignore: Unused imports
 import type { Events } from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import type * as main$0 from "../../../../../grpc-gui/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import type * as models$0 from "../../../../../grpc-gui/internal/models/models.js";
+
 declare module "@wailsio/runtime" {
     namespace Events {
         interface CustomEvents {
+            "collection:run": main$0.RunProgress;
+            "server:health": models$0.HealthCheck;
+            "server:reflection": main$0.ServerWithReflection;
+            "server:schema-changed": main$0.SchemaChange;
             "time": string;
         }
     }
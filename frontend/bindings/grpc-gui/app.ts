@@ -5,25 +5,98 @@
 // @ts-ignore: Unused imports
 import { Call as $Call, CancellablePromise as $CancellablePromise } from "@wailsio/runtime";
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as assertion$0 from "./internal/assertion/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as extract$0 from "./internal/extract/models.js";
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import * as grpcreflect$0 from "./internal/grpcreflect/models.js";
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
+import * as grpcrequest$0 from "./internal/grpcrequest/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
 import * as models$0 from "./internal/models/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as runner$0 from "./internal/runner/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as search$0 from "./internal/search/models.js";
 
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import * as $models from "./models.js";
 
+/**
+ * CheckServerHealth сразу опрашивает grpc.health.v1.Health/Check сервера.
+ */
+export function CheckServerHealth(serverId: number): $CancellablePromise<models$0.HealthCheck | null> {
+    return $Call.ByID(2332555170, serverId);
+}
+
+export function ClearExtractedVariables(): $CancellablePromise<void> {
+    return $Call.ByID(3466525307);
+}
+
+export function CreateCollection(name: string, description: string): $CancellablePromise<number> {
+    return $Call.ByID(2603031537, name, description);
+}
+
+/**
+ * CreateEnvironment создает окружение. serverId = 0 - глобальное окружение.
+ */
+export function CreateEnvironment(name: string, serverId: number, variables: { [_ in string]?: string } | null): $CancellablePromise<number> {
+    return $Call.ByID(2929247706, name, serverId, variables);
+}
+
+/**
+ * CreateFolder создает папку в конце родителя. parentId = 0 - корень коллекции.
+ */
+export function CreateFolder(collectionId: number, parentId: number, name: string): $CancellablePromise<number> {
+    return $Call.ByID(1403526973, collectionId, parentId, name);
+}
+
+/**
+ * CreateSavedRequest сохраняет запрос в конец папки request.FolderID коллекции request.CollectionID.
+ */
+export function CreateSavedRequest(request: models$0.SavedRequest): $CancellablePromise<number> {
+    return $Call.ByID(95531485, request);
+}
+
 export function CreateServer(name: string, address: string, useTLS: boolean, insecure: boolean): $CancellablePromise<number> {
     return $Call.ByID(3177189426, name, address, useTLS, insecure);
 }
 
+/**
+ * DeleteCollection удаляет коллекцию вместе с папками и запросами.
+ */
+export function DeleteCollection(id: number): $CancellablePromise<void> {
+    return $Call.ByID(2537942162, id);
+}
+
+export function DeleteEnvironment(id: number): $CancellablePromise<void> {
+    return $Call.ByID(3611611839, id);
+}
+
+/**
+ * DeleteFolder удаляет папку со всеми вложенными папками и запросами.
+ */
+export function DeleteFolder(id: number): $CancellablePromise<void> {
+    return $Call.ByID(127207050, id);
+}
+
 export function DeleteHistoryItem(id: number): $CancellablePromise<void> {
     return $Call.ByID(2090475737, id);
 }
 
+export function DeleteSavedRequest(id: number): $CancellablePromise<void> {
+    return $Call.ByID(3966486854, id);
+}
+
 export function DeleteServer(id: number): $CancellablePromise<void> {
     return $Call.ByID(963928181, id);
 }
@@ -32,8 +105,90 @@ export function DeleteTabState(tabID: string): $CancellablePromise<void> {
     return $Call.ByID(1010453490, tabID);
 }
 
-export function DoGRPCRequest(serverId: number, address: string, service: string, method: string, payload: string, requestHeaders: { [_ in string]?: string } | null, contextValues: { [_ in string]?: string } | null): $CancellablePromise<[string, number]> {
-    return $Call.ByID(531879593, serverId, address, service, method, payload, requestHeaders, contextValues);
+export function DoGRPCRequest(serverId: number, address: string, service: string, method: string, payload: string, requestHeaders: { [_ in string]?: string } | null, reqContext: grpcrequest$0.RequestContext | null): $CancellablePromise<$models.RequestResult | null> {
+    return $Call.ByID(531879593, serverId, address, service, method, payload, requestHeaders, reqContext);
+}
+
+/**
+ * DoSavedRequest отправляет сохраненный запрос с правками из вкладки: сервер и метод берутся из
+ * сохраненного запроса, вместе с проверками метода выполняются проверки самого запроса.
+ */
+export function DoSavedRequest(id: number, address: string, payload: string, requestHeaders: { [_ in string]?: string } | null, reqContext: grpcrequest$0.RequestContext | null): $CancellablePromise<$models.RequestResult | null> {
+    return $Call.ByID(784668072, id, address, payload, requestHeaders, reqContext);
+}
+
+/**
+ * DuplicateCollection копирует коллекцию со всеми папками и запросами.
+ */
+export function DuplicateCollection(id: number): $CancellablePromise<number> {
+    return $Call.ByID(3710874438, id);
+}
+
+/**
+ * DuplicateSavedRequest создает копию запроса сразу после оригинала.
+ */
+export function DuplicateSavedRequest(id: number): $CancellablePromise<number> {
+    return $Call.ByID(935874522, id);
+}
+
+/**
+ * FindTypeUsages возвращает методы сервера, в запрос или ответ которых входит тип.
+ * Помогает оценить, что заденет изменение общего сообщения.
+ */
+export function FindTypeUsages(serverId: number, typeName: string): $CancellablePromise<grpcreflect$0.TypeUsage[] | null> {
+    return $Call.ByID(4147425466, serverId, typeName);
+}
+
+/**
+ * FormatRunReport печатает отчет прогона в формате "junit" или "json".
+ */
+export function FormatRunReport(report: runner$0.Report | null, format: string): $CancellablePromise<string> {
+    return $Call.ByID(524801637, report, format);
+}
+
+/**
+ * GetAnyTypeExample возвращает пример значения google.protobuf.Any для выбранного типа.
+ * Тип ищется через рефлексию сервера, поэтому подходят и сообщения вне сигнатур методов.
+ */
+export function GetAnyTypeExample(serverId: number, typeName: string): $CancellablePromise<string> {
+    return $Call.ByID(622780287, serverId, typeName);
+}
+
+/**
+ * GetAssertions возвращает проверки метода в порядке выполнения.
+ */
+export function GetAssertions(serverId: number, service: string, method: string): $CancellablePromise<assertion$0.Assertion[] | null> {
+    return $Call.ByID(3989195478, serverId, service, method);
+}
+
+export function GetCollectionItems(id: number): $CancellablePromise<$models.CollectionItems | null> {
+    return $Call.ByID(4133903105, id);
+}
+
+export function GetCollections(): $CancellablePromise<models$0.Collection[] | null> {
+    return $Call.ByID(4271397524);
+}
+
+export function GetEnvironments(): $CancellablePromise<models$0.Environment[] | null> {
+    return $Call.ByID(2412777393);
+}
+
+/**
+ * GetExtractedVariables возвращает значения, извлеченные из ответов и заданные скриптами за время работы приложения.
+ */
+export function GetExtractedVariables(): $CancellablePromise<{ [_ in string]?: string } | null> {
+    return $Call.ByID(3805628202);
+}
+
+/**
+ * GetExtractionRules возвращает правила извлечения метода в порядке выполнения.
+ */
+export function GetExtractionRules(serverId: number, service: string, method: string): $CancellablePromise<extract$0.Rule[] | null> {
+    return $Call.ByID(3144499821, serverId, service, method);
+}
+
+export function GetFakeJsonExample(msg: grpcreflect$0.MessageInfo | null, seed: number): $CancellablePromise<string> {
+    return $Call.ByID(2576248006, msg, seed);
 }
 
 export function GetHistory(serverId: number, limit: number): $CancellablePromise<models$0.History[] | null> {
@@ -48,6 +203,62 @@ export function GetJsonExample(msg: grpcreflect$0.MessageInfo | null): $Cancella
     return $Call.ByID(1994665097, msg);
 }
 
+/**
+ * GetJsonExampleForOneofs возвращает шаблон запроса с выбранными вариантами oneof.
+ * Ключ выбора - "<полное имя сообщения>.<группа>", значение - имя поля.
+ */
+export function GetJsonExampleForOneofs(msg: grpcreflect$0.MessageInfo | null, selection: { [_ in string]?: string } | null): $CancellablePromise<string> {
+    return $Call.ByID(796948070, msg, selection);
+}
+
+/**
+ * GetMethodInfo возвращает сообщения, примеры и схему метода.
+ * Список сервисов содержит только сигнатуры, детали загружаются при выборе метода.
+ */
+export function GetMethodInfo(serverId: number, service: string, method: string): $CancellablePromise<grpcreflect$0.MethodInfo | null> {
+    return $Call.ByID(1594320950, serverId, service, method);
+}
+
+/**
+ * GetMethodScript возвращает скрипты метода, пустые, если они не заданы.
+ */
+export function GetMethodScript(serverId: number, service: string, method: string): $CancellablePromise<models$0.MethodScript | null> {
+    return $Call.ByID(2494219909, serverId, service, method);
+}
+
+/**
+ * GetMethodTypeGraph возвращает граф типов запроса и ответа метода.
+ */
+export function GetMethodTypeGraph(serverId: number, service: string, method: string): $CancellablePromise<grpcreflect$0.TypeGraph | null> {
+    return $Call.ByID(1650965470, serverId, service, method);
+}
+
+/**
+ * GetMethodTypeGraphDOT возвращает граф типов метода в формате Graphviz.
+ */
+export function GetMethodTypeGraphDOT(serverId: number, service: string, method: string): $CancellablePromise<string> {
+    return $Call.ByID(2049792741, serverId, service, method);
+}
+
+export function GetSavedRequest(id: number): $CancellablePromise<models$0.SavedRequest | null> {
+    return $Call.ByID(1775664183, id);
+}
+
+/**
+ * GetServerHealth возвращает статус сервера и последние проверки, новые первыми.
+ */
+export function GetServerHealth(serverId: number, limit: number): $CancellablePromise<$models.ServerHealth | null> {
+    return $Call.ByID(1700781234, serverId, limit);
+}
+
+/**
+ * GetServerOpenAPI строит документ по сохраненной схеме и дескрипторам, поэтому он воспроизводим
+ * из того же кеша и доступен без сервера. К серверу обращаемся, только если схема еще не сохранялась.
+ */
+export function GetServerOpenAPI(id: number): $CancellablePromise<string> {
+    return $Call.ByID(1692096412, id);
+}
+
 export function GetServerReflection(id: number): $CancellablePromise<models$0.Server | null> {
     return $Call.ByID(3482647849, id);
 }
@@ -64,18 +275,126 @@ export function GetServersWithReflection(): $CancellablePromise<$models.ServerWi
     return $Call.ByID(3123129712);
 }
 
+/**
+ * GetServiceInfo возвращает сигнатуры методов сервиса, пока полная схема сервера еще загружается.
+ * Без связи с сервером методы берутся из последней сохраненной схемы.
+ */
+export function GetServiceInfo(serverId: number, service: string): $CancellablePromise<grpcreflect$0.ServiceInfo | null> {
+    return $Call.ByID(175536650, serverId, service);
+}
+
 export function GetTabStates(): $CancellablePromise<models$0.TabState[] | null> {
     return $Call.ByID(473154136);
 }
 
+/**
+ * GetVariables возвращает переменные, которые увидит запрос к серверу:
+ * активное глобальное окружение, поверх него активное окружение сервера,
+ * поверх всего значения, извлеченные из предыдущих ответов.
+ */
+export function GetVariables(serverId: number): $CancellablePromise<{ [_ in string]?: string } | null> {
+    return $Call.ByID(373724816, serverId);
+}
+
+/**
+ * MoveFolder переносит папку в parentId на позицию position среди его папок и запросов.
+ */
+export function MoveFolder(id: number, parentId: number, position: number): $CancellablePromise<void> {
+    return $Call.ByID(3833907176, id, parentId, position);
+}
+
+/**
+ * MoveSavedRequest переносит запрос в папку folderId на позицию position среди ее папок и запросов.
+ */
+export function MoveSavedRequest(id: number, folderId: number, position: number): $CancellablePromise<void> {
+    return $Call.ByID(1766125848, id, folderId, position);
+}
+
+export function RenameFolder(id: number, name: string): $CancellablePromise<void> {
+    return $Call.ByID(3733990943, id, name);
+}
+
+/**
+ * RunCollection прогоняет запросы коллекции по порядку, результаты приходят событиями по мере выполнения.
+ */
+export function RunCollection(collectionId: number, opts: $models.RunOptions): $CancellablePromise<runner$0.Report | null> {
+    return $Call.ByID(3316663426, collectionId, opts);
+}
+
+/**
+ * SaveAssertions проверяет и заменяет проверки метода. Пустой список удаляет все проверки.
+ */
+export function SaveAssertions(serverId: number, service: string, method: string, assertions: assertion$0.Assertion[] | null): $CancellablePromise<void> {
+    return $Call.ByID(3972976749, serverId, service, method, assertions);
+}
+
+/**
+ * SaveExtractionRules заменяет правила метода. Пустой список удаляет все правила.
+ */
+export function SaveExtractionRules(serverId: number, service: string, method: string, rules: extract$0.Rule[] | null): $CancellablePromise<void> {
+    return $Call.ByID(3623718508, serverId, service, method, rules);
+}
+
+/**
+ * SaveMethodScript проверяет синтаксис и сохраняет скрипты метода. Два пустых скрипта удаляют запись.
+ */
+export function SaveMethodScript(serverId: number, service: string, method: string, preRequest: string, postResponse: string): $CancellablePromise<void> {
+    return $Call.ByID(2392249246, serverId, service, method, preRequest, postResponse);
+}
+
 export function SaveTabStates(tabStates: models$0.TabState[] | null): $CancellablePromise<void> {
     return $Call.ByID(4192036329, tabStates);
 }
 
+/**
+ * SearchSymbols ищет сервисы, методы, сообщения, поля и перечисления по всем серверам.
+ * Используется закэшированная рефлексия, сами серверы не опрашиваются.
+ */
+export function SearchSymbols(query: string, limit: number): $CancellablePromise<search$0.Hit[] | null> {
+    return $Call.ByID(342316914, query, limit);
+}
+
+/**
+ * SetActiveEnvironment включает окружение, остальные окружения той же области выключаются.
+ */
+export function SetActiveEnvironment(id: number, active: boolean): $CancellablePromise<void> {
+    return $Call.ByID(3268371178, id, active);
+}
+
+/**
+ * SetOpenServers сообщает, какие серверы открыты во вкладках.
+ * Их схема, как и схема избранных серверов, обновляется в фоне, а статус отслеживается через Health/Watch.
+ */
+export function SetOpenServers(ids: number[] | null): $CancellablePromise<void> {
+    return $Call.ByID(3359405277, ids);
+}
+
+/**
+ * SetServerHealthService задает имя сервиса для проверок, пустое - статус сервера целиком.
+ */
+export function SetServerHealthService(serverId: number, service: string): $CancellablePromise<void> {
+    return $Call.ByID(1822820325, serverId, service);
+}
+
 export function ToggleFavoriteServer(serverID: number): $CancellablePromise<void> {
     return $Call.ByID(372922338, serverID);
 }
 
+export function UpdateCollection(id: number, name: string, description: string): $CancellablePromise<void> {
+    return $Call.ByID(1405316192, id, name, description);
+}
+
+export function UpdateEnvironment(id: number, name: string, variables: { [_ in string]?: string } | null): $CancellablePromise<void> {
+    return $Call.ByID(2862569693, id, name, variables);
+}
+
+/**
+ * UpdateSavedRequest меняет содержимое запроса, коллекция, папка и позиция не меняются.
+ */
+export function UpdateSavedRequest(request: models$0.SavedRequest): $CancellablePromise<void> {
+    return $Call.ByID(2135021816, request);
+}
+
 export function UpdateServer(id: number, name: string, address: string, useTLS: boolean, insecure: boolean): $CancellablePromise<void> {
     return $Call.ByID(3010445599, id, name, address, useTLS, insecure);
 }
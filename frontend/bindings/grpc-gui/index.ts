@@ -7,10 +7,17 @@ export {
 };
 
 export {
+    ReflectionStatus,
     ValidationStatus
 } from "./models.js";
 
 export type {
+    CollectionItems,
+    RequestResult,
+    RunOptions,
+    RunProgress,
+    SchemaChange,
+    ServerHealth,
     ServerWithReflection,
     ValidationResult
 } from "./models.js";
@@ -0,0 +1,76 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+/**
+ * Assertion - проверка ответа.
+ */
+export interface Assertion {
+    "kind": Kind;
+    "path"?: string;
+    "operator"?: Operator;
+    "expected"?: string;
+}
+
+/**
+ * Kind - что проверяет утверждение.
+ */
+export enum Kind {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * KindStatus - код ответа, Expected - имя кода (OK, NOT_FOUND) или число
+     */
+    KindStatus = "status",
+
+    /**
+     * KindBody - значение по пути Path в JSON ответа
+     */
+    KindBody = "body",
+
+    /**
+     * KindHeader и KindTrailer - значение ключа метаданных Path, несколько значений через запятую
+     */
+    KindHeader = "header",
+    KindTrailer = "trailer",
+
+    /**
+     * KindDuration - время выполнения, Expected - длительность ("200ms") или число миллисекунд
+     */
+    KindDuration = "duration",
+
+    /**
+     * KindSchema - тело ответа соответствует JSON Schema из Expected
+     */
+    KindSchema = "schema",
+};
+
+export enum Operator {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    OpEquals = "eq",
+    OpNotEquals = "ne",
+    OpGreater = "gt",
+    OpGreaterOrEq = "gte",
+    OpLess = "lt",
+    OpLessOrEq = "lte",
+    OpContains = "contains",
+    OpMatches = "matches",
+    OpExists = "exists",
+    OpNotExists = "notExists",
+};
+
+/**
+ * Result - итог проверки. Actual - фактическое значение, если его удалось получить.
+ */
+export interface Result {
+    "assertion": Assertion;
+    "passed": boolean;
+    "actual"?: string;
+    "message"?: string;
+}
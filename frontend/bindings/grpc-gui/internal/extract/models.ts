@@ -0,0 +1,38 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+/**
+ * Rule - правило, которое после ответа записывает значение в переменную.
+ */
+export interface Rule {
+    "variable": string;
+    "source": Source;
+    "expression": string;
+}
+
+export enum Source {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    /**
+     * SourceBody - выражение пути по JSON ответа
+     */
+    SourceBody = "body",
+
+    /**
+     * SourceHeader и SourceTrailer - имя ключа метаданных ответа
+     */
+    SourceHeader = "header",
+    SourceTrailer = "trailer",
+};
+
+/**
+ * Value - результат правила. При ошибке Value пустое, переменная не меняется.
+ */
+export interface Value {
+    "variable": string;
+    "value"?: string;
+    "error"?: string;
+}
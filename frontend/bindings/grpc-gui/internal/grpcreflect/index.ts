@@ -1,11 +1,22 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export {
+    TypeNodeKind
+} from "./models.js";
+
 export type {
     EnumValueInfo,
     FieldInfo,
+    FieldValidation,
+    HTTPRule,
     MessageInfo,
     MethodInfo,
+    SchemaDiff,
     ServiceInfo,
-    ServicesInfo
+    ServicesInfo,
+    TypeEdge,
+    TypeGraph,
+    TypeNode,
+    TypeUsage
 } from "./models.js";
@@ -26,6 +26,41 @@ export interface FieldInfo {
     "oneofGroup"?: string;
     "message"?: MessageInfo | null;
     "enumValues"?: EnumValueInfo[] | null;
+    "validation"?: FieldValidation | null;
+    "fieldMaskPaths"?: string[] | null;
+    "defaultValue"?: string;
+    "hasPresence"?: boolean;
+    "delimited"?: boolean;
+    "isExtension"?: boolean;
+}
+
+/**
+ * FieldValidation - упрощенное представление правил protoc-gen-validate,
+ * достаточное для генерации правдоподобных значений.
+ */
+export interface FieldValidation {
+    "required"?: boolean;
+    "in"?: string[] | null;
+    "minLen"?: number | null;
+    "maxLen"?: number | null;
+    "pattern"?: string;
+    "prefix"?: string;
+    "suffix"?: string;
+    "format"?: string;
+    "min"?: number | null;
+    "max"?: number | null;
+    "exclusiveMin"?: boolean;
+    "exclusiveMax"?: boolean;
+    "minItems"?: number | null;
+    "maxItems"?: number | null;
+    "definedOnly"?: boolean;
+}
+
+export interface HTTPRule {
+    "method": string;
+    "path": string;
+    "body"?: string;
+    "responseBody"?: string;
 }
 
 export interface MessageInfo {
@@ -43,6 +78,20 @@ export interface MethodInfo {
     "requestExampleString"?: string;
     "responseExample"?: json$0.RawMessage;
     "requestSchema"?: json$0.RawMessage;
+    "httpRules"?: HTTPRule[] | null;
+    "schemaHash"?: string;
+}
+
+/**
+ * SchemaDiff - что поменялось в схеме сервера между двумя обновлениями рефлексии.
+ * Методы записываются как "<сервис>/<метод>".
+ */
+export interface SchemaDiff {
+    "addedServices"?: string[] | null;
+    "removedServices"?: string[] | null;
+    "addedMethods"?: string[] | null;
+    "removedMethods"?: string[] | null;
+    "changedMethods"?: string[] | null;
 }
 
 export interface ServiceInfo {
@@ -52,4 +101,55 @@ export interface ServiceInfo {
 
 export interface ServicesInfo {
     "services": ServiceInfo[] | null;
+    "messageTypes"?: string[] | null;
+    "schemaHash"?: string;
+}
+
+/**
+ * TypeEdge - ссылка из сообщения на тип поля. У метода ребра "request" и "response".
+ */
+export interface TypeEdge {
+    "from": string;
+    "to": string;
+    "field": string;
+    "repeated"?: boolean;
+    "map"?: boolean;
+}
+
+/**
+ * TypeGraph - граф зависимостей типов метода.
+ */
+export interface TypeGraph {
+    "root": string;
+    "nodes": TypeNode[] | null;
+    "edges": TypeEdge[] | null;
+}
+
+export interface TypeNode {
+    "name": string;
+    "kind": TypeNodeKind;
+}
+
+export enum TypeNodeKind {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    TypeNodeMethod = "method",
+    TypeNodeMessage = "message",
+    TypeNodeEnum = "enum",
+};
+
+/**
+ * TypeUsage - метод, в запрос или ответ которого входит тип.
+ * RequestPath и ResponsePath - цепочка полей от сообщения метода до типа, например ["ComplexRequest.user", "User.address"].
+ */
+export interface TypeUsage {
+    "service": string;
+    "method": string;
+    "inRequest": boolean;
+    "inResponse": boolean;
+    "requestPath"?: string[] | null;
+    "responsePath"?: string[] | null;
 }
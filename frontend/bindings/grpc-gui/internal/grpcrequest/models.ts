@@ -0,0 +1,50 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+/**
+ * BinaryMetadata - бинарное значение заголовка или трейлера в читаемом виде.
+ */
+export interface BinaryMetadata {
+    "trailer"?: boolean;
+    "key": string;
+    "base64": string;
+    "hex": string;
+
+    /**
+     * Type и JSON заполнены, если значение разобралось как сообщение известного типа
+     */
+    "type"?: string;
+    "json"?: string;
+}
+
+/**
+ * CallCredentials - учетные данные вызова, отправляются заголовком authorization.
+ */
+export interface CallCredentials {
+    "type": string;
+    "token"?: string;
+    "username"?: string;
+    "password"?: string;
+}
+
+/**
+ * RequestContext - параметры вызова, которые реально уходят на сервер.
+ */
+export interface RequestContext {
+    /**
+     * Timeout - дедлайн вызова в формате time.ParseDuration, например "500ms" или "5s"
+     */
+    "timeout"?: string;
+
+    /**
+     * Compression - компрессор запроса, поддерживается gzip
+     */
+    "compression"?: string;
+    "waitForReady"?: boolean;
+    "credentials"?: CallCredentials | null;
+
+    /**
+     * Metadata - значения контекста, которые передаются серверу метаданными вызова
+     */
+    "metadata"?: { [_ in string]?: string } | null;
+}
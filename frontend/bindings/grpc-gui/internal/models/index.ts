@@ -2,7 +2,16 @@
 // This file is automatically generated. DO NOT EDIT
 
 export type {
+    Collection,
+    Environment,
+    Folder,
+    HealthCheck,
     History,
+    MethodScript,
+    SavedAssertion,
+    SavedCredentials,
+    SavedRequest,
+    SavedRequestContext,
     Server,
     TabState
 } from "./models.js";
@@ -8,6 +8,58 @@ import * as gorm$0 from "../../../gorm.io/gorm/models.js";
 // @ts-ignore: Unused imports
 import * as time$0 from "../../../time/models.js";
 
+/**
+ * Collection - библиотека сохраненных запросов.
+ */
+export interface Collection {
+    "id": number;
+    "createdAt": time$0.Time;
+    "updatedAt": time$0.Time;
+    "name": string;
+    "description": string;
+}
+
+/**
+ * Environment - именованный набор переменных. Глобальные окружения (ServerID = 0) действуют
+ * для всех серверов, серверные переопределяют их значения. В каждой области активно не больше одного.
+ */
+export interface Environment {
+    "id": number;
+    "createdAt": time$0.Time;
+    "updatedAt": time$0.Time;
+    "name": string;
+    "serverId": number;
+    "active": boolean;
+    "variables": { [_ in string]?: string } | null;
+}
+
+/**
+ * Folder - папка коллекции. ParentID = 0 - папка в корне коллекции.
+ * Папки и запросы одного родителя упорядочены общим Position.
+ */
+export interface Folder {
+    "id": number;
+    "createdAt": time$0.Time;
+    "updatedAt": time$0.Time;
+    "collectionId": number;
+    "parentId": number;
+    "position": number;
+    "name": string;
+}
+
+/**
+ * HealthCheck - результат одной проверки grpc.health.v1, из них складывается история доступности.
+ */
+export interface HealthCheck {
+    "id": number;
+    "createdAt": time$0.Time;
+    "serverId": number;
+    "service"?: string;
+    "status": string;
+    "latencyMs": number;
+    "error"?: string;
+}
+
 export interface History {
     "id": number;
     "createdAt": time$0.Time;
@@ -17,6 +69,16 @@ export interface History {
     "server"?: Server;
     "request": string;
     "response": string;
+
+    /**
+     * Address - адрес, по которому ушел запрос, после подстановки переменных
+     */
+    "address"?: string;
+
+    /**
+     * RequestTemplate - JSON запроса до подстановки переменных, пустой, если переменных не было
+     */
+    "requestTemplate"?: string;
     "service": string;
     "method": string;
     "statusCode": number;
@@ -26,8 +88,120 @@ export interface History {
      */
     "executionTime": number;
     "requestHeaders"?: string;
+
+    /**
+     * ResponseHeaders и ResponseTrailers - JSON объекты со всеми значениями каждого ключа
+     */
     "responseHeaders"?: string;
-    "contextValues"?: string;
+    "responseTrailers"?: string;
+
+    /**
+     * RequestContext - JSON параметров вызова: таймаут, компрессия, wait-for-ready, учетные данные и метаданные
+     */
+    "requestContext"?: string;
+
+    /**
+     * StatusDetails - JSON массив google.rpc.Status.details
+     */
+    "statusDetails"?: string;
+
+    /**
+     * StatusMessage - сообщение статуса или текст ошибки, если до вызова не дошло
+     */
+    "statusMessage"?: string;
+
+    /**
+     * ErrorKind - этап, на котором сломался вызов: dial, reflection, payload, transport, deadline, status, response
+     */
+    "errorKind"?: string;
+
+    /**
+     * Extracted - JSON массив значений, извлеченных правилами метода после ответа
+     */
+    "extracted"?: string;
+
+    /**
+     * ScriptLogs - JSON массив строк console.log и ошибок скриптов метода
+     */
+    "scriptLogs"?: string;
+
+    /**
+     * Assertions - JSON массив результатов проверок, AssertionsPassed пустой, если проверок не было
+     */
+    "assertions"?: string;
+    "assertionsPassed"?: boolean | null;
+
+    /**
+     * SavedRequestID - сохраненный запрос, из которого отправлен вызов, 0 - обычный вызов
+     */
+    "savedRequestId"?: number;
+}
+
+/**
+ * MethodScript - скрипты метода: PreRequest выполняется перед отправкой, PostResponse после ответа.
+ */
+export interface MethodScript {
+    "id": number;
+    "createdAt": time$0.Time;
+    "updatedAt": time$0.Time;
+    "serverId": number;
+    "service": string;
+    "method": string;
+    "preRequest": string;
+    "postResponse": string;
+}
+
+/**
+ * SavedAssertion - проверка сохраненного запроса, по JSON совпадает с assertion.Assertion.
+ */
+export interface SavedAssertion {
+    "kind": string;
+    "path"?: string;
+    "operator"?: string;
+    "expected"?: string;
+}
+
+/**
+ * SavedCredentials - учетные данные вызова, по JSON совпадает с grpcrequest.CallCredentials.
+ */
+export interface SavedCredentials {
+    "type": string;
+    "token"?: string;
+    "username"?: string;
+    "password"?: string;
+}
+
+/**
+ * SavedRequest - сохраненный вызов. Address, Payload, Headers и Context - шаблоны с {{переменными}},
+ * пустой Address - адрес сервера. FolderID = 0 - запрос в корне коллекции.
+ */
+export interface SavedRequest {
+    "id": number;
+    "createdAt": time$0.Time;
+    "updatedAt": time$0.Time;
+    "collectionId": number;
+    "folderId": number;
+    "position": number;
+    "name": string;
+    "serverId": number;
+    "service": string;
+    "method": string;
+    "address": string;
+    "payload": string;
+    "headers": { [_ in string]?: string } | null;
+    "context": SavedRequestContext | null;
+    "assertions": SavedAssertion[] | null;
+}
+
+/**
+ * SavedRequestContext - параметры вызова сохраненного запроса, по JSON совпадает с grpcrequest.RequestContext.
+ */
+export interface SavedRequestContext {
+    "timeout"?: string;
+    "compression"?: string;
+    "waitForReady"?: boolean;
+    "credentials"?: SavedCredentials | null;
+    "metadata"?: { [_ in string]?: string } | null;
 }
 
 export interface Server {
@@ -40,6 +214,14 @@ export interface Server {
     "favorite": boolean;
     "optUseTLS": boolean;
     "optInsecure": boolean;
+
+    /**
+     * HealthService - имя сервиса для grpc.health.v1, пустое - статус сервера целиком
+     */
+    "healthService": string;
+    "healthStatus": string;
+    "healthCheckedAt": time$0.Time;
+    "healthError"?: string;
 }
 
 export interface TabState {
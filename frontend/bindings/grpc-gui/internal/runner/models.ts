@@ -0,0 +1,72 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as assertion$0 from "../assertion/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../../../time/models.js";
+
+/**
+ * Iteration - один проход коллекции. Data - строка файла данных.
+ */
+export interface Iteration {
+    "index": number;
+    "data"?: { [_ in string]?: string } | null;
+    "results": Result[] | null;
+}
+
+/**
+ * Report - итог прогона коллекции. Время везде в миллисекундах.
+ */
+export interface Report {
+    "collection": string;
+    "startedAt": time$0.Time;
+    "duration": number;
+    "summary": Summary;
+    "iterations": Iteration[] | null;
+
+    /**
+     * Stopped - прогон прерван: упал запрос при StopOnFailure или отменен
+     */
+    "stopped": boolean;
+}
+
+/**
+ * Result - итог одного запроса прогона.
+ */
+export interface Result {
+    "requestId": number;
+    "name": string;
+    "path": string;
+    "service": string;
+    "method": string;
+    "status": Status;
+    "code": number;
+    "codeName"?: string;
+    "statusMessage"?: string;
+    "errorKind"?: string;
+    "error"?: string;
+    "response"?: string;
+    "duration": number;
+    "assertions"?: assertion$0.Result[] | null;
+}
+
+export enum Status {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    StatusPassed = "passed",
+    StatusFailed = "failed",
+    StatusSkipped = "skipped",
+};
+
+export interface Summary {
+    "total": number;
+    "passed": number;
+    "failed": number;
+    "skipped": number;
+}
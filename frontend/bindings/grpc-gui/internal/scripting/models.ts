@@ -0,0 +1,21 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+/**
+ * LogEntry - строка console.log и ошибки скрипта.
+ */
+export interface LogEntry {
+    "phase": Phase;
+    "level": string;
+    "message": string;
+}
+
+export enum Phase {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    PhasePreRequest = "pre-request",
+    PhasePostResponse = "post-response",
+};
@@ -0,0 +1,41 @@
+// Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
+// This file is automatically generated. DO NOT EDIT
+
+/**
+ * Entry - найденный символ и координаты, по которым его можно открыть.
+ * Для сообщений, полей и перечислений Service/Method указывают на первый метод, который их использует.
+ */
+export interface Entry {
+    "kind": Kind;
+    "name": string;
+    "fullName": string;
+    "comment"?: string;
+    "serverId": number;
+    "serverName": string;
+    "service"?: string;
+    "method"?: string;
+
+    /**
+     * Message - сообщение или перечисление, которому принадлежит поле или значение
+     */
+    "message"?: string;
+}
+
+export interface Hit {
+    "entry": Entry;
+    "score": number;
+}
+
+export enum Kind {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    KindService = "service",
+    KindMethod = "method",
+    KindMessage = "message",
+    KindField = "field",
+    KindEnum = "enum",
+    KindEnumValue = "enum_value",
+};
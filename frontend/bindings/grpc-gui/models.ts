@@ -1,17 +1,167 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as json$0 from "../encoding/json/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as assertion$0 from "./internal/assertion/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as extract$0 from "./internal/extract/models.js";
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
 import * as grpcreflect$0 from "./internal/grpcreflect/models.js";
 // eslint-disable-next-line @typescript-eslint/ban-ts-comment
 // @ts-ignore: Unused imports
+import * as grpcrequest$0 from "./internal/grpcrequest/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
 import * as models$0 from "./internal/models/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as runner$0 from "./internal/runner/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as scripting$0 from "./internal/scripting/models.js";
+// eslint-disable-next-line @typescript-eslint/ban-ts-comment
+// @ts-ignore: Unused imports
+import * as time$0 from "../time/models.js";
+
+/**
+ * CollectionItems - содержимое коллекции плоскими списками, дерево строит фронтенд по ParentID и FolderID.
+ */
+export interface CollectionItems {
+    "collection": models$0.Collection;
+    "folders": models$0.Folder[] | null;
+    "requests": models$0.SavedRequest[] | null;
+}
+
+/**
+ * ReflectionStatus отличает свежую схему от устаревшего кеша и от сервера, который ни разу не ответил.
+ */
+export enum ReflectionStatus {
+    /**
+     * The Go zero value for the underlying type of the enum.
+     */
+    $zero = "",
+
+    ReflectionStatusOK = "ok",
+    ReflectionStatusStale = "stale",
+    ReflectionStatusUnreachable = "unreachable",
+};
+
+/**
+ * RequestResult - результат вызова для фронтенда. Ошибка вызова не отклоняет промис,
+ * а приходит вместе с кодом, деталями статуса и трейлерами.
+ */
+export interface RequestResult {
+    "response": string;
+    "code": number;
+    "error"?: string;
+    "headers"?: { [_ in string]?: string[] | null } | null;
+    "trailers"?: { [_ in string]?: string[] | null } | null;
+    "statusDetails"?: json$0.RawMessage;
+    "statusMessage"?: string;
+    "errorKind"?: string;
+
+    /**
+     * BinaryMetadata - -bin заголовки и трейлеры в base64, hex и, если тип известен, в JSON.
+     * В Headers и Trailers такие значения тоже приходят в base64
+     */
+    "binaryMetadata"?: grpcrequest$0.BinaryMetadata[] | null;
+    "executionTime": number;
+
+    /**
+     * Extracted - результаты правил извлечения метода, удачные значения уже доступны как переменные
+     */
+    "extracted"?: extract$0.Value[] | null;
+
+    /**
+     * ScriptLogs - console.log и ошибки pre-request и post-response скриптов
+     */
+    "scriptLogs"?: scripting$0.LogEntry[] | null;
+
+    /**
+     * Assertions - результаты проверок метода, AssertionsPassed пустой, если проверок нет
+     */
+    "assertions"?: assertion$0.Result[] | null;
+    "assertionsPassed"?: boolean | null;
+}
+
+/**
+ * RunOptions - настройки прогона коллекции.
+ */
+export interface RunOptions {
+    /**
+     * EnvironmentID - окружение прогона, 0 - активные окружения, как при обычной отправке
+     */
+    "environmentId": number;
+
+    /**
+     * DataName и Data - имя и содержимое файла данных (.csv или .json), каждая строка - итерация
+     */
+    "dataName": string;
+    "data": string;
+    "stopOnFailure": boolean;
+}
+
+/**
+ * RunProgress - событие о выполненном запросе прогона.
+ */
+export interface RunProgress {
+    "collectionId": number;
+    "iteration": number;
+    "result": runner$0.Result;
+}
+
+/**
+ * SchemaChange - событие об изменении схемы сервера после обновления рефлексии.
+ */
+export interface SchemaChange {
+    "serverId": number;
+    "serverName": string;
+    "oldHash": string;
+    "newHash": string;
+    "diff": grpcreflect$0.SchemaDiff;
+}
+
+/**
+ * ServerHealth - текущий статус сервера и история проверок для доски статусов.
+ */
+export interface ServerHealth {
+    "serverId": number;
+    "status": string;
+    "checkedAt"?: time$0.Time | null;
+
+    /**
+     * Uptime - доля проверок со статусом SERVING, от 0 до 1. Серверы без grpc.health.v1 не учитываются
+     */
+    "uptime": number;
+    "history": models$0.HealthCheck[] | null;
+}
 
 export interface ServerWithReflection {
     "server": models$0.Server | null;
     "reflection": grpcreflect$0.ServicesInfo | null;
+    "status": ReflectionStatus;
     "error"?: string;
+
+    /**
+     * SchemaAt - время получения показанной схемы, для устаревшего кеша по нему видно ее возраст
+     */
+    "schemaAt"?: time$0.Time | null;
+
+    /**
+     * Pending - пока есть только список сервисов и методы из прошлого кеша, полная схема придет событием
+     */
+    "pending"?: boolean;
+
+    /**
+     * Warning - часть сервисов получить не удалось, сохраненная схема оставлена прежней
+     */
+    "warning"?: string;
 }
 
 export interface ValidationResult {
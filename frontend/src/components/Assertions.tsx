@@ -0,0 +1,173 @@
+import { For, Show, createSignal, onMount } from "solid-js";
+import styles from "./SendRequest.module.css";
+import { Assertion, Kind, Operator } from "../../bindings/grpc-gui/internal/assertion/models";
+import { $notifications, NotificationType } from "../stores/notifications";
+
+// Проверки хранятся у метода или у запроса из коллекции, поэтому загрузка и сохранение передаются снаружи
+export type AssertionsProps = {
+	title: string;
+	load: () => Promise<Assertion[] | null>;
+	save: (assertions: Assertion[]) => Promise<void>;
+};
+
+type AssertionRow = Assertion & { id: string };
+
+const kinds: { value: Kind; label: string }[] = [
+	{ value: Kind.KindStatus, label: "Код ответа" },
+	{ value: Kind.KindBody, label: "Тело" },
+	{ value: Kind.KindHeader, label: "Заголовок" },
+	{ value: Kind.KindTrailer, label: "Трейлер" },
+	{ value: Kind.KindDuration, label: "Время" },
+	{ value: Kind.KindSchema, label: "JSON Schema" },
+];
+
+const operators: { value: Operator; label: string }[] = [
+	{ value: Operator.OpEquals, label: "=" },
+	{ value: Operator.OpNotEquals, label: "≠" },
+	{ value: Operator.OpGreater, label: ">" },
+	{ value: Operator.OpGreaterOrEq, label: "≥" },
+	{ value: Operator.OpLess, label: "<" },
+	{ value: Operator.OpLessOrEq, label: "≤" },
+	{ value: Operator.OpContains, label: "содержит" },
+	{ value: Operator.OpMatches, label: "regexp" },
+	{ value: Operator.OpExists, label: "есть" },
+	{ value: Operator.OpNotExists, label: "нет" },
+];
+
+// Операторы, которые имеют смысл для каждого вида проверки
+const operatorsFor = (kind: Kind) => {
+	switch (kind) {
+		case Kind.KindStatus:
+			return operators.slice(0, 2);
+		case Kind.KindDuration:
+			return operators.slice(2, 6);
+		default:
+			return operators;
+	}
+};
+
+const defaults = (kind: Kind): Partial<Assertion> => {
+	switch (kind) {
+		case Kind.KindStatus:
+			return { operator: Operator.OpEquals, expected: "OK", path: "" };
+		case Kind.KindDuration:
+			return { operator: Operator.OpLess, expected: "200ms", path: "" };
+		case Kind.KindSchema:
+			return { operator: Operator.$zero, expected: '{"type": "object"}', path: "" };
+		default:
+			return { operator: Operator.OpExists };
+	}
+};
+
+const emptyRow = (): AssertionRow => ({
+	id: crypto.randomUUID(),
+	kind: Kind.KindStatus,
+	path: "",
+	operator: Operator.OpEquals,
+	expected: "OK",
+});
+
+export const Assertions = (props: AssertionsProps) => {
+	const [rows, setRows] = createSignal<AssertionRow[]>([]);
+
+	onMount(async () => {
+		try {
+			const assertions = await props.load();
+			setRows((assertions || []).map(a => ({ ...a, id: crypto.randomUUID() })));
+		} catch (error) {
+			console.error("Failed to load assertions:", error);
+		}
+	});
+
+	const updateRow = (id: string, patch: Partial<Assertion>) =>
+		setRows(rows().map(row => (row.id === id ? { ...row, ...patch } : row)));
+
+	const handleSave = async () => {
+		const assertions = rows().map(({ id, ...assertion }) => assertion);
+		try {
+			await props.save(assertions);
+			$notifications.addNotification({
+				message: "Проверки сохранены",
+				title: "Готово",
+				type: NotificationType.SUCCESS,
+			});
+		} catch (error) {
+			$notifications.addNotification({
+				message: error instanceof Error ? error.message : String(error),
+				title: "Ошибка",
+				type: NotificationType.ERROR,
+			});
+		}
+	};
+
+	const needsPath = (kind: Kind) => kind === Kind.KindBody || kind === Kind.KindHeader || kind === Kind.KindTrailer;
+	const needsExpected = (row: Assertion) => row.operator !== Operator.OpExists && row.operator !== Operator.OpNotExists;
+
+	return (
+		<div class={styles.keyValueList}>
+			<div class="text-sm font-semibold">{props.title}</div>
+			<For each={rows()}>
+				{row => (
+					<div class={styles.keyValueRow}>
+						<select
+							class="select select-sm select-bordered"
+							value={row.kind}
+							onChange={e => {
+								const kind = e.currentTarget.value as Kind;
+								updateRow(row.id, { kind, ...defaults(kind) });
+							}}>
+							<For each={kinds}>{kind => <option value={kind.value}>{kind.label}</option>}</For>
+						</select>
+						<Show when={needsPath(row.kind)}>
+							<input
+								type="text"
+								class="input input-sm font-mono"
+								placeholder={row.kind === Kind.KindBody ? "$.count" : "x-version"}
+								value={row.path ?? ""}
+								onInput={e => updateRow(row.id, { path: e.currentTarget.value })}
+							/>
+						</Show>
+						<Show when={row.kind !== Kind.KindSchema}>
+							<select
+								class="select select-sm select-bordered"
+								value={row.operator}
+								onChange={e => updateRow(row.id, { operator: e.currentTarget.value as Operator })}>
+								<For each={operatorsFor(row.kind)}>{op => <option value={op.value}>{op.label}</option>}</For>
+							</select>
+						</Show>
+						<Show when={needsExpected(row)}>
+							<Show
+								when={row.kind === Kind.KindSchema}
+								fallback={
+									<input
+										type="text"
+										class="input input-sm font-mono"
+										placeholder="Ожидаемое значение"
+										value={row.expected ?? ""}
+										onInput={e => updateRow(row.id, { expected: e.currentTarget.value })}
+									/>
+								}>
+								<textarea
+									class="textarea textarea-bordered font-mono text-sm flex-1"
+									value={row.expected ?? ""}
+									onInput={e => updateRow(row.id, { expected: e.currentTarget.value })}
+								/>
+							</Show>
+						</Show>
+						<button class="btn btn-sm btn-ghost" onClick={() => setRows(rows().filter(r => r.id !== row.id))}>
+							×
+						</button>
+					</div>
+				)}
+			</For>
+			<div class={styles.keyValueActions}>
+				<button class="btn btn-sm btn-neutral" onClick={() => setRows([...rows(), emptyRow()])}>
+					Добавить
+				</button>
+				<button class="btn btn-sm btn-primary" onClick={handleSave}>
+					Схоронить
+				</button>
+			</div>
+		</div>
+	);
+};
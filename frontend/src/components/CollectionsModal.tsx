@@ -0,0 +1,328 @@
+import { For, Show, createSignal, onMount } from "solid-js";
+import { $collections } from "../stores/collections";
+import { $tabs } from "../stores/tabs";
+import { $notifications, NotificationType } from "../stores/notifications";
+import { folderPaths } from "./SaveToCollectionModal";
+import { RunCollectionModal } from "./RunCollectionModal";
+import { Collection, Folder, SavedRequest } from "../../bindings/grpc-gui/internal/models/models";
+
+export type CollectionsModalProps = {
+	onClose: () => void;
+};
+
+// Node - элемент дерева коллекции; папки и запросы делят порядок внутри родителя
+type Node =
+	| { folder: Folder; request?: undefined }
+	| { folder?: undefined; request: SavedRequest };
+
+const nodePosition = (node: Node) => (node.folder ? node.folder.position : node.request.position);
+const nodeId = (node: Node) => (node.folder ? node.folder.id : node.request.id);
+
+// END_OF_LIST - позиция больше любой, элемент встает в конец родителя
+const END_OF_LIST = 1 << 30;
+
+export const CollectionsModal = (props: CollectionsModalProps) => {
+	const {
+		collections,
+		items,
+		loadCollections,
+		openCollection,
+		createCollection,
+		updateCollection,
+		deleteCollection,
+		duplicateCollection,
+		createFolder,
+		renameFolder,
+		deleteFolder,
+		moveFolder,
+		deleteSavedRequest,
+		duplicateSavedRequest,
+		moveSavedRequest,
+		updateSavedRequest,
+	} = $collections;
+	const { openSavedRequest } = $tabs;
+
+	const [newName, setNewName] = createSignal("");
+	const [showRun, setShowRun] = createSignal(false);
+
+	onMount(async () => {
+		await loadCollections();
+		if (!items() && collections().length > 0) {
+			await openCollection(collections()[0].id);
+		}
+	});
+
+	const run = async (fn: () => Promise<unknown>) => {
+		try {
+			await fn();
+		} catch (error) {
+			$notifications.addNotification({
+				message: error instanceof Error ? error.message : String(error),
+				title: "Ошибка",
+				type: NotificationType.ERROR,
+			});
+		}
+	};
+
+	const children = (parentId: number): Node[] => {
+		const current = items();
+		if (!current) return [];
+		const nodes: Node[] = [
+			...(current.folders ?? []).filter(f => f.parentId === parentId).map(folder => ({ folder })),
+			...(current.requests ?? []).filter(r => r.folderId === parentId).map(request => ({ request })),
+		];
+		return nodes.sort((a, b) => {
+			if (nodePosition(a) !== nodePosition(b)) return nodePosition(a) - nodePosition(b);
+			if (!!a.folder !== !!b.folder) return a.folder ? -1 : 1;
+			return nodeId(a) - nodeId(b);
+		});
+	};
+
+	const move = (node: Node, parentId: number, position: number) => {
+		const { folder, request } = node;
+		run(() =>
+			folder ? moveFolder(folder.id, parentId, position) : moveSavedRequest(request!.id, parentId, position),
+		);
+	};
+
+	const shift = (node: Node, parentId: number, delta: number) => {
+		const index = children(parentId).findIndex(
+			n => !!n.folder === !!node.folder && nodeId(n) === nodeId(node),
+		);
+		if (index + delta < 0) return;
+		move(node, parentId, index + delta);
+	};
+
+	const handleCreateCollection = (e: SubmitEvent) => {
+		e.preventDefault();
+		run(async () => {
+			const id = await createCollection(newName(), "");
+			setNewName("");
+			await openCollection(id);
+		});
+	};
+
+	const handleRenameCollection = (collection: Collection) => {
+		const name = prompt("Название коллекции", collection.name);
+		if (name) run(() => updateCollection(collection.id, name, collection.description));
+	};
+
+	const handleDeleteCollection = (collection: Collection) => {
+		if (confirm(`Удалить коллекцию «${collection.name}» со всеми запросами?`)) {
+			run(() => deleteCollection(collection.id));
+		}
+	};
+
+	const handleCreateFolder = (parentId: number) => {
+		const current = items();
+		const name = prompt("Название папки");
+		if (current && name) run(() => createFolder(current.collection.id, parentId, name));
+	};
+
+	const handleRename = (node: Node) => {
+		const { folder, request } = node;
+		const name = prompt("Название", folder ? folder.name : request!.name);
+		if (!name) return;
+		run(() => (folder ? renameFolder(folder.id, name) : updateSavedRequest({ ...request!, name })));
+	};
+
+	const handleDelete = (node: Node) => {
+		const { folder, request } = node;
+		if (folder) {
+			if (confirm(`Удалить папку «${folder.name}» со всем содержимым?`)) {
+				run(() => deleteFolder(folder.id));
+			}
+			return;
+		}
+		run(() => deleteSavedRequest(request!.id));
+	};
+
+	const handleOpen = (request: SavedRequest) => {
+		openSavedRequest(request);
+		props.onClose();
+	};
+
+	const Tree = (treeProps: { parentId: number; depth: number }) => (
+		<For each={children(treeProps.parentId)}>
+			{node => (
+				<>
+					<div
+						class="flex items-center gap-2 py-1 hover:bg-base-200 rounded px-1 group"
+						style={{ "padding-left": `${treeProps.depth * 1.25 + 0.25}rem` }}>
+						<Show
+							when={node.request}
+							fallback={<span class="font-semibold flex-1 truncate">📁 {node.folder?.name}</span>}>
+							{request => (
+								<button
+									class="flex-1 text-left truncate"
+									title="Открыть"
+									onClick={() => handleOpen(request())}>
+									<span>{request().name}</span>
+									<span class="text-xs text-base-content/50 font-mono ml-2">
+										{request().service}/{request().method}
+									</span>
+								</button>
+							)}
+						</Show>
+						<div class="flex gap-1 opacity-0 group-hover:opacity-100">
+							<Show when={node.folder}>
+								{folder => (
+									<button class="btn btn-xs btn-ghost" onClick={() => handleCreateFolder(folder().id)}>
+										+ папка
+									</button>
+								)}
+							</Show>
+							<Show when={node.request}>
+								{request => (
+									<button
+										class="btn btn-xs btn-ghost"
+										onClick={() => run(() => duplicateSavedRequest(request().id))}>
+										Копия
+									</button>
+								)}
+							</Show>
+							<select
+								class="select select-xs select-bordered"
+								title="Переместить в папку"
+								value=""
+								onChange={e => {
+									const target = Number(e.currentTarget.value);
+									e.currentTarget.value = "";
+									move(node, target, END_OF_LIST);
+								}}>
+								<option value="" disabled>
+									Переместить...
+								</option>
+								<option value={0}>Корень коллекции</option>
+								<For each={folderPaths(items()?.folders ?? []).filter(f => f.id !== node.folder?.id)}>
+									{f => <option value={f.id}>{f.path}</option>}
+								</For>
+							</select>
+							<button
+								class="btn btn-xs btn-ghost"
+								title="Выше"
+								onClick={() => shift(node, treeProps.parentId, -1)}>
+								↑
+							</button>
+							<button
+								class="btn btn-xs btn-ghost"
+								title="Ниже"
+								onClick={() => shift(node, treeProps.parentId, 1)}>
+								↓
+							</button>
+							<button class="btn btn-xs btn-ghost" onClick={() => handleRename(node)}>
+								Переименовать
+							</button>
+							<button class="btn btn-xs btn-ghost" onClick={() => handleDelete(node)}>
+								×
+							</button>
+						</div>
+					</div>
+					<Show when={node.folder}>
+						{folder => <Tree parentId={folder().id} depth={treeProps.depth + 1} />}
+					</Show>
+				</>
+			)}
+		</For>
+	);
+
+	return (
+		<>
+			<dialog class="modal modal-open" onClick={props.onClose}>
+				<div class="modal-box max-w-5xl" onClick={e => e.stopPropagation()}>
+					<h3 class="text-xl font-bold mb-2">Коллекции</h3>
+					<div class="text-sm text-base-content/50 mb-6">
+						Сохраненные запросы с телом, заголовками, параметрами вызова и проверками. Запрос сохраняется
+						кнопкой «В коллекцию» на вкладке метода.
+					</div>
+
+					<div class="flex gap-4 min-h-64">
+						<div class="w-60 shrink-0 space-y-1">
+							<For each={collections()}>
+								{collection => (
+									<div
+										class="flex items-center gap-1 rounded px-2 py-1 cursor-pointer hover:bg-base-200 group"
+										classList={{ "bg-base-200": items()?.collection.id === collection.id }}
+										onClick={() => run(() => openCollection(collection.id))}>
+										<span class="flex-1 truncate">{collection.name}</span>
+										<div class="flex opacity-0 group-hover:opacity-100" onClick={e => e.stopPropagation()}>
+											<button
+												class="btn btn-xs btn-ghost"
+												title="Переименовать"
+												onClick={() => handleRenameCollection(collection)}>
+												✎
+											</button>
+											<button
+												class="btn btn-xs btn-ghost"
+												title="Копия"
+												onClick={() => run(() => duplicateCollection(collection.id))}>
+												⧉
+											</button>
+											<button
+												class="btn btn-xs btn-ghost"
+												title="Удалить"
+												onClick={() => handleDeleteCollection(collection)}>
+												×
+											</button>
+										</div>
+									</div>
+								)}
+							</For>
+							<form onSubmit={handleCreateCollection} class="flex gap-1 pt-2">
+								<input
+									required
+									type="text"
+									class="input input-sm flex-1 min-w-0"
+									placeholder="Новая коллекция"
+									value={newName()}
+									onInput={e => setNewName(e.currentTarget.value)}
+								/>
+								<button type="submit" class="btn btn-sm btn-neutral">
+									+
+								</button>
+							</form>
+						</div>
+
+						<div class="flex-1 min-w-0">
+							<Show
+								when={items()}
+								fallback={<div class="text-sm text-base-content/50">Коллекций пока нет</div>}>
+								<div class="flex justify-between items-center mb-2">
+									<span class="font-semibold">{items()?.collection.name}</span>
+									<div class="flex gap-2">
+										<button class="btn btn-xs btn-neutral" onClick={() => handleCreateFolder(0)}>
+											+ папка
+										</button>
+										<button class="btn btn-xs btn-primary" onClick={() => setShowRun(true)}>
+											Запустить
+										</button>
+									</div>
+								</div>
+								<Tree parentId={0} depth={0} />
+								<Show when={children(0).length === 0}>
+									<div class="text-sm text-base-content/50">Коллекция пуста</div>
+								</Show>
+							</Show>
+						</div>
+					</div>
+
+					<div class="modal-action">
+						<button class="btn" onClick={props.onClose}>
+							Закрыть
+						</button>
+					</div>
+				</div>
+			</dialog>
+
+			<Show when={showRun() && items()}>
+				{current => (
+					<RunCollectionModal
+						collectionId={current().collection.id}
+						collectionName={current().collection.name}
+						onClose={() => setShowRun(false)}
+					/>
+				)}
+			</Show>
+		</>
+	);
+};
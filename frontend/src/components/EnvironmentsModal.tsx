@@ -0,0 +1,233 @@
+import { For, Show, createSignal, onMount } from "solid-js";
+import { $environments } from "../stores/environments";
+import { $servers } from "../stores/servers";
+import { $notifications, NotificationType } from "../stores/notifications";
+import { Environment } from "../../bindings/grpc-gui/internal/models/models";
+
+export type EnvironmentsModalProps = {
+	onClose: () => void;
+};
+
+type VariableRow = { id: string; key: string; value: string };
+
+type EditorState = {
+	id?: number;
+	name: string;
+	serverId: number;
+	variables: VariableRow[];
+};
+
+const emptyRow = (): VariableRow => ({ id: crypto.randomUUID(), key: "", value: "" });
+
+export const EnvironmentsModal = (props: EnvironmentsModalProps) => {
+	const { environments, loadEnvironments, createEnvironment, updateEnvironment, deleteEnvironment, setActive } =
+		$environments;
+	const { servers } = $servers;
+	const [editor, setEditor] = createSignal<EditorState | null>(null);
+
+	onMount(loadEnvironments);
+
+	const scopeName = (serverId: number) =>
+		serverId === 0
+			? "Глобальное"
+			: servers().find(s => s.server?.id === serverId)?.server?.name || `Сервер #${serverId}`;
+
+	const notifyError = (error: unknown) =>
+		$notifications.addNotification({
+			message: error instanceof Error ? error.message : String(error),
+			title: "Ошибка",
+			type: NotificationType.ERROR,
+		});
+
+	const startEdit = (env?: Environment) => {
+		if (!env) {
+			setEditor({ name: "", serverId: 0, variables: [emptyRow()] });
+			return;
+		}
+		const rows = Object.entries(env.variables || {}).map(([key, value]) => ({
+			id: crypto.randomUUID(),
+			key,
+			value: value || "",
+		}));
+		setEditor({ id: env.id, name: env.name, serverId: env.serverId, variables: [...rows, emptyRow()] });
+	};
+
+	const updateRow = (id: string, patch: Partial<VariableRow>) => {
+		const e = editor();
+		if (!e) return;
+		setEditor({ ...e, variables: e.variables.map(row => (row.id === id ? { ...row, ...patch } : row)) });
+	};
+
+	const handleSave = async (e: SubmitEvent) => {
+		e.preventDefault();
+		const state = editor();
+		if (!state) return;
+
+		const variables: Record<string, string> = {};
+		state.variables.forEach(row => {
+			if (row.key.trim()) {
+				variables[row.key.trim()] = row.value;
+			}
+		});
+
+		try {
+			if (state.id) {
+				await updateEnvironment(state.id, state.name, variables);
+			} else {
+				await createEnvironment(state.name, state.serverId, variables);
+			}
+			setEditor(null);
+		} catch (error) {
+			notifyError(error);
+		}
+	};
+
+	const handleDelete = async (env: Environment) => {
+		try {
+			await deleteEnvironment(env.id);
+		} catch (error) {
+			notifyError(error);
+		}
+	};
+
+	const handleToggle = async (env: Environment, active: boolean) => {
+		try {
+			await setActive(env.id, active);
+		} catch (error) {
+			notifyError(error);
+		}
+	};
+
+	return (
+		<dialog class="modal modal-open" onClick={props.onClose}>
+			<div class="modal-box max-w-4xl" onClick={e => e.stopPropagation()}>
+				<h3 class="text-xl font-bold mb-2">Окружения</h3>
+				<div class="text-sm text-base-content/50 mb-6">
+					Переменные подставляются вместо <span class="font-mono">{"{{name}}"}</span> в адрес, тело, заголовки и
+					параметры вызова. Активно одно глобальное окружение и одно на сервер, серверное переопределяет глобальное.
+					<br />
+					Функции: <span class="font-mono">{"{{uuid}}"}</span>, <span class="font-mono">{"{{now+1h | rfc3339}}"}</span>,{" "}
+					<span class="font-mono">{"{{randomInt 1 100}}"}</span>,{" "}
+					<span class="font-mono">{'{{base64 file:"./avatar.png"}}'}</span>, форматтеры unix, unixms, date, upper, lower,
+					base64
+				</div>
+
+				<Show
+					when={editor()}
+					fallback={
+						<>
+							<table class="table table-sm">
+								<tbody>
+									<For each={environments()}>
+										{env => (
+											<tr>
+												<td>
+													<input
+														type="checkbox"
+														class="toggle toggle-sm"
+														checked={env.active}
+														onChange={e => handleToggle(env, e.currentTarget.checked)}
+													/>
+												</td>
+												<td class="font-semibold">{env.name}</td>
+												<td class="text-base-content/60">{scopeName(env.serverId)}</td>
+												<td class="text-base-content/60">
+													{Object.keys(env.variables || {}).length} перем.
+												</td>
+												<td class="text-right">
+													<button class="btn btn-xs btn-ghost" onClick={() => startEdit(env)}>
+														Изменить
+													</button>
+													<button class="btn btn-xs btn-ghost" onClick={() => handleDelete(env)}>
+														×
+													</button>
+												</td>
+											</tr>
+										)}
+									</For>
+								</tbody>
+							</table>
+							<Show when={environments().length === 0}>
+								<div class="text-sm text-base-content/50">Окружений пока нет</div>
+							</Show>
+							<div class="modal-action">
+								<button class="btn" onClick={props.onClose}>
+									Закрыть
+								</button>
+								<button class="btn btn-primary" onClick={() => startEdit()}>
+									Добавить
+								</button>
+							</div>
+						</>
+					}>
+					{state => (
+						<form onSubmit={handleSave} class="space-y-2">
+							<div class="flex gap-2">
+								<input
+									required
+									type="text"
+									class="input input-sm"
+									placeholder="dev"
+									value={state().name}
+									onInput={e => setEditor({ ...state(), name: e.currentTarget.value })}
+								/>
+								<select
+									class="select select-sm select-bordered"
+									disabled={!!state().id}
+									value={state().serverId}
+									onChange={e => setEditor({ ...state(), serverId: Number(e.currentTarget.value) })}>
+									<option value={0}>Глобальное</option>
+									<For each={servers()}>
+										{s => <option value={s.server?.id}>{s.server?.name}</option>}
+									</For>
+								</select>
+							</div>
+							<For each={state().variables}>
+								{row => (
+									<div class="flex gap-2 items-center">
+										<input
+											type="text"
+											class="input input-sm font-mono"
+											placeholder="token"
+											value={row.key}
+											onInput={e => updateRow(row.id, { key: e.currentTarget.value })}
+										/>
+										<input
+											type="text"
+											class="input input-sm font-mono flex-1"
+											placeholder="Значение"
+											value={row.value}
+											onInput={e => updateRow(row.id, { value: e.currentTarget.value })}
+										/>
+										<button
+											type="button"
+											class="btn btn-sm btn-ghost"
+											onClick={() =>
+												setEditor({ ...state(), variables: state().variables.filter(r => r.id !== row.id) })
+											}>
+											×
+										</button>
+									</div>
+								)}
+							</For>
+							<button
+								type="button"
+								class="btn btn-sm btn-neutral"
+								onClick={() => setEditor({ ...state(), variables: [...state().variables, emptyRow()] })}>
+								Добавить переменную
+							</button>
+							<div class="modal-action">
+								<button type="button" class="btn" onClick={() => setEditor(null)}>
+									Отмена
+								</button>
+								<button type="submit" class="btn btn-primary">
+									Схоронить
+								</button>
+							</div>
+						</form>
+					)}
+				</Show>
+			</div>
+		</dialog>
+	);
+};
@@ -0,0 +1,102 @@
+import { For, createSignal, onMount } from "solid-js";
+import styles from "./SendRequest.module.css";
+import { GetExtractionRules, SaveExtractionRules } from "../../bindings/grpc-gui/app";
+import { Rule, Source } from "../../bindings/grpc-gui/internal/extract/models";
+import { $notifications, NotificationType } from "../stores/notifications";
+
+export type ExtractionRulesProps = {
+	serverId: number;
+	service: string;
+	method: string;
+};
+
+type RuleRow = Rule & { id: string };
+
+const emptyRow = (): RuleRow => ({ id: crypto.randomUUID(), variable: "", source: Source.SourceBody, expression: "" });
+
+export const ExtractionRules = (props: ExtractionRulesProps) => {
+	const [rows, setRows] = createSignal<RuleRow[]>([]);
+
+	onMount(async () => {
+		try {
+			const rules = await GetExtractionRules(props.serverId, props.service, props.method);
+			setRows((rules || []).map(rule => ({ ...rule, id: crypto.randomUUID() })));
+		} catch (error) {
+			console.error("Failed to load extraction rules:", error);
+		}
+	});
+
+	const updateRow = (id: string, patch: Partial<Rule>) =>
+		setRows(rows().map(row => (row.id === id ? { ...row, ...patch } : row)));
+
+	const handleSave = async () => {
+		const rules = rows()
+			.filter(row => row.variable.trim() || row.expression.trim())
+			.map(({ variable, source, expression }) => ({ variable, source, expression }));
+
+		try {
+			await SaveExtractionRules(props.serverId, props.service, props.method, rules);
+			$notifications.addNotification({
+				message: "Правила извлечения сохранены",
+				title: "Готово",
+				type: NotificationType.SUCCESS,
+			});
+		} catch (error) {
+			$notifications.addNotification({
+				message: error instanceof Error ? error.message : String(error),
+				title: "Ошибка",
+				type: NotificationType.ERROR,
+			});
+		}
+	};
+
+	return (
+		<div class={styles.keyValueList}>
+			<div class={styles.keyValueDescription}>
+				После ответа значения записываются в переменные и доступны в следующих запросах как{" "}
+				<span class="font-mono">{"{{name}}"}</span>. Для тела ответа - путь JSONPath (
+				<span class="font-mono">$.session.id</span>, <span class="font-mono">$.items[0]</span>) или jq (
+				<span class="font-mono">.items[].id</span>), для заголовков и трейлеров - имя ключа
+			</div>
+			<For each={rows()}>
+				{row => (
+					<div class={styles.keyValueRow}>
+						<input
+							type="text"
+							class="input input-sm font-mono"
+							placeholder="session_id"
+							value={row.variable}
+							onInput={e => updateRow(row.id, { variable: e.currentTarget.value })}
+						/>
+						<select
+							class="select select-sm select-bordered"
+							value={row.source}
+							onChange={e => updateRow(row.id, { source: e.currentTarget.value as Source })}>
+							<option value={Source.SourceBody}>Тело</option>
+							<option value={Source.SourceHeader}>Заголовок</option>
+							<option value={Source.SourceTrailer}>Трейлер</option>
+						</select>
+						<input
+							type="text"
+							class="input input-sm font-mono"
+							placeholder={row.source === Source.SourceBody ? "$.sessionId" : "x-request-id"}
+							value={row.expression}
+							onInput={e => updateRow(row.id, { expression: e.currentTarget.value })}
+						/>
+						<button class="btn btn-sm btn-ghost" onClick={() => setRows(rows().filter(r => r.id !== row.id))}>
+							×
+						</button>
+					</div>
+				)}
+			</For>
+			<div class={styles.keyValueActions}>
+				<button class="btn btn-sm btn-neutral" onClick={() => setRows([...rows(), emptyRow()])}>
+					Добавить
+				</button>
+				<button class="btn btn-sm btn-primary" onClick={handleSave}>
+					Схоронить
+				</button>
+			</div>
+		</div>
+	);
+};
@@ -118,6 +118,13 @@ export const HistoryList = () => {
 										<Show when={item().statusCode !== 0}>
 											<span class="badge badge-xs badge-error">ERROR</span>
 										</Show>
+										<Show when={item().assertionsPassed != null}>
+											<span
+												class={`badge badge-xs badge-outline ${item().assertionsPassed ? "badge-success" : "badge-error"}`}
+												title="Проверки ответа">
+												{item().assertionsPassed ? "проверки ✓" : "проверки ✗"}
+											</span>
+										</Show>
 									</div>
 								</div>
 								<button
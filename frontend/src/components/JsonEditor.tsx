@@ -21,6 +21,7 @@ export type JsonEditorProps = {
 	placeholder?: string;
 	readOnly?: boolean;
 	schema?: MessageInfo | null;
+	anyTypes?: string[] | null;
 };
 
 const getFieldsFromSchema = (schema: MessageInfo | null | undefined): Map<string, FieldInfo> => {
@@ -270,17 +271,49 @@ const createEnumInfoPlugin = (schema: MessageInfo | null | undefined) => {
 	});
 };
 
-const createJsonAutocompletion = (schema: MessageInfo | null | undefined) => {
+const createJsonAutocompletion = (schema: MessageInfo | null | undefined, anyTypes?: string[] | null) => {
 	return (context: CompletionContext): CompletionResult | null => {
 		if (!schema?.fields) return null;
 
+		const textBefore = context.state.doc.sliceString(0, context.pos);
+
+		const typeUrl = context.matchBefore(/"@type"\s*:\s*"[^"]*/);
+		if (typeUrl && anyTypes?.length) {
+			return {
+				from: typeUrl.from + typeUrl.text.lastIndexOf('"') + 1,
+				options: anyTypes.map(name => ({
+					label: `type.googleapis.com/${name}`,
+					type: "type",
+					detail: "Any",
+				})),
+			};
+		}
+
+		const fields = getFieldsFromSchema(schema);
+
+		const maskMatch = textBefore.match(/"(\w+)"\s*:\s*"[^"]*$/);
+		if (maskMatch) {
+			const maskField = [...fields.entries()].find(
+				([path, field]) => path.endsWith(maskMatch[1]) && field.wellKnownType === "field_mask"
+			)?.[1];
+			const maskPath = context.matchBefore(/[\w.]*/);
+			if (maskField?.fieldMaskPaths?.length && maskPath) {
+				return {
+					from: maskPath.from,
+					options: maskField.fieldMaskPaths.map(path => ({
+						label: path,
+						type: "property",
+						detail: "FieldMask",
+					})),
+				};
+			}
+		}
+
 		const word = context.matchBefore(/\w*/);
 		if (!word || (word.from === word.to && !context.explicit)) return null;
 
-		const fields = getFieldsFromSchema(schema);
 		const options = [];
 
-		const textBefore = context.state.doc.sliceString(0, context.pos);
 		const fieldMatch = textBefore.match(/"(\w+)"\s*:\s*"?[^"]*$/);
 		
 		if (fieldMatch) {
@@ -379,7 +412,7 @@ export const JsonEditor = (props: JsonEditorProps) => {
 		if (props.schema && !props.readOnly) {
 			extensions.push(
 				autocompletion({
-					override: [createJsonAutocompletion(props.schema)],
+					override: [createJsonAutocompletion(props.schema, props.anyTypes)],
 					activateOnTyping: true,
 				})
 			);
@@ -455,7 +488,7 @@ export const JsonEditor = (props: JsonEditorProps) => {
 		if (schema && !props.readOnly) {
 			extensions.push(
 				autocompletion({
-					override: [createJsonAutocompletion(schema)],
+					override: [createJsonAutocompletion(schema, props.anyTypes)],
 					activateOnTyping: true,
 				})
 			);
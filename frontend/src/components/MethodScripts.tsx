@@ -0,0 +1,75 @@
+import { createSignal, onMount } from "solid-js";
+import styles from "./SendRequest.module.css";
+import { GetMethodScript, SaveMethodScript } from "../../bindings/grpc-gui/app";
+import { $notifications, NotificationType } from "../stores/notifications";
+
+export type MethodScriptsProps = {
+	serverId: number;
+	service: string;
+	method: string;
+};
+
+export const MethodScripts = (props: MethodScriptsProps) => {
+	const [preRequest, setPreRequest] = createSignal("");
+	const [postResponse, setPostResponse] = createSignal("");
+
+	onMount(async () => {
+		try {
+			const script = await GetMethodScript(props.serverId, props.service, props.method);
+			setPreRequest(script?.preRequest || "");
+			setPostResponse(script?.postResponse || "");
+		} catch (error) {
+			console.error("Failed to load method scripts:", error);
+		}
+	});
+
+	const handleSave = async () => {
+		try {
+			await SaveMethodScript(props.serverId, props.service, props.method, preRequest(), postResponse());
+			$notifications.addNotification({
+				message: "Скрипты сохранены",
+				title: "Готово",
+				type: NotificationType.SUCCESS,
+			});
+		} catch (error) {
+			$notifications.addNotification({
+				message: error instanceof Error ? error.message : String(error),
+				title: "Ошибка",
+				type: NotificationType.ERROR,
+			});
+		}
+	};
+
+	return (
+		<div class={styles.keyValueList}>
+			<div class={styles.keyValueDescription}>
+				JavaScript без доступа к файлам и сети, не дольше 5 секунд. Доступны{" "}
+				<span class="font-mono">request.payload</span>, <span class="font-mono">request.json()</span>,{" "}
+				<span class="font-mono">request.setJSON(obj)</span>, <span class="font-mono">request.metadata</span>,{" "}
+				<span class="font-mono">response.code</span>, <span class="font-mono">response.json()</span>,{" "}
+				<span class="font-mono">response.headers</span>, <span class="font-mono">vars.get/set</span>,{" "}
+				<span class="font-mono">crypto.hmac("sha256", key, data)</span>, <span class="font-mono">crypto.hash</span>,{" "}
+				<span class="font-mono">encoding.base64Encode</span> и <span class="font-mono">console.log</span>
+			</div>
+			<label class="text-sm font-semibold">Перед запросом</label>
+			<textarea
+				class="textarea textarea-bordered font-mono text-sm h-32"
+				placeholder={'request.metadata["x-signature"] = crypto.hmac("sha256", vars.get("secret"), request.payload);'}
+				value={preRequest()}
+				onInput={e => setPreRequest(e.currentTarget.value)}
+			/>
+			<label class="text-sm font-semibold">После ответа</label>
+			<textarea
+				class="textarea textarea-bordered font-mono text-sm h-32"
+				placeholder={'if (response.code === 0) vars.set("session_id", response.json().sessionId);'}
+				value={postResponse()}
+				onInput={e => setPostResponse(e.currentTarget.value)}
+			/>
+			<div class={styles.keyValueActions}>
+				<button class="btn btn-sm btn-primary" onClick={handleSave}>
+					Схоронить
+				</button>
+			</div>
+		</div>
+	);
+};
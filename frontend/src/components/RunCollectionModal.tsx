@@ -0,0 +1,227 @@
+import { For, Show, createSignal, onCleanup, onMount } from "solid-js";
+import { Events } from "@wailsio/runtime";
+import { $environments } from "../stores/environments";
+import { $notifications, NotificationType } from "../stores/notifications";
+import { FormatRunReport, RunCollection } from "../../bindings/grpc-gui/app";
+import { Report, Result, Status } from "../../bindings/grpc-gui/internal/runner/models";
+
+export type RunCollectionModalProps = {
+	collectionId: number;
+	collectionName: string;
+	onClose: () => void;
+};
+
+type ProgressRow = { iteration: number; result: Result };
+
+const statusBadge: Record<string, string> = {
+	[Status.StatusPassed]: "badge-success",
+	[Status.StatusFailed]: "badge-error",
+	[Status.StatusSkipped]: "badge-ghost",
+};
+
+const statusLabel: Record<string, string> = {
+	[Status.StatusPassed]: "✓",
+	[Status.StatusFailed]: "✗",
+	[Status.StatusSkipped]: "пропущен",
+};
+
+// failureText - причина падения запроса: ошибка вызова или не прошедшие проверки
+const failureText = (result: Result) => {
+	const lines: string[] = [];
+	if (result.error) lines.push(result.error);
+	(result.assertions ?? [])
+		.filter(a => !a.passed)
+		.forEach(a => {
+			const { kind, path, operator, expected } = a.assertion;
+			const assertion = [kind, path, operator, expected].filter(Boolean).join(" ");
+			lines.push(a.message ? `${assertion}: ${a.message}` : assertion);
+		});
+	return lines.join("\n");
+};
+
+export const RunCollectionModal = (props: RunCollectionModalProps) => {
+	const { environments, loadEnvironments } = $environments;
+
+	const [environmentId, setEnvironmentId] = createSignal(0);
+	const [dataFile, setDataFile] = createSignal<{ name: string; data: string } | null>(null);
+	const [stopOnFailure, setStopOnFailure] = createSignal(false);
+	const [running, setRunning] = createSignal(false);
+	const [progress, setProgress] = createSignal<ProgressRow[]>([]);
+	const [report, setReport] = createSignal<Report | null>(null);
+
+	onMount(() => {
+		loadEnvironments();
+
+		// Результаты приходят по мере выполнения, итоговый отчет - ответом на вызов
+		const off = Events.On("collection:run", event => {
+			const update = event.data;
+			if (update.collectionId === props.collectionId) {
+				setProgress(rows => [...rows, { iteration: update.iteration, result: update.result }]);
+			}
+		});
+		onCleanup(off);
+	});
+
+	const notifyError = (error: unknown) =>
+		$notifications.addNotification({
+			message: error instanceof Error ? error.message : String(error),
+			title: "Ошибка",
+			type: NotificationType.ERROR,
+		});
+
+	const handleFile = async (file: File | undefined) => {
+		setDataFile(file ? { name: file.name, data: await file.text() } : null);
+	};
+
+	const handleRun = async () => {
+		setRunning(true);
+		setProgress([]);
+		setReport(null);
+		try {
+			const result = await RunCollection(props.collectionId, {
+				environmentId: environmentId(),
+				dataName: dataFile()?.name ?? "",
+				data: dataFile()?.data ?? "",
+				stopOnFailure: stopOnFailure(),
+			});
+			setReport(result);
+		} catch (error) {
+			notifyError(error);
+		} finally {
+			setRunning(false);
+		}
+	};
+
+	const handleDownload = async (format: "junit" | "json") => {
+		const current = report();
+		if (!current) return;
+		try {
+			const content = await FormatRunReport(current, format);
+			const blob = new Blob([content], { type: format === "junit" ? "application/xml" : "application/json" });
+			const url = URL.createObjectURL(blob);
+			const a = window.document.createElement("a");
+			a.href = url;
+			a.download = `${props.collectionName}-report.${format === "junit" ? "xml" : "json"}`;
+			window.document.body.appendChild(a);
+			a.click();
+			window.document.body.removeChild(a);
+			URL.revokeObjectURL(url);
+		} catch (error) {
+			notifyError(error);
+		}
+	};
+
+	const rows = (): ProgressRow[] => {
+		const current = report();
+		if (!current) return progress();
+		return (current.iterations ?? []).flatMap(it =>
+			(it.results ?? []).map(result => ({ iteration: it.index, result })),
+		);
+	};
+
+	const iterations = () => new Set(rows().map(r => r.iteration)).size;
+
+	return (
+		<dialog class="modal modal-open" onClick={() => !running() && props.onClose()}>
+			<div class="modal-box max-w-4xl" onClick={e => e.stopPropagation()}>
+				<h3 class="text-xl font-bold mb-2">Прогон «{props.collectionName}»</h3>
+				<div class="text-sm text-base-content/50 mb-4">
+					Запросы идут по порядку дерева, извлеченные значения передаются следующим запросам. Каждая строка
+					файла данных (.csv с заголовком или .json массив объектов) - отдельная итерация со своими переменными.
+				</div>
+
+				<div class="flex flex-wrap gap-2 items-center mb-4">
+					<select
+						class="select select-sm select-bordered"
+						value={environmentId()}
+						disabled={running()}
+						onChange={e => setEnvironmentId(Number(e.currentTarget.value))}>
+						<option value={0}>Активные окружения</option>
+						<For each={environments()}>{env => <option value={env.id}>{env.name}</option>}</For>
+					</select>
+					<input
+						type="file"
+						accept=".csv,.json"
+						class="file-input file-input-sm file-input-bordered"
+						disabled={running()}
+						onChange={e => handleFile(e.currentTarget.files?.[0])}
+					/>
+					<label class="label cursor-pointer gap-2">
+						<input
+							type="checkbox"
+							class="checkbox checkbox-sm"
+							checked={stopOnFailure()}
+							disabled={running()}
+							onChange={e => setStopOnFailure(e.currentTarget.checked)}
+						/>
+						<span class="text-sm">Остановиться на первой ошибке</span>
+					</label>
+					<button class="btn btn-sm btn-primary ml-auto" disabled={running()} onClick={handleRun}>
+						<Show when={running()} fallback="Запустить">
+							<span class="loading loading-spinner loading-xs" />
+							Выполняется
+						</Show>
+					</button>
+				</div>
+
+				<Show when={report()}>
+					{current => (
+						<div class="flex items-center gap-3 mb-2 text-sm">
+							<span class="badge badge-success">пройдено {current().summary.passed}</span>
+							<span class="badge badge-error">упало {current().summary.failed}</span>
+							<Show when={current().summary.skipped > 0}>
+								<span class="badge badge-ghost">пропущено {current().summary.skipped}</span>
+							</Show>
+							<span class="text-base-content/60">{current().duration} мс</span>
+							<div class="ml-auto flex gap-2">
+								<button class="btn btn-xs btn-neutral" onClick={() => handleDownload("junit")}>
+									JUnit
+								</button>
+								<button class="btn btn-xs btn-neutral" onClick={() => handleDownload("json")}>
+									JSON
+								</button>
+							</div>
+						</div>
+					)}
+				</Show>
+
+				<div class="max-h-96 overflow-auto">
+					<table class="table table-xs">
+						<tbody>
+							<For each={rows()}>
+								{row => (
+									<tr>
+										<Show when={iterations() > 1}>
+											<td class="text-base-content/50">#{row.iteration}</td>
+										</Show>
+										<td>
+											<span class={`badge badge-sm ${statusBadge[row.result.status] ?? ""}`}>
+												{statusLabel[row.result.status] ?? row.result.status}
+											</span>
+										</td>
+										<td>
+											<div>{row.result.path}</div>
+											<Show when={row.result.status === Status.StatusFailed && failureText(row.result)}>
+												<div class="text-xs text-error whitespace-pre-wrap font-mono">
+													{failureText(row.result)}
+												</div>
+											</Show>
+										</td>
+										<td class="font-mono text-base-content/60">{row.result.codeName}</td>
+										<td class="text-right text-base-content/60">{row.result.duration} мс</td>
+									</tr>
+								)}
+							</For>
+						</tbody>
+					</table>
+				</div>
+
+				<div class="modal-action">
+					<button class="btn" disabled={running()} onClick={props.onClose}>
+						Закрыть
+					</button>
+				</div>
+			</div>
+		</dialog>
+	);
+};
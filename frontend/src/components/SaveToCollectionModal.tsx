@@ -0,0 +1,171 @@
+import { For, Show, createMemo, createSignal, onMount } from "solid-js";
+import { $collections } from "../stores/collections";
+import { $tabs, SendRequestData, TabType } from "../stores/tabs";
+import { $notifications, NotificationType } from "../stores/notifications";
+import { GetCollectionItems, GetSavedRequest } from "../../bindings/grpc-gui/app";
+import { Folder, SavedRequest } from "../../bindings/grpc-gui/internal/models/models";
+
+export type SaveToCollectionModalProps = {
+	tabId: string;
+	onClose: () => void;
+};
+
+// folderPaths - папки коллекции с полным путем для выпадающего списка
+export const folderPaths = (folders: Folder[]) => {
+	const byId = new Map(folders.map(f => [f.id, f]));
+	const path = (folder: Folder): string => {
+		const parent = byId.get(folder.parentId);
+		return parent ? `${path(parent)} / ${folder.name}` : folder.name;
+	};
+	return folders.map(f => ({ id: f.id, path: path(f) })).sort((a, b) => a.path.localeCompare(b.path));
+};
+
+export const SaveToCollectionModal = (props: SaveToCollectionModalProps) => {
+	const { tabs, updateTabData, buildRequest } = $tabs;
+	const { collections, loadCollections, createCollection, createSavedRequest, updateSavedRequest } =
+		$collections;
+
+	const data = createMemo(() => tabs.find(t => t.id === props.tabId)?.data as SendRequestData | undefined);
+
+	const [name, setName] = createSignal(data()?.methodName ?? "");
+	const [collectionId, setCollectionId] = createSignal(0);
+	const [newCollection, setNewCollection] = createSignal("");
+	const [folderId, setFolderId] = createSignal(0);
+	const [folders, setFolders] = createSignal<Folder[]>([]);
+
+	onMount(async () => {
+		await loadCollections();
+		const first = collections()[0];
+		if (first) await selectCollection(first.id);
+	});
+
+	const selectCollection = async (id: number) => {
+		setCollectionId(id);
+		setFolderId(0);
+		setFolders(id ? ((await GetCollectionItems(id))?.folders ?? []) : []);
+	};
+
+	const notifyError = (error: unknown) =>
+		$notifications.addNotification({
+			message: error instanceof Error ? error.message : String(error),
+			title: "Ошибка",
+			type: NotificationType.ERROR,
+		});
+
+	const notifySaved = () =>
+		$notifications.addNotification({
+			message: "Запрос сохранен в коллекцию",
+			title: "Готово",
+			type: NotificationType.SUCCESS,
+		});
+
+	const handleUpdate = async () => {
+		const d = data();
+		if (!d?.savedRequestId) return;
+		try {
+			const saved = await GetSavedRequest(d.savedRequestId);
+			if (!saved) return;
+			await updateSavedRequest({ ...saved, ...buildRequest(d) });
+			notifySaved();
+			props.onClose();
+		} catch (error) {
+			notifyError(error);
+		}
+	};
+
+	const handleCreate = async (e: SubmitEvent) => {
+		e.preventDefault();
+		const d = data();
+		if (!d) return;
+
+		try {
+			let targetId = collectionId();
+			if (!targetId) {
+				targetId = await createCollection(newCollection(), "");
+			}
+
+			const request = {
+				collectionId: targetId,
+				folderId: folderId(),
+				name: name(),
+				serverId: d.serverId,
+				service: d.serviceName,
+				method: d.methodName,
+				address: "",
+				...buildRequest(d),
+				assertions: [],
+			} as unknown as SavedRequest;
+
+			const id = await createSavedRequest(request);
+			updateTabData<TabType.REQUEST>(props.tabId, { savedRequestId: id });
+			notifySaved();
+			props.onClose();
+		} catch (error) {
+			notifyError(error);
+		}
+	};
+
+	return (
+		<dialog class="modal modal-open" onClick={props.onClose}>
+			<div class="modal-box" onClick={e => e.stopPropagation()}>
+				<h3 class="text-xl font-bold mb-4">Сохранить в коллекцию</h3>
+
+				<Show when={data()?.savedRequestId}>
+					<div class="flex items-center gap-2 mb-4">
+						<span class="text-sm text-base-content/60 flex-1">Вкладка открыта из коллекции</span>
+						<button class="btn btn-sm btn-primary" onClick={handleUpdate}>
+							Обновить
+						</button>
+					</div>
+					<div class="divider text-xs">или сохранить как новый</div>
+				</Show>
+
+				<form onSubmit={handleCreate} class="space-y-2">
+					<input
+						required
+						type="text"
+						class="input input-sm input-bordered w-full"
+						placeholder="Название запроса"
+						value={name()}
+						onInput={e => setName(e.currentTarget.value)}
+					/>
+					<select
+						class="select select-sm select-bordered w-full"
+						value={collectionId()}
+						onChange={e => selectCollection(Number(e.currentTarget.value))}>
+						<For each={collections()}>{c => <option value={c.id}>{c.name}</option>}</For>
+						<option value={0}>Новая коллекция...</option>
+					</select>
+					<Show
+						when={collectionId()}
+						fallback={
+							<input
+								required
+								type="text"
+								class="input input-sm input-bordered w-full"
+								placeholder="Название коллекции"
+								value={newCollection()}
+								onInput={e => setNewCollection(e.currentTarget.value)}
+							/>
+						}>
+						<select
+							class="select select-sm select-bordered w-full"
+							value={folderId()}
+							onChange={e => setFolderId(Number(e.currentTarget.value))}>
+							<option value={0}>Корень коллекции</option>
+							<For each={folderPaths(folders())}>{f => <option value={f.id}>{f.path}</option>}</For>
+						</select>
+					</Show>
+					<div class="modal-action">
+						<button type="button" class="btn" onClick={props.onClose}>
+							Отмена
+						</button>
+						<button type="submit" class="btn btn-primary">
+							Схоронить
+						</button>
+					</div>
+				</form>
+			</div>
+		</dialog>
+	);
+};
@@ -1,16 +1,35 @@
 import { $servers } from "../stores/servers";
-import { createMemo, createSignal, For, Show, onMount } from "solid-js";
+import { createEffect, createMemo, createSignal, For, Show, onMount } from "solid-js";
 import styles from "./SendRequest.module.css";
 import { JsonEditor } from "./JsonEditor";
+import { ExtractionRules } from "./ExtractionRules";
+import { MethodScripts } from "./MethodScripts";
+import { Assertions } from "./Assertions";
+import { SaveToCollectionModal } from "./SaveToCollectionModal";
+import { $collections } from "../stores/collections";
 import { $tabs, SendRequestData } from "../stores/tabs";
 import { IoCopy, IoDownload } from "solid-icons/io";
+import {
+	GetAnyTypeExample,
+	GetFakeJsonExample,
+	GetJsonExampleForOneofs,
+	GetMethodTypeGraphDOT,
+	GetAssertions,
+	SaveAssertions,
+	GetSavedRequest,
+} from "../../bindings/grpc-gui/app";
+import { MessageInfo, MethodInfo } from "../../bindings/grpc-gui/internal/grpcreflect/models";
+import { Assertion } from "../../bindings/grpc-gui/internal/assertion/models";
+import stripJsonComments from "strip-json-comments";
+import { $notifications, NotificationType } from "../stores/notifications";
+import { ReflectionStatus } from "../../bindings/grpc-gui";
 
 export type SendRequestProps = {
 	tabId: string;
 };
 
 export const SendRequest = (props: SendRequestProps) => {
-	const { servers } = $servers;
+	const { servers, loadMethodInfo } = $servers;
 	const {
 		tabs,
 		updateTabData,
@@ -23,6 +42,7 @@ export const SendRequest = (props: SendRequestProps) => {
 		removeContextRow,
 		updateContextKey,
 		updateContextValue,
+		updateCallOptions,
 	} = $tabs;
 
 	const tab = createMemo(() => tabs.find(t => t.id === props.tabId));
@@ -42,11 +62,82 @@ export const SendRequest = (props: SendRequestProps) => {
 		return service?.methods?.find(m => m.name === d.methodName);
 	});
 
-	onMount(() => {
+	const oneofGroups = createMemo(() => {
+		const groups = new Map<string, string[]>();
+		for (const field of method()?.request?.fields ?? []) {
+			if (!field.oneofGroup) continue;
+			groups.set(field.oneofGroup, [...(groups.get(field.oneofGroup) ?? []), field.name]);
+		}
+		return [...groups.entries()].filter(([, variants]) => variants.length > 1);
+	});
+
+	const anyFields = createMemo(() => {
+		const paths: string[] = [];
+		const walk = (prefix: string, message: MessageInfo | null | undefined) => {
+			for (const field of message?.fields ?? []) {
+				const path = prefix ? `${prefix}.${field.name}` : field.name;
+				if (field.wellKnownType === "any" && !field.repeated && !field.isMap) {
+					paths.push(path);
+				} else if (field.message && !field.repeated && !field.isMap) {
+					walk(path, field.message);
+				}
+			}
+		};
+		walk("", method()?.request);
+		return paths;
+	});
+
+	const anyTypes = createMemo(() => server()?.reflection?.messageTypes ?? []);
+
+	const [oneofSelection, setOneofSelection] = createSignal<Record<string, string>>({});
+
+	const [isMethodLoading, setIsMethodLoading] = createSignal(false);
+	// Без флага эффект ниже бесконечно повторял бы загрузку для недоступного сервера
+	let methodLoadFailed = false;
+
+	onMount(async () => {
 		const d = data();
-		if (!d || d.requestBody !== "{}" || d.historyData) return;
+		if (!d) return;
 
+		let m = method();
+		if (!m?.request) {
+			setIsMethodLoading(true);
+			try {
+				m = await loadMethodInfo(d.serverId, d.serviceName, d.methodName);
+			} catch (err) {
+				methodLoadFailed = true;
+				$notifications.addNotification({
+					type: NotificationType.ERROR,
+					title: "Ошибка",
+					message: `Не удалось загрузить описание метода: ${err}`,
+				});
+			} finally {
+				setIsMethodLoading(false);
+			}
+		}
+
+		const current = data();
+		if (!current || current.requestBody !== "{}" || current.historyData) return;
+
+		applyExample(m);
+	});
+
+	// После фонового обновления схемы детали изменившегося метода загружаются заново
+	createEffect(() => {
+		const d = data();
 		const m = method();
+		if (!d || !m || m.request || isMethodLoading() || methodLoadFailed) return;
+
+		setIsMethodLoading(true);
+		loadMethodInfo(d.serverId, d.serviceName, d.methodName)
+			.catch(err => {
+				methodLoadFailed = true;
+				console.error("Failed to load method info:", err);
+			})
+			.finally(() => setIsMethodLoading(false));
+	});
+
+	function applyExample(m: MethodInfo | null | undefined) {
 		if (m?.requestExampleString) {
 			updateTabData(props.tabId, { requestBody: m.requestExampleString });
 		} else if (m?.requestExample) {
@@ -57,9 +148,49 @@ export const SendRequest = (props: SendRequestProps) => {
 				console.error("Failed to parse request example:", err);
 			}
 		}
-	});
+	}
+
+	const handleResetToExample = async () => {
+		const d = data();
+		if (!d) return;
+
+		try {
+			applyExample(await loadMethodInfo(d.serverId, d.serviceName, d.methodName));
+		} finally {
+			updateTabData(props.tabId, { schemaWarning: undefined });
+		}
+	};
+
+	const handleDownloadTypeGraph = async () => {
+		const d = data();
+		if (!d) return;
+
+		let dot = "";
+		try {
+			dot = await GetMethodTypeGraphDOT(d.serverId, d.serviceName, d.methodName);
+		} catch (err: any) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: err?.message || "Не удалось построить граф типов",
+			});
+			return;
+		}
+
+		const blob = new Blob([dot], { type: "text/vnd.graphviz" });
+		const url = URL.createObjectURL(blob);
+		const a = document.createElement("a");
+		a.href = url;
+		a.download = `${d.serviceName}.${d.methodName}.dot`;
+		document.body.appendChild(a);
+		a.click();
+		document.body.removeChild(a);
+		URL.revokeObjectURL(url);
+	};
 
 	const [isLoading, setIsLoading] = createSignal(false);
+	const [showSave, setShowSave] = createSignal(false);
+	const { updateSavedRequest } = $collections;
 
 	const handleCopyResponse = () => {
 		const response = data()?.response;
@@ -95,6 +226,59 @@ export const SendRequest = (props: SendRequestProps) => {
 		URL.revokeObjectURL(url);
 	};
 
+	const handleGenerateFakeData = async () => {
+		const m = method();
+		if (!m?.request) return;
+
+		try {
+			const seed = Math.floor(Math.random() * 1_000_000_000);
+			const example = await GetFakeJsonExample(m.request, seed);
+			updateTabData(props.tabId, { requestBody: example, activeTab: "body" });
+		} catch (err) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: "Не удалось сгенерировать данные",
+			});
+		}
+	};
+
+	const handleSelectOneof = async (group: string, variant: string) => {
+		const request = method()?.request;
+		if (!request) return;
+
+		const selection = { ...oneofSelection(), [`${request.name}.${group}`]: variant };
+		setOneofSelection(selection);
+		const example = await GetJsonExampleForOneofs(request, selection);
+		updateTabData(props.tabId, { requestBody: example, activeTab: "body" });
+	};
+
+	const handleSelectAnyType = async (path: string, typeName: string) => {
+		const d = data();
+		if (!d || !typeName) return;
+
+		try {
+			const example = JSON.parse(await GetAnyTypeExample(d.serverId, typeName));
+			const body = JSON.parse(stripJsonComments(d.requestBody || "{}"));
+
+			const parts = path.split(".");
+			let target = body;
+			for (const part of parts.slice(0, -1)) {
+				if (typeof target[part] !== "object" || target[part] === null) target[part] = {};
+				target = target[part];
+			}
+			target[parts[parts.length - 1]] = example;
+
+			updateTabData(props.tabId, { requestBody: JSON.stringify(body, null, 2), activeTab: "body" });
+		} catch (err) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: `Не удалось подставить тип ${typeName}: ${err}`,
+			});
+		}
+	};
+
 	const handleSendRequest = async () => {
 		const srv = server();
 		if (!srv?.server?.address) return;
@@ -119,9 +303,35 @@ export const SendRequest = (props: SendRequestProps) => {
 							<span class={styles.separator}>/</span>
 							<span class={styles.descriptionTitle}>{d().methodName}</span>
 						</div>
-						<div class={styles.address}>{server()?.server?.address}</div>
+						<div class={styles.address}>
+							{server()?.server?.address}
+							<Show when={isMethodLoading()}> · загрузка описания метода...</Show>
+							<Show when={server()?.status === ReflectionStatus.ReflectionStatusStale}>
+								<span class="text-warning"> · сервер недоступен, схема из кеша</span>
+							</Show>
+							{" · "}
+							<button class="link link-hover" onClick={handleDownloadTypeGraph} title="Граф типов метода в формате Graphviz">
+								граф типов
+							</button>
+						</div>
 					</div>
 
+					<Show when={d().schemaWarning}>
+						<div class="alert alert-warning alert-soft py-1 px-3 text-sm flex justify-between">
+							<span>{d().schemaWarning}</span>
+							<div class="flex gap-2">
+								<button class="btn btn-xs" onClick={handleResetToExample}>
+									Подставить новый пример
+								</button>
+								<button
+									class="btn btn-xs btn-ghost"
+									onClick={() => updateTabData(props.tabId, { schemaWarning: undefined })}>
+									Скрыть
+								</button>
+							</div>
+						</div>
+					</Show>
+
 					<div class={styles.content}>
 						<div class={styles.requestSection}>
 							<div class={styles.tabs}>
@@ -144,10 +354,70 @@ export const SendRequest = (props: SendRequestProps) => {
 										onClick={() => updateTabData(props.tabId, { activeTab: "context" })}>
 										Контекст
 									</button>
+									<button
+										class={styles.tab}
+										classList={{ [styles.tabActive]: d().activeTab === "extraction" }}
+										onClick={() => updateTabData(props.tabId, { activeTab: "extraction" })}>
+										Извлечение
+									</button>
+									<button
+										class={styles.tab}
+										classList={{ [styles.tabActive]: d().activeTab === "scripts" }}
+										onClick={() => updateTabData(props.tabId, { activeTab: "scripts" })}>
+										Скрипты
+									</button>
+									<button
+										class={styles.tab}
+										classList={{ [styles.tabActive]: d().activeTab === "assertions" }}
+										onClick={() => updateTabData(props.tabId, { activeTab: "assertions" })}>
+										Проверки
+									</button>
+								</div>
+								<div class="flex gap-2">
+									<For each={anyFields()}>
+										{path => (
+											<input
+												class="input input-sm input-bordered"
+												list={`any-types-${props.tabId}`}
+												placeholder={`${path}: тип Any`}
+												title={`Тип сообщения для ${path}`}
+												onChange={e => handleSelectAnyType(path, e.currentTarget.value)}
+											/>
+										)}
+									</For>
+									<Show when={anyFields().length > 0}>
+										<datalist id={`any-types-${props.tabId}`}>
+											<For each={anyTypes()}>{name => <option value={name} />}</For>
+										</datalist>
+									</Show>
+									<For each={oneofGroups()}>
+										{([group, variants]) => (
+											<select
+												class="select select-sm select-bordered"
+												title={`oneof ${group}`}
+												value={oneofSelection()[`${method()?.request?.name}.${group}`] ?? variants[0]}
+												onChange={e => handleSelectOneof(group, e.currentTarget.value)}>
+												<For each={variants}>{variant => <option value={variant}>{variant}</option>}</For>
+											</select>
+										)}
+									</For>
+									<button
+										class="btn btn-sm btn-ghost"
+										onClick={handleGenerateFakeData}
+										disabled={!method()?.request}
+										title="Заполнить тело запроса правдоподобными данными">
+										Случайные данные
+									</button>
+									<button
+										class="btn btn-sm btn-ghost"
+										onClick={() => setShowSave(true)}
+										title={d().savedRequestId ? "Обновить запрос в коллекции" : "Сохранить запрос в коллекцию"}>
+										В коллекцию
+									</button>
+									<button class="btn btn-sm btn-success" onClick={handleSendRequest} disabled={isLoading()}>
+										{isLoading() ? "Отправка..." : "Отправить"}
+									</button>
 								</div>
-								<button class="btn btn-sm btn-success" onClick={handleSendRequest} disabled={isLoading()}>
-									{isLoading() ? "Отправка..." : "Отправить"}
-								</button>
 							</div>
 
 							<div class={styles.tabContent}>
@@ -157,6 +427,7 @@ export const SendRequest = (props: SendRequestProps) => {
 											initialValue={d().requestBody}
 											onChange={value => updateTabData(props.tabId, { requestBody: value })}
 											schema={method()?.request}
+											anyTypes={anyTypes()}
 										/>
 									</div>
 								</Show>
@@ -164,7 +435,8 @@ export const SendRequest = (props: SendRequestProps) => {
 								<Show when={d().activeTab === "metadata"}>
 									<div class={styles.keyValueList}>
 										<div class={styles.keyValueDescription}>
-											Метаданные передаются как gRPC заголовки (headers) в запросе
+											Метаданные передаются как gRPC заголовки (headers) в запросе. Значения ключей с суффиксом -bin
+											указываются в base64 или в hex с префиксом hex:
 										</div>
 										<For each={d().metadata}>
 											{item => (
@@ -200,7 +472,83 @@ export const SendRequest = (props: SendRequestProps) => {
 								<Show when={d().activeTab === "context"}>
 									<div class={styles.keyValueList}>
 										<div class={styles.keyValueDescription}>
-											Контекстные значения передаются в context.Context запроса
+											Параметры вызова gRPC. Значения ниже уходят на сервер метаданными вызова
+										</div>
+										<div class={styles.keyValueRow}>
+											<input
+												type="text"
+												class="input input-sm"
+												placeholder="Таймаут, например 5s или 500ms"
+												value={d().callOptions?.timeout ?? ""}
+												onInput={e => updateCallOptions(props.tabId, { timeout: e.currentTarget.value })}
+											/>
+											<select
+												class="select select-sm select-bordered"
+												value={d().callOptions?.compression ?? ""}
+												onChange={e => updateCallOptions(props.tabId, { compression: e.currentTarget.value })}>
+												<option value="">Без сжатия</option>
+												<option value="gzip">gzip</option>
+											</select>
+											<label class="label cursor-pointer gap-2">
+												<input
+													type="checkbox"
+													class="checkbox checkbox-sm"
+													checked={d().callOptions?.waitForReady ?? false}
+													onChange={e => updateCallOptions(props.tabId, { waitForReady: e.currentTarget.checked })}
+												/>
+												<span class="label-text">wait-for-ready</span>
+											</label>
+										</div>
+										<div class={styles.keyValueRow}>
+											<select
+												class="select select-sm select-bordered"
+												value={d().callOptions?.credentials?.type ?? ""}
+												onChange={e =>
+													updateCallOptions(props.tabId, {
+														credentials: { ...d().callOptions?.credentials, type: e.currentTarget.value },
+													})
+												}>
+												<option value="">Без учетных данных</option>
+												<option value="bearer">Bearer</option>
+												<option value="basic">Basic</option>
+											</select>
+											<Show when={d().callOptions?.credentials?.type === "bearer"}>
+												<input
+													type="password"
+													class="input input-sm"
+													placeholder="Токен"
+													value={d().callOptions?.credentials?.token ?? ""}
+													onInput={e =>
+														updateCallOptions(props.tabId, {
+															credentials: { ...d().callOptions!.credentials!, token: e.currentTarget.value },
+														})
+													}
+												/>
+											</Show>
+											<Show when={d().callOptions?.credentials?.type === "basic"}>
+												<input
+													type="text"
+													class="input input-sm"
+													placeholder="Пользователь"
+													value={d().callOptions?.credentials?.username ?? ""}
+													onInput={e =>
+														updateCallOptions(props.tabId, {
+															credentials: { ...d().callOptions!.credentials!, username: e.currentTarget.value },
+														})
+													}
+												/>
+												<input
+													type="password"
+													class="input input-sm"
+													placeholder="Пароль"
+													value={d().callOptions?.credentials?.password ?? ""}
+													onInput={e =>
+														updateCallOptions(props.tabId, {
+															credentials: { ...d().callOptions!.credentials!, password: e.currentTarget.value },
+														})
+													}
+												/>
+											</Show>
 										</div>
 										<For each={d().contextValues}>
 											{item => (
@@ -232,6 +580,43 @@ export const SendRequest = (props: SendRequestProps) => {
 										</div>
 									</div>
 								</Show>
+
+								<Show when={d().activeTab === "extraction"}>
+									<ExtractionRules serverId={d().serverId} service={d().serviceName} method={d().methodName} />
+								</Show>
+
+								<Show when={d().activeTab === "scripts"}>
+									<MethodScripts serverId={d().serverId} service={d().serviceName} method={d().methodName} />
+								</Show>
+
+								<Show when={d().activeTab === "assertions"}>
+									<div class={styles.keyValueList}>
+										<div class={styles.keyValueDescription}>
+											Проверки выполняются после каждого вызова, результат виден в ответе и в истории. Путь по телу -
+											JSONPath или jq, время - <span class="font-mono">200ms</span> или число миллисекунд, код - имя (
+											<span class="font-mono">NOT_FOUND</span>) или число
+										</div>
+										<Assertions
+											title="Проверки метода"
+											load={() => GetAssertions(d().serverId, d().serviceName, d().methodName)}
+											save={assertions => SaveAssertions(d().serverId, d().serviceName, d().methodName, assertions)}
+										/>
+										<Show when={d().savedRequestId}>
+											{savedId => (
+												<Assertions
+													title="Проверки запроса из коллекции"
+													load={async () =>
+														((await GetSavedRequest(savedId()))?.assertions ?? []) as Assertion[]
+													}
+													save={async assertions => {
+														const saved = await GetSavedRequest(savedId());
+														if (saved) await updateSavedRequest({ ...saved, assertions });
+													}}
+												/>
+											)}
+										</Show>
+									</div>
+								</Show>
 							</div>
 						</div>
 
@@ -260,6 +645,10 @@ export const SendRequest = (props: SendRequestProps) => {
 							</div>
 						</div>
 					</div>
+
+					<Show when={showSave()}>
+						<SaveToCollectionModal tabId={props.tabId} onClose={() => setShowSave(false)} />
+					</Show>
 				</div>
 			)}
 		</Show>
@@ -1,4 +1,4 @@
-import { For, Show, createSignal } from "solid-js";
+import { For, Show, createEffect, createSignal, onCleanup, onMount } from "solid-js";
 import { $servers } from "../stores/servers";
 import { $expand } from "../stores/expand";
 import { BiRegularRefresh } from "solid-icons/bi";
@@ -7,15 +7,60 @@ import { $busy } from "../stores/busy";
 import { DropDownContainer } from "../components/Dropdown";
 import { EmptyFallback } from "../components/EmptyFallback";
 import { IoChevronCollapse, IoExpand } from "solid-icons/io";
-import { FaSolidHashtag, FaSolidPen, FaSolidTrash } from "solid-icons/fa";
+import {
+	FaSolidFileExport,
+	FaSolidHashtag,
+	FaSolidHeartPulse,
+	FaSolidPen,
+	FaSolidTrash,
+} from "solid-icons/fa";
 import { TiStarOutline, TiStarFullOutline } from "solid-icons/ti";
 import { $tabs } from "../stores/tabs";
-import { ServerWithReflection } from "../../bindings/grpc-gui";
-import { ToggleFavoriteServer, DeleteServer } from "../../bindings/grpc-gui/app";
+import { ReflectionStatus, ServerWithReflection } from "../../bindings/grpc-gui";
+import {
+	ToggleFavoriteServer,
+	DeleteServer,
+	GetServerOpenAPI,
+	SearchSymbols,
+	FindTypeUsages,
+	CheckServerHealth,
+	GetServerHealth,
+	SetServerHealthService,
+} from "../../bindings/grpc-gui/app";
+import { TypeUsage } from "../../bindings/grpc-gui/internal/grpcreflect/models";
+import { Hit, Kind } from "../../bindings/grpc-gui/internal/search";
 import { ContextMenu } from "@kobalte/core/context-menu";
 import { VsRefresh } from "solid-icons/vs";
 import { useNavigate } from "@solidjs/router";
 import { ServerModal } from "./ServerModal";
+import { EnvironmentsModal } from "./EnvironmentsModal";
+import { CollectionsModal } from "./CollectionsModal";
+
+// ServiceMethodsLoader догружает методы раскрытого сервиса, пока полная схема сервера еще в пути
+const ServiceMethodsLoader = (props: { serverId: number; serviceName: string }) => {
+	const [loading, setLoading] = createSignal(true);
+	const [error, setError] = createSignal("");
+
+	onMount(async () => {
+		try {
+			await $servers.loadServiceMethods(props.serverId, props.serviceName);
+		} catch (err) {
+			setError(err instanceof Error ? err.message : String(err));
+		} finally {
+			setLoading(false);
+		}
+	});
+
+	return (
+		<Show when={!loading()} fallback={<span class="loading loading-spinner loading-xs" />}>
+			<Show when={error()} fallback={<EmptyFallback message="А где методы?" />}>
+				<div title={error()} class="truncate text-xs text-error/90">
+					{error()}
+				</div>
+			</Show>
+		</Show>
+	);
+};
 
 export const WorkspaceServicesMenu = () => {
 	const navigate = useNavigate();
@@ -23,6 +68,64 @@ export const WorkspaceServicesMenu = () => {
 	const [searchQuery, setSearchQuery] = createSignal("");
 	const [editingServer, setEditingServer] = createSignal<ServerWithReflection | null>(null);
 	const [showAddModal, setShowAddModal] = createSignal(false);
+	const [showEnvironments, setShowEnvironments] = createSignal(false);
+	const [showCollections, setShowCollections] = createSignal(false);
+	const [symbolHits, setSymbolHits] = createSignal<Hit[]>([]);
+
+	// Поиск символов по всем серверам идет на бэкенде, запрос отправляется после паузы в наборе
+	createEffect(() => {
+		const query = searchQuery().trim();
+		if (query.length < 2) {
+			setSymbolHits([]);
+			return;
+		}
+
+		const timer = setTimeout(async () => {
+			try {
+				setSymbolHits((await SearchSymbols(query, 20)) || []);
+			} catch (err) {
+				console.error("Failed to search symbols:", err);
+			}
+		}, 200);
+		onCleanup(() => clearTimeout(timer));
+	});
+
+	const symbolKindLabels: Record<string, string> = {
+		[Kind.KindService]: "сервис",
+		[Kind.KindMethod]: "метод",
+		[Kind.KindMessage]: "сообщение",
+		[Kind.KindField]: "поле",
+		[Kind.KindEnum]: "enum",
+		[Kind.KindEnumValue]: "значение",
+	};
+
+	const [typeUsages, setTypeUsages] = createSignal<{ hit: Hit; usages: TypeUsage[] } | null>(null);
+
+	const handleShowTypeUsages = async (e: MouseEvent, hit: Hit) => {
+		e.stopPropagation();
+		try {
+			const usages = await FindTypeUsages(hit.entry.serverId, hit.entry.fullName);
+			setTypeUsages({ hit, usages: usages || [] });
+		} catch (err: any) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: err?.message || "Не удалось найти использования типа",
+			});
+		}
+	};
+
+	const handleOpenSymbol = (hit: Hit) => {
+		const { serverId, service, method } = hit.entry;
+		if (service && method) {
+			$tabs.openRequestTab(serverId, service, method);
+			return;
+		}
+		if (service) {
+			$expand.setByKey($servers.getServerExpandPersistentKey(serverId), true);
+			$expand.setByKey($servers.getServiceExpandPersistentKey(serverId, service), true);
+		}
+	};
 
 	const filteredServers = () => {
 		const query = searchQuery().toLowerCase().trim();
@@ -140,6 +243,82 @@ export const WorkspaceServicesMenu = () => {
 		});
 	};
 
+	const handleDownloadOpenAPI = async (server: ServerWithReflection) => {
+		let document = "";
+		try {
+			document = await GetServerOpenAPI(server.server?.id!);
+		} catch (err: any) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: err?.message || "Не удалось сгенерировать OpenAPI",
+			});
+			return;
+		}
+
+		const blob = new Blob([document], { type: "application/json" });
+		const url = URL.createObjectURL(blob);
+		const a = window.document.createElement("a");
+		a.href = url;
+		a.download = `openapi-${server.server?.name}.json`;
+		window.document.body.appendChild(a);
+		a.click();
+		window.document.body.removeChild(a);
+		URL.revokeObjectURL(url);
+	};
+
+	const healthColors: Record<string, string> = {
+		SERVING: "bg-success",
+		NOT_SERVING: "bg-error",
+		SERVICE_UNKNOWN: "bg-warning",
+		UNREACHABLE: "bg-error",
+	};
+
+	const healthTitle = (server: ServerWithReflection) => {
+		const s = server.server;
+		if (!s?.healthStatus) return "Статус еще не проверялся";
+		const at = new Date(s.healthCheckedAt).toLocaleString("ru-RU");
+		return `${s.healthStatus}${s.healthService ? ` (${s.healthService})` : ""}, ${at}${s.healthError ? `\n${s.healthError}` : ""}`;
+	};
+
+	const handleShowHealth = async (serverId: number) => {
+		try {
+			await CheckServerHealth(serverId);
+			const summary = await GetServerHealth(serverId, 0);
+			const history = (summary?.history || [])
+				.slice(0, 10)
+				.map(check => check.status)
+				.join(", ");
+			$notifications.addNotification({
+				type: NotificationType.SUCCESS,
+				title: `Статус: ${summary?.status}`,
+				message: `Аптайм ${Math.round((summary?.uptime || 0) * 100)}%. Последние проверки: ${history}`,
+			});
+		} catch (err: any) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: err?.message || "Не удалось проверить статус сервера",
+			});
+		}
+	};
+
+	const handleSetHealthService = async (server: ServerWithReflection) => {
+		const service = prompt("Сервис для health check (пусто - весь сервер)", server.server?.healthService || "");
+		if (service === null) return;
+
+		try {
+			await SetServerHealthService(server.server?.id!, service.trim());
+			await CheckServerHealth(server.server?.id!);
+		} catch (err: any) {
+			$notifications.addNotification({
+				type: NotificationType.ERROR,
+				title: "Ошибка",
+				message: err?.message || "Не удалось сохранить сервис для health check",
+			});
+		}
+	};
+
 	const handleEditServer = (server: ServerWithReflection) => {
 		setEditingServer(server);
 	};
@@ -175,6 +354,12 @@ export const WorkspaceServicesMenu = () => {
 						</button>
 					</Show>
 
+					<button class="btn btn-xs btn-neutral" title="Переменные окружений" onClick={() => setShowEnvironments(true)}>
+						Окружения
+					</button>
+					<button class="btn btn-xs btn-neutral" title="Сохраненные запросы" onClick={() => setShowCollections(true)}>
+						Коллекции
+					</button>
 					<button class="btn btn-xs btn-secondary" onClick={handleAddService}>
 						Добавить
 					</button>
@@ -193,6 +378,60 @@ export const WorkspaceServicesMenu = () => {
 				</div>
 			</Show>
 
+			<Show when={symbolHits().length > 0}>
+				<div class="mt-2 flex flex-col gap-0.5 max-h-64 overflow-auto">
+					<div class="text-xs text-base-content/60">Символы</div>
+					<For each={symbolHits()}>
+						{hit => (
+							<button
+								onClick={() => handleOpenSymbol(hit)}
+								title={hit.entry.comment || hit.entry.fullName}
+								class="w-full text-sm text-base-content/90 cursor-pointer flex gap-1 items-center px-2 justify-start hover:text-base-content transition-all duration-300 hover:bg-base-300">
+								<span class="badge badge-xs badge-ghost">{symbolKindLabels[hit.entry.kind]}</span>
+								<span class="truncate flex-1 text-left">{hit.entry.fullName}</span>
+								<span class="truncate text-xs text-base-content/50">{hit.entry.serverName}</span>
+								<Show when={hit.entry.kind === Kind.KindMessage || hit.entry.kind === Kind.KindEnum}>
+									<span
+										class="link link-hover text-xs"
+										title="Методы, которые используют тип"
+										onClick={e => handleShowTypeUsages(e, hit)}>
+										где
+									</span>
+								</Show>
+							</button>
+						)}
+					</For>
+				</div>
+			</Show>
+
+			<Show when={typeUsages()}>
+				{current => (
+					<div class="mt-2 flex flex-col gap-0.5 max-h-64 overflow-auto">
+						<div class="flex justify-between text-xs text-base-content/60">
+							<span class="truncate">Используют {current().hit.entry.fullName}</span>
+							<button class="link link-hover" onClick={() => setTypeUsages(null)}>
+								скрыть
+							</button>
+						</div>
+						<For each={current().usages} fallback={<EmptyFallback message="Никто не использует" />}>
+							{usage => (
+								<button
+									onClick={() => $tabs.openRequestTab(current().hit.entry.serverId, usage.service, usage.method)}
+									title={[...(usage.requestPath || []), ...(usage.responsePath || [])].join("\n")}
+									class="w-full text-sm text-base-content/90 cursor-pointer flex gap-1 items-center px-2 justify-start hover:text-base-content transition-all duration-300 hover:bg-base-300">
+									<span class="truncate flex-1 text-left">
+										{usage.service}/{usage.method}
+									</span>
+									<span class="text-xs text-base-content/50">
+										{[usage.inRequest && "запрос", usage.inResponse && "ответ"].filter(Boolean).join(", ")}
+									</span>
+								</button>
+							)}
+						</For>
+					</div>
+				)}
+			</Show>
+
 			<div class="mt-4 flex flex-col gap-1">
 				<Show when={isLoading()}>
 					<div class="flex items-center justify-center py-8">
@@ -222,16 +461,22 @@ export const WorkspaceServicesMenu = () => {
 									open={expanded()[serverExpandKey]}
 									title={server.server?.name!}
 									prefix={
-										<button
-											class="hover:text-warning transition-colors cursor-default"
-											onClick={e => handleToggleFavorite(e, serverId)}
-											title={server.server?.favorite ? "Убрать из избранного" : "Добавить в избранное"}>
-											{server.server?.favorite ? (
-												<TiStarFullOutline class="w-3 h-3" />
-											) : (
-												<TiStarOutline class="w-3 h-3" />
-											)}
-										</button>
+										<>
+											<span
+												title={healthTitle(server)}
+												class={`inline-block w-2 h-2 rounded-full ${healthColors[server.server?.healthStatus || ""] || "bg-base-content/20"}`}
+											/>
+											<button
+												class="hover:text-warning transition-colors cursor-default"
+												onClick={e => handleToggleFavorite(e, serverId)}
+												title={server.server?.favorite ? "Убрать из избранного" : "Добавить в избранное"}>
+												{server.server?.favorite ? (
+													<TiStarFullOutline class="w-3 h-3" />
+												) : (
+													<TiStarOutline class="w-3 h-3" />
+												)}
+											</button>
+										</>
 									}
 									onOpenChange={v => handleToggleServerExpand(serverExpandKey, v)}
 									headerWrapper={header => (
@@ -245,6 +490,24 @@ export const WorkspaceServicesMenu = () => {
 														<VsRefresh class="w-3 h-3" />
 														<span>Обновить рефлексию</span>
 													</ContextMenu.Item>
+													<ContextMenu.Item
+														class="context-menu-item flex items-center gap-2"
+														onSelect={() => handleShowHealth(serverId)}>
+														<FaSolidHeartPulse class="w-3 h-3" />
+														<span>Статус и аптайм</span>
+													</ContextMenu.Item>
+													<ContextMenu.Item
+														class="context-menu-item flex items-center gap-2"
+														onSelect={() => handleSetHealthService(server)}>
+														<FaSolidHeartPulse class="w-3 h-3" />
+														<span>Сервис для health check</span>
+													</ContextMenu.Item>
+													<ContextMenu.Item
+														class="context-menu-item flex items-center gap-2"
+														onSelect={() => handleDownloadOpenAPI(server)}>
+														<FaSolidFileExport class="w-3 h-3" />
+														<span>Скачать OpenAPI</span>
+													</ContextMenu.Item>
 													<ContextMenu.Item
 														class="context-menu-item flex items-center gap-2"
 														onSelect={() => handleEditServer(server)}>
@@ -261,7 +524,32 @@ export const WorkspaceServicesMenu = () => {
 											</ContextMenu.Portal>
 										</ContextMenu>
 									)}>
-									<Show when={!server.error}>
+									<Show when={!server.error && server.status === ReflectionStatus.$zero}>
+										<div class="flex items-center gap-2 text-sm text-base-content/60">
+											<span class="loading loading-spinner loading-xs"></span>
+											<span>Загрузка рефлексии...</span>
+										</div>
+									</Show>
+
+									<Show when={server.error && server.status === ReflectionStatus.ReflectionStatusStale}>
+										<div title={server.error} class="truncate text-xs text-warning/90">
+											Офлайн, схема от{" "}
+											{server.schemaAt ? new Date(server.schemaAt).toLocaleString("ru-RU") : "?"}:{" "}
+											{server.error}
+										</div>
+									</Show>
+
+									<Show when={!server.error && server.warning}>
+										<div title={server.warning} class="truncate text-xs text-warning/90">
+											Схема загружена не полностью: {server.warning}
+										</div>
+									</Show>
+
+									<Show
+										when={
+											(!server.error && server.status !== ReflectionStatus.$zero) ||
+											server.status === ReflectionStatus.ReflectionStatusStale
+										}>
 										<For
 											each={server.reflection?.services}
 											fallback={<EmptyFallback message="А где сервисы?" />}>
@@ -279,7 +567,7 @@ export const WorkspaceServicesMenu = () => {
 														<div class="flex flex-col gap-0.5">
 															<For
 																each={service.methods}
-																fallback={<EmptyFallback message="А где методы?" />}>
+																fallback={<ServiceMethodsLoader serverId={serverId} serviceName={service.name} />}>
 																{method => {
 																	return (
 																		<button
@@ -298,7 +586,7 @@ export const WorkspaceServicesMenu = () => {
 										</For>
 									</Show>
 
-									<Show when={server.error}>
+									<Show when={server.error && server.status !== ReflectionStatus.ReflectionStatusStale}>
 										<div class="flex gap-1 items-center justify-between">
 											<div title={server.error} class="truncate text-sm text-error/90">
 												{server.error}
@@ -332,6 +620,14 @@ export const WorkspaceServicesMenu = () => {
 					onSuccess={handleModalSuccess}
 				/>
 			</Show>
+
+			<Show when={showEnvironments()}>
+				<EnvironmentsModal onClose={() => setShowEnvironments(false)} />
+			</Show>
+
+			<Show when={showCollections()}>
+				<CollectionsModal onClose={() => setShowCollections(false)} />
+			</Show>
 		</>
 	);
 };
@@ -0,0 +1,90 @@
+import { createRoot, createSignal } from "solid-js";
+import {
+	GetCollections,
+	GetCollectionItems,
+	CreateCollection,
+	UpdateCollection,
+	DeleteCollection,
+	DuplicateCollection,
+	CreateFolder,
+	RenameFolder,
+	DeleteFolder,
+	MoveFolder,
+	CreateSavedRequest,
+	UpdateSavedRequest,
+	DeleteSavedRequest,
+	DuplicateSavedRequest,
+	MoveSavedRequest,
+} from "../../bindings/grpc-gui/app";
+import { CollectionItems } from "../../bindings/grpc-gui/models";
+import { Collection, SavedRequest } from "../../bindings/grpc-gui/internal/models/models";
+
+const createCollectionsStore = () => {
+	const [collections, setCollections] = createSignal<Collection[]>([]);
+	// items - содержимое открытой коллекции
+	const [items, setItems] = createSignal<CollectionItems | null>(null);
+
+	const loadCollections = async () => {
+		try {
+			setCollections((await GetCollections()) || []);
+		} catch (error) {
+			console.error("Failed to load collections:", error);
+		}
+	};
+
+	const openCollection = async (id: number | null) => {
+		if (id === null) {
+			setItems(null);
+			return;
+		}
+		setItems(await GetCollectionItems(id));
+	};
+
+	// reload обновляет список коллекций и открытую коллекцию после любого изменения
+	const reload = async () => {
+		await loadCollections();
+		const current = items();
+		if (current) {
+			await openCollection(current.collection.id).catch(() => setItems(null));
+		}
+	};
+
+	const withReload =
+		<A extends unknown[], R>(fn: (...args: A) => Promise<R>) =>
+		async (...args: A) => {
+			const result = await fn(...args);
+			await reload();
+			return result;
+		};
+
+	return {
+		collections,
+		items,
+		loadCollections,
+		openCollection,
+		createCollection: withReload((name: string, description: string) => CreateCollection(name, description)),
+		updateCollection: withReload((id: number, name: string, description: string) =>
+			UpdateCollection(id, name, description),
+		),
+		deleteCollection: withReload(async (id: number) => {
+			await DeleteCollection(id);
+			if (items()?.collection.id === id) setItems(null);
+		}),
+		duplicateCollection: withReload((id: number) => DuplicateCollection(id)),
+		createFolder: withReload((collectionId: number, parentId: number, name: string) =>
+			CreateFolder(collectionId, parentId, name),
+		),
+		renameFolder: withReload((id: number, name: string) => RenameFolder(id, name)),
+		deleteFolder: withReload((id: number) => DeleteFolder(id)),
+		moveFolder: withReload((id: number, parentId: number, position: number) => MoveFolder(id, parentId, position)),
+		createSavedRequest: withReload((request: SavedRequest) => CreateSavedRequest(request)),
+		updateSavedRequest: withReload((request: SavedRequest) => UpdateSavedRequest(request)),
+		deleteSavedRequest: withReload((id: number) => DeleteSavedRequest(id)),
+		duplicateSavedRequest: withReload((id: number) => DuplicateSavedRequest(id)),
+		moveSavedRequest: withReload((id: number, folderId: number, position: number) =>
+			MoveSavedRequest(id, folderId, position),
+		),
+	};
+};
+
+export const $collections = createRoot(createCollectionsStore);
@@ -0,0 +1,54 @@
+import { createRoot, createSignal } from "solid-js";
+import {
+	GetEnvironments,
+	CreateEnvironment,
+	UpdateEnvironment,
+	DeleteEnvironment,
+	SetActiveEnvironment,
+} from "../../bindings/grpc-gui/app";
+import { Environment } from "../../bindings/grpc-gui/internal/models/models";
+
+const createEnvironmentsStore = () => {
+	const [environments, setEnvironments] = createSignal<Environment[]>([]);
+
+	const loadEnvironments = async () => {
+		try {
+			setEnvironments((await GetEnvironments()) || []);
+		} catch (error) {
+			console.error("Failed to load environments:", error);
+		}
+	};
+
+	// serverId = 0 - глобальное окружение
+	const createEnvironment = async (name: string, serverId: number, variables: Record<string, string>) => {
+		const id = await CreateEnvironment(name, serverId, variables);
+		await loadEnvironments();
+		return id;
+	};
+
+	const updateEnvironment = async (id: number, name: string, variables: Record<string, string>) => {
+		await UpdateEnvironment(id, name, variables);
+		await loadEnvironments();
+	};
+
+	const deleteEnvironment = async (id: number) => {
+		await DeleteEnvironment(id);
+		await loadEnvironments();
+	};
+
+	const setActive = async (id: number, active: boolean) => {
+		await SetActiveEnvironment(id, active);
+		await loadEnvironments();
+	};
+
+	return {
+		environments,
+		loadEnvironments,
+		createEnvironment,
+		updateEnvironment,
+		deleteEnvironment,
+		setActive,
+	};
+};
+
+export const $environments = createRoot(createEnvironmentsStore);
@@ -1,19 +1,117 @@
-import { createRoot, createSignal, onMount } from "solid-js";
-import { GetServersWithReflection, GetServerWithReflection } from "../../bindings/grpc-gui/app";
-import { ServerWithReflection } from "../../bindings/grpc-gui";
+import { createRoot, createSignal, onCleanup, onMount } from "solid-js";
+import { Events } from "@wailsio/runtime";
+import {
+	GetMethodInfo,
+	GetServers,
+	GetServersWithReflection,
+	GetServerWithReflection,
+	GetServiceInfo,
+} from "../../bindings/grpc-gui/app";
+import { ReflectionStatus, ServerWithReflection } from "../../bindings/grpc-gui";
+import { MethodInfo, ServiceInfo } from "../../bindings/grpc-gui/internal/grpcreflect/models";
 import { $notifications, NotificationType } from "./notifications";
 
 const createServersStore = () => {
 	const [servers, setServers] = createSignal<ServerWithReflection[]>([]);
 	const [isLoading, setIsLoading] = createSignal(false);
 
-	onMount(() => refreshServers());
+	onMount(() => {
+		// Рефлексия серверов приходит по мере готовности, не дожидаясь самого медленного
+		const off = Events.On("server:reflection", event => {
+			const update = event.data;
+			setServers(s => s.map(v => (v.server?.id === update.server?.id ? applyReflection(v, update) : v)));
+		});
+		onCleanup(off);
+
+		// Статус grpc.health.v1 приходит из плановых проверок и подписок на открытые серверы
+		const offHealth = Events.On("server:health", event => {
+			const check = event.data;
+			setServers(s =>
+				s.map(v =>
+					v.server?.id === check.serverId
+						? {
+								...v,
+								server: {
+									...v.server,
+									healthStatus: check.status,
+									healthCheckedAt: check.createdAt,
+									healthError: check.error,
+								},
+							}
+						: v,
+				),
+			);
+		});
+		onCleanup(offHealth);
+
+		refreshServers();
+	});
+
+	// Быстрый список сервисов не заменяет уже полученную полную схему: свежая придет следующим событием
+	const applyReflection = (previous: ServerWithReflection | undefined, update: ServerWithReflection) => {
+		if (!previous) return update;
+		if (update.pending && previous.reflection && !previous.pending && !previous.error) {
+			return { ...previous, server: update.server };
+		}
+		return keepLoadedMethods(previous, update);
+	};
+
+	// Уже загруженные детали методов остаются, если схема метода не поменялась.
+	// В быстром списке у новых сервисов методов нет, для них остаются ранее загруженные
+	const keepLoadedMethods = (previous: ServerWithReflection, update: ServerWithReflection): ServerWithReflection => {
+		if (!update.reflection) return update;
+
+		const loaded = new Map<string, MethodInfo>();
+		const previousMethods = new Map<string, MethodInfo[]>();
+		previous.reflection?.services?.forEach(service => {
+			previousMethods.set(service.name, service.methods ?? []);
+			service.methods?.forEach(m => m.request && loaded.set(`${service.name}/${m.name}`, m));
+		});
+
+		const methods = (service: ServiceInfo) => {
+			if (update.pending && !service.methods?.length) return previousMethods.get(service.name) ?? [];
+			return service.methods.map(m => {
+				const existing = loaded.get(`${service.name}/${m.name}`);
+				return existing && existing.schemaHash === m.schemaHash ? existing : m;
+			});
+		};
+
+		return {
+			...update,
+			reflection: {
+				...update.reflection,
+				services: update.reflection.services.map(service => ({ ...service, methods: methods(service) })),
+			},
+		};
+	};
 
 	const refreshServers = async () => {
 		setIsLoading(true);
+		try {
+			const list = await GetServers();
+			setServers(prev =>
+				(list || []).map(
+					server =>
+						prev.find(v => v.server?.id === server.id) ?? {
+							server,
+							reflection: null,
+							status: ReflectionStatus.$zero,
+						},
+				),
+			);
+		} catch (err) {
+			console.error("Failed to fetch servers:", err);
+		} finally {
+			setIsLoading(false);
+		}
+
 		try {
 			const servers = await GetServersWithReflection();
-			setServers(servers || []);
+			setServers(prev =>
+				(servers || []).map(update =>
+					applyReflection(prev.find(v => v.server?.id === update.server?.id), update),
+				),
+			);
 		} catch (err) {
 			console.error("Failed to fetch servers:", err);
 			$notifications.addNotification({
@@ -21,15 +119,13 @@ const createServersStore = () => {
 				title: "Ошибка",
 				message: "Не удалось получить список сервисов",
 			});
-		} finally {
-			setIsLoading(false);
 		}
 	};
 
 	const refreshServerById = async (serverId: number) => {
 		try {
 			const server = await GetServerWithReflection(serverId);
-			setServers(s => s.map(v => (v.server?.id === serverId ? server! : v)));
+			setServers(s => s.map(v => (v.server?.id === serverId ? keepLoadedMethods(v, server!) : v)));
 		} catch (err) {
 			return $notifications.addNotification({
 				type: NotificationType.ERROR,
@@ -39,6 +135,58 @@ const createServersStore = () => {
 		}
 	};
 
+	// Пока полная схема сервера грузится в фоне, методы раскрытого сервиса запрашиваются отдельно
+	const loadServiceMethods = async (serverId: number, serviceName: string) => {
+		const service: ServiceInfo | null = await GetServiceInfo(serverId, serviceName);
+		if (!service) return;
+
+		setServers(s =>
+			s.map(v => {
+				if (v.server?.id !== serverId || !v.reflection) return v;
+				return {
+					...v,
+					reflection: {
+						...v.reflection,
+						services: v.reflection.services.map(existing =>
+							existing.name === serviceName && !existing.methods?.length ? service : existing,
+						),
+					},
+				};
+			}),
+		);
+	};
+
+	// Список сервисов приходит без деталей методов, они догружаются при открытии метода
+	const loadMethodInfo = async (serverId: number, serviceName: string, methodName: string) => {
+		const existing = servers()
+			.find(v => v.server?.id === serverId)
+			?.reflection?.services?.find(s => s.name === serviceName)
+			?.methods?.find(m => m.name === methodName);
+		if (existing?.request) return existing;
+
+		const info: MethodInfo | null = await GetMethodInfo(serverId, serviceName, methodName);
+		if (!info) return existing;
+
+		setServers(s =>
+			s.map(v => {
+				if (v.server?.id !== serverId || !v.reflection) return v;
+				return {
+					...v,
+					reflection: {
+						...v.reflection,
+						services: v.reflection.services.map(service =>
+							service.name !== serviceName
+								? service
+								: { ...service, methods: service.methods.map(m => (m.name === methodName ? info : m)) },
+						),
+					},
+				};
+			}),
+		);
+
+		return info;
+	};
+
 	const getServerExpandPersistentKey = (serverId: number) => {
 		return `server-expand:${serverId}`;
 	};
@@ -94,6 +242,8 @@ const createServersStore = () => {
 		refreshServers,
 		getTabIdForMethod,
 		refreshServerById,
+		loadMethodInfo,
+		loadServiceMethods,
 		toggleFavorite,
 		removeServer,
 		getServerExpandPersistentKey,
@@ -1,7 +1,19 @@
 import { createRoot, createSignal, createEffect } from "solid-js";
 import { createStore, produce } from "solid-js/store";
-import { History } from "../../bindings/grpc-gui/internal/models/models";
-import { DoGRPCRequest, SaveTabStates, GetTabStates, DeleteTabState } from "../../bindings/grpc-gui/app";
+import { History, SavedRequest } from "../../bindings/grpc-gui/internal/models/models";
+import { BinaryMetadata, RequestContext } from "../../bindings/grpc-gui/internal/grpcrequest/models";
+import { Value as ExtractValue } from "../../bindings/grpc-gui/internal/extract/models";
+import { LogEntry } from "../../bindings/grpc-gui/internal/scripting/models";
+import { Kind, Result as AssertionResult } from "../../bindings/grpc-gui/internal/assertion/models";
+import {
+	DoGRPCRequest,
+	DoSavedRequest,
+	SaveTabStates,
+	GetTabStates,
+	DeleteTabState,
+	SetOpenServers,
+} from "../../bindings/grpc-gui/app";
+import { Events } from "@wailsio/runtime";
 import { $history } from "./history";
 import stripJsonComments from "strip-json-comments";
 
@@ -20,12 +32,18 @@ export type SendRequestData = {
 	serviceName: string;
 	methodName: string;
 	historyData?: History;
-	activeTab: "body" | "metadata" | "context";
+	// savedRequestId - вкладка открыта из коллекции
+	savedRequestId?: number;
+	activeTab: "body" | "metadata" | "context" | "extraction" | "scripts" | "assertions";
 	requestBody: string;
 	metadata: KeyValuePair[];
+	// contextValues уходят на сервер метаданными вызова
 	contextValues: KeyValuePair[];
+	// callOptions - таймаут, компрессия, wait-for-ready и учетные данные; в старых вкладках отсутствует
+	callOptions?: RequestContext;
 	response: string;
 	responseTime: number;
+	schemaWarning?: string;
 };
 
 export type TabData = {
@@ -182,6 +200,16 @@ const createTabsStore = () => {
 		});
 	};
 
+	const updateCallOptions = (tabId: string, patch: Partial<RequestContext>) => {
+		const tab = tabs.find(t => t.id === tabId);
+		if (!tab || tab.type !== TabType.REQUEST) return;
+
+		const data = tab.data as SendRequestData;
+		updateTabData<TabType.REQUEST>(tabId, {
+			callOptions: { ...data.callOptions, ...patch },
+		});
+	};
+
 	const activateTab = (id: string) => {
 		setTabs(
 			produce(tabs => {
@@ -190,6 +218,46 @@ const createTabsStore = () => {
 		);
 	};
 
+	// openSavedRequest открывает запрос из коллекции, отправка и сохранение идут от его имени
+	const openSavedRequest = (saved: SavedRequest) => {
+		let requestBody = saved.payload || "{}";
+		try {
+			requestBody = JSON.stringify(JSON.parse(requestBody), null, 2);
+		} catch {}
+
+		const rows = (values?: { [_ in string]?: string } | null) => {
+			const list = Object.entries(values || {}).map(([key, value]) => ({
+				id: crypto.randomUUID(),
+				key,
+				value: value || "",
+			}));
+			return list.length > 0 ? list : [{ id: crypto.randomUUID(), key: "", value: "" }];
+		};
+
+		const { metadata: contextMetadata, ...callOptions } = saved.context || {};
+
+		addTab<TabType.REQUEST>({
+			id: `saved-${saved.id}`,
+			name: saved.name,
+			type: TabType.REQUEST,
+			data: {
+				serverId: saved.serverId,
+				serviceName: saved.service,
+				methodName: saved.method,
+				savedRequestId: saved.id,
+				activeTab: "body",
+				requestBody,
+				metadata: rows(saved.headers),
+				contextValues: rows(contextMetadata),
+				callOptions,
+				response: "",
+				responseTime: 0,
+			},
+			isActive: true,
+			temporary: false,
+		});
+	};
+
 	const openRequestTab = (
 		serverId: number,
 		serviceName: string,
@@ -205,23 +273,37 @@ const createTabsStore = () => {
 
 		let metadata = defaultMetadata;
 		let contextValues = defaultContextValues;
+		let callOptions: RequestContext = {};
 		let requestBody = "{}";
 		let response = "";
 		let responseTime = 0;
 
 		if (historyData) {
-			if (historyData.request) {
+			// Запрос с переменными открываем шаблоном, чтобы повтор взял текущие значения окружения
+			const source = { ...historyData };
+			if (historyData.requestTemplate) {
+				try {
+					const template = JSON.parse(historyData.requestTemplate);
+					source.request = template.payload || "";
+					source.requestHeaders = template.headers ? JSON.stringify(template.headers) : "";
+					source.requestContext = template.context ? JSON.stringify(template.context) : "";
+				} catch (err) {
+					console.error("Failed to parse request template:", err);
+				}
+			}
+
+			if (source.request) {
 				try {
-					const parsed = JSON.parse(historyData.request);
+					const parsed = JSON.parse(source.request);
 					requestBody = JSON.stringify(parsed, null, 2);
 				} catch {
-					requestBody = historyData.request;
+					requestBody = source.request;
 				}
 			}
 
-			if (historyData.requestHeaders) {
+			if (source.requestHeaders) {
 				try {
-					const parsed = JSON.parse(historyData.requestHeaders);
+					const parsed = JSON.parse(source.requestHeaders);
 					const metadataArray = Object.entries(parsed).map(([key, value]) => ({
 						id: crypto.randomUUID(),
 						key,
@@ -235,10 +317,13 @@ const createTabsStore = () => {
 				}
 			}
 
-			if (historyData.contextValues) {
+			if (source.requestContext) {
 				try {
-					const parsed = JSON.parse(historyData.contextValues);
-					const contextArray = Object.entries(parsed).map(([key, value]) => ({
+					const { metadata: contextMetadata, ...options } = JSON.parse(
+						source.requestContext,
+					) as RequestContext;
+					callOptions = options;
+					const contextArray = Object.entries(contextMetadata || {}).map(([key, value]) => ({
 						id: crypto.randomUUID(),
 						key,
 						value: String(value),
@@ -247,7 +332,7 @@ const createTabsStore = () => {
 						contextValues = contextArray;
 					}
 				} catch (err) {
-					console.error("Failed to parse context values:", err);
+					console.error("Failed to parse request context:", err);
 				}
 			}
 
@@ -261,6 +346,32 @@ const createTabsStore = () => {
 					const startTimeStr = new Date(historyData.createdAt).toLocaleString("ru-RU");
 					response = `// Время начала выполнения запроса: ${startTimeStr}\n// Время выполнения запроса: ${historyData.executionTime}ms\n// Код ответа: ${historyData.statusCode}\n\n${historyData.response}`;
 				}
+			} else if (historyData.statusMessage || historyData.statusDetails) {
+				const startTimeStr = new Date(historyData.createdAt).toLocaleString("ru-RU");
+				let details = historyData.statusDetails || "";
+				try {
+					details = JSON.stringify(JSON.parse(details), null, 2);
+				} catch {}
+				const kind = historyData.errorKind ? ` [${historyData.errorKind}]` : "";
+				response = `// Время начала выполнения запроса: ${startTimeStr}\n// Время выполнения запроса: ${historyData.executionTime}ms\n// Код ответа: ${historyData.statusCode} (ERROR)${kind}\n\n// ${historyData.statusMessage}\n${details}`;
+			}
+
+			if ((historyData.extracted || historyData.scriptLogs || historyData.assertions) && response) {
+				try {
+					const lines = [
+						...formatExtracted(historyData.extracted ? (JSON.parse(historyData.extracted) as ExtractValue[]) : []),
+						...formatScriptLogs(historyData.scriptLogs ? (JSON.parse(historyData.scriptLogs) as LogEntry[]) : []),
+						...formatAssertions(
+							historyData.assertions ? (JSON.parse(historyData.assertions) as AssertionResult[]) : [],
+						),
+					];
+					const split = response.indexOf("\n\n");
+					if (lines.length > 0 && split >= 0) {
+						response = `${response.slice(0, split)}\n${lines.join("\n")}${response.slice(split)}`;
+					}
+				} catch (err) {
+					console.error("Failed to parse extracted values, script logs and assertions:", err);
+				}
 			}
 
 			responseTime = historyData.executionTime || 0;
@@ -279,6 +390,7 @@ const createTabsStore = () => {
 				requestBody,
 				metadata,
 				contextValues,
+				callOptions,
 				response,
 				responseTime,
 			},
@@ -343,6 +455,102 @@ const createTabsStore = () => {
 		}, 1000) as unknown as number;
 	});
 
+	// Схема открытых во вкладках серверов обновляется на бэкенде в фоне
+	let openServersKey = "";
+	createEffect(() => {
+		const ids = [...new Set(tabs.map(tab => (tab.data as SendRequestData).serverId))].sort((a, b) => a - b);
+		const key = ids.join(",");
+		if (key === openServersKey) return;
+
+		openServersKey = key;
+		SetOpenServers(ids).catch(err => console.error("Failed to set open servers:", err));
+	});
+
+	Events.On("server:schema-changed", event => {
+		const change = event.data;
+		const changed = new Set([...(change.diff.changedMethods ?? []), ...(change.diff.removedMethods ?? [])]);
+
+		tabs.forEach(tab => {
+			const data = tab.data as SendRequestData;
+			if (data.serverId !== change.serverId) return;
+
+			const methodKey = `${data.serviceName}/${data.methodName}`;
+			if (!changed.has(methodKey)) return;
+
+			const removed = change.diff.removedMethods?.includes(methodKey);
+			updateTabData<TabType.REQUEST>(tab.id, {
+				schemaWarning: removed
+					? `Метод больше не доступен на сервере ${change.serverName}`
+					: `Схема метода изменилась на сервере ${change.serverName}, тело запроса может быть устаревшим`,
+			});
+		});
+	});
+
+	// Метаданные ответа показываем комментариями над телом, все значения ключа через запятую.
+	// Бинарные -bin значения выводятся отдельно через formatBinaryMetadata
+	const formatMetadata = (title: string, md?: { [_ in string]?: string[] | null } | null) =>
+		Object.entries(md || {})
+			.filter(([key]) => !key.endsWith("-bin"))
+			.map(([key, values]) => `// ${title} ${key}: ${(values || []).join(", ")}`);
+
+	const formatBinaryMetadata = (items?: BinaryMetadata[] | null) =>
+		(items || []).map(
+			item =>
+				`// ${item.trailer ? "Трейлер" : "Заголовок"} ${item.key}: ` +
+				(item.json ? `${item.type} ${item.json}` : `hex ${item.hex}, base64 ${item.base64}`),
+		);
+
+	// Результаты правил извлечения: удачные значения уже доступны как {{переменные}}
+	const formatExtracted = (values?: (ExtractValue | null)[] | null) =>
+		(values || []).map(value =>
+			value?.error ? `// Извлечение ${value.variable}: ошибка ${value.error}` : `// Извлечено ${value?.variable} = ${value?.value}`,
+		);
+
+	const formatScriptLogs = (logs?: (LogEntry | null)[] | null) =>
+		(logs || []).map(entry => `// [${entry?.phase}] ${entry?.level}: ${entry?.message}`);
+
+	// Схема не выводится целиком, только вид проверки
+	const formatAssertions = (results?: (AssertionResult | null)[] | null) =>
+		(results || []).map(r => {
+			const a = r?.assertion;
+			const expected = a?.kind === Kind.KindSchema ? "" : a?.expected;
+			const title = [a?.kind, a?.path, a?.operator, expected].filter(Boolean).join(" ");
+			return (
+				`// Проверка ${r?.passed ? "✓" : "✗"} ${title}` +
+				(r?.actual ? ` (получено ${r.actual})` : "") +
+				(!r?.passed && r?.message ? `: ${r.message}` : "")
+			);
+		});
+
+	// buildRequest собирает из вкладки то, что уходит на сервер и сохраняется в коллекцию
+	const buildRequest = (data: SendRequestData) => {
+		const metadataObj: { [key: string]: string } = {};
+		data.metadata.forEach(item => {
+			if (item.key.trim()) {
+				metadataObj[item.key] = item.value;
+			}
+		});
+
+		const contextObj: { [key: string]: string } = {};
+		data.contextValues.forEach(item => {
+			if (item.key.trim()) {
+				contextObj[item.key] = item.value;
+			}
+		});
+
+		const context: RequestContext = {
+			...data.callOptions,
+			credentials: data.callOptions?.credentials?.type ? data.callOptions.credentials : null,
+			metadata: Object.keys(contextObj).length > 0 ? contextObj : null,
+		};
+
+		return {
+			payload: stripJsonComments(data.requestBody),
+			headers: Object.keys(metadataObj).length > 0 ? metadataObj : null,
+			context,
+		};
+	};
+
 	const sendRequest = async (tabId: string, serverAddress: string) => {
 		const tab = tabs.find(t => t.id === tabId);
 		if (!tab || tab.type !== TabType.REQUEST) return;
@@ -355,44 +563,49 @@ const createTabsStore = () => {
 			const startTime = new Date();
 			const startTimeStr = startTime.toLocaleString("ru-RU");
 
-			const metadataObj: { [key: string]: string } = {};
-			data.metadata.forEach(item => {
-				if (item.key.trim()) {
-					metadataObj[item.key] = item.value;
-				}
-			});
-
-			const contextObj: { [key: string]: string } = {};
-			data.contextValues.forEach(item => {
-				if (item.key.trim()) {
-					contextObj[item.key] = item.value;
-				}
-			});
-
-			const cleanedRequestBody = stripJsonComments(data.requestBody);
-
-			const [responseData, time] = await DoGRPCRequest(
-				data.serverId,
-				serverAddress,
-				data.serviceName,
-				data.methodName,
-				cleanedRequestBody,
-				Object.keys(metadataObj).length > 0 ? metadataObj : null,
-				Object.keys(contextObj).length > 0 ? contextObj : null,
-			);
-
-			let formattedResponse = "";
-			try {
-				const parsed = JSON.parse(responseData);
-				const prettyJson = JSON.stringify(parsed, null, 2);
-				formattedResponse = `// Время начала выполнения запроса: ${startTimeStr}\n// Время выполнения запроса: ${time}ms\n// Код ответа: 0 (OK)\n\n${prettyJson}`;
-			} catch {
-				formattedResponse = `// Время начала выполнения запроса: ${startTimeStr}\n// Время выполнения запроса: ${time}ms\n// Код ответа: 0 (OK)\n\n${responseData}`;
+			const { payload, headers, context } = buildRequest(data);
+
+			// Запрос из коллекции отправляется вместе со своими проверками
+			const result = data.savedRequestId
+				? await DoSavedRequest(data.savedRequestId, serverAddress, payload, headers, context)
+				: await DoGRPCRequest(
+						data.serverId,
+						serverAddress,
+						data.serviceName,
+						data.methodName,
+						payload,
+						headers,
+						context,
+					);
+			if (!result) return;
+
+			const header = [
+				`// Время начала выполнения запроса: ${startTimeStr}`,
+				`// Время выполнения запроса: ${result.executionTime}ms`,
+				result.code === 0 ? "// Код ответа: 0 (OK)" : `// Код ответа: ${result.code} (ERROR)${result.errorKind ? ` [${result.errorKind}]` : ""}`,
+				...formatMetadata("Заголовок", result.headers),
+				...formatMetadata("Трейлер", result.trailers),
+				...formatBinaryMetadata(result.binaryMetadata),
+				...formatExtracted(result.extracted),
+				...formatScriptLogs(result.scriptLogs),
+				...formatAssertions(result.assertions),
+			].join("\n");
+
+			let body = result.response;
+			if (result.code !== 0) {
+				body = [
+					`// ${result.error}`,
+					result.statusDetails ? JSON.stringify(result.statusDetails, null, 2) : "",
+				].join("\n");
+			} else {
+				try {
+					body = JSON.stringify(JSON.parse(result.response), null, 2);
+				} catch {}
 			}
 
 			updateTabData<TabType.REQUEST>(tabId, {
-				response: formattedResponse,
-				responseTime: time,
+				response: `${header}\n\n${body}`,
+				responseTime: result.executionTime,
 			});
 
 			$history.refresh();
@@ -431,6 +644,8 @@ const createTabsStore = () => {
 		getTabData,
 		activateTab,
 		openRequestTab,
+		openSavedRequest,
+		buildRequest,
 		sendRequest,
 		loadTabs,
 		saveTabs,
@@ -445,6 +660,7 @@ const createTabsStore = () => {
 		removeContextRow,
 		updateContextKey,
 		updateContextValue,
+		updateCallOptions,
 	};
 };
 
@@ -0,0 +1,397 @@
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"grpc-gui/internal/extract"
+)
+
+// Kind - что проверяет утверждение.
+type Kind string
+
+const (
+	// KindStatus - код ответа, Expected - имя кода (OK, NOT_FOUND) или число
+	KindStatus Kind = "status"
+	// KindBody - значение по пути Path в JSON ответа
+	KindBody Kind = "body"
+	// KindHeader и KindTrailer - значение ключа метаданных Path, несколько значений через запятую
+	KindHeader  Kind = "header"
+	KindTrailer Kind = "trailer"
+	// KindDuration - время выполнения, Expected - длительность ("200ms") или число миллисекунд
+	KindDuration Kind = "duration"
+	// KindSchema - тело ответа соответствует JSON Schema из Expected
+	KindSchema Kind = "schema"
+)
+
+type Operator string
+
+const (
+	OpEquals      Operator = "eq"
+	OpNotEquals   Operator = "ne"
+	OpGreater     Operator = "gt"
+	OpGreaterOrEq Operator = "gte"
+	OpLess        Operator = "lt"
+	OpLessOrEq    Operator = "lte"
+	OpContains    Operator = "contains"
+	OpMatches     Operator = "matches"
+	OpExists      Operator = "exists"
+	OpNotExists   Operator = "notExists"
+)
+
+// Assertion - проверка ответа.
+type Assertion struct {
+	Kind     Kind     `json:"kind"`
+	Path     string   `json:"path,omitempty"`
+	Operator Operator `json:"operator,omitempty"`
+	Expected string   `json:"expected,omitempty"`
+}
+
+// Result - итог проверки. Actual - фактическое значение, если его удалось получить.
+type Result struct {
+	Assertion Assertion `json:"assertion"`
+	Passed    bool      `json:"passed"`
+	Actual    string    `json:"actual,omitempty"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// Response - то, что проверяется.
+type Response struct {
+	Code     codes.Code
+	Body     string
+	Headers  map[string][]string
+	Trailers map[string][]string
+	Duration time.Duration
+}
+
+func (a Assertion) String() string {
+	parts := []string{string(a.Kind)}
+	for _, part := range []string{a.Path, string(a.Operator), a.Expected} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if a.Kind == KindSchema {
+		parts = parts[:1]
+	}
+	return strings.Join(parts, " ")
+}
+
+// Validate проверяет утверждение без ответа: путь, оператор и ожидаемое значение.
+func (a Assertion) Validate() error {
+	switch a.Kind {
+	case KindStatus:
+		if a.Operator != OpEquals && a.Operator != OpNotEquals {
+			return fmt.Errorf("status supports only eq and ne, got %q", a.Operator)
+		}
+		_, err := parseCode(a.Expected)
+		return err
+
+	case KindBody, KindHeader, KindTrailer:
+		if a.Kind == KindBody {
+			if err := extract.ValidatePath(a.Path); err != nil {
+				return err
+			}
+		} else if strings.TrimSpace(a.Path) == "" {
+			return fmt.Errorf("metadata key is required")
+		}
+		return validateOperator(a.Operator, a.Expected)
+
+	case KindDuration:
+		switch a.Operator {
+		case OpLess, OpLessOrEq, OpGreater, OpGreaterOrEq:
+		default:
+			return fmt.Errorf("duration supports only lt, lte, gt and gte, got %q", a.Operator)
+		}
+		_, err := parseDuration(a.Expected)
+		return err
+
+	case KindSchema:
+		_, err := compileSchema(a.Expected)
+		return err
+	}
+	return fmt.Errorf("unknown assertion kind %q", a.Kind)
+}
+
+func validateOperator(op Operator, expected string) error {
+	switch op {
+	case OpEquals, OpNotEquals, OpContains, OpExists, OpNotExists:
+		return nil
+	case OpGreater, OpGreaterOrEq, OpLess, OpLessOrEq:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(expected), 64); err != nil {
+			return fmt.Errorf("%s expects a number, got %q", op, expected)
+		}
+		return nil
+	case OpMatches:
+		_, err := regexp.Compile(expected)
+		return err
+	}
+	return fmt.Errorf("unknown operator %q", op)
+}
+
+// Evaluate выполняет все проверки. Ошибка одной проверки не мешает остальным.
+func Evaluate(assertions []Assertion, resp Response) []Result {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	var body any
+	var bodyErr error
+	bodyParsed := false
+	parsedBody := func() (any, error) {
+		if !bodyParsed {
+			body, bodyErr = extract.ParseJSON(resp.Body)
+			bodyParsed = true
+		}
+		return body, bodyErr
+	}
+
+	results := make([]Result, 0, len(assertions))
+	for _, a := range assertions {
+		result := Result{Assertion: a}
+
+		switch a.Kind {
+		case KindStatus:
+			result.Actual = codeName(resp.Code)
+			expected, err := parseCode(a.Expected)
+			if err != nil {
+				result.Message = err.Error()
+				break
+			}
+			result.Passed = (resp.Code == expected) == (a.Operator == OpEquals)
+
+		case KindBody:
+			data, err := parsedBody()
+			if err != nil {
+				result.Message = err.Error()
+				break
+			}
+			value, err := extract.Query(data, a.Path)
+			result.evaluate(value, err == nil, err)
+
+		case KindHeader, KindTrailer:
+			md := resp.Headers
+			if a.Kind == KindTrailer {
+				md = resp.Trailers
+			}
+			values, ok := md[strings.ToLower(strings.TrimSpace(a.Path))]
+			var value any
+			if ok {
+				value = strings.Join(values, ",")
+			}
+			result.evaluate(value, ok, fmt.Errorf("metadata %q not found", a.Path))
+
+		case KindDuration:
+			result.Actual = resp.Duration.String()
+			limit, err := parseDuration(a.Expected)
+			if err != nil {
+				result.Message = err.Error()
+				break
+			}
+			result.Passed = compare(a.Operator, float64(resp.Duration), float64(limit))
+
+		case KindSchema:
+			if err := validateSchema(a.Expected, resp.Body); err != nil {
+				result.Message = err.Error()
+				break
+			}
+			result.Passed = true
+
+		default:
+			result.Message = fmt.Sprintf("unknown assertion kind %q", a.Kind)
+		}
+
+		if !result.Passed && result.Message == "" {
+			result.Message = fmt.Sprintf("expected %s", a)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// NotSent - результат для запроса, который не был отправлен: все проверки провалены с причиной.
+func NotSent(assertions []Assertion, reason string) []Result {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	results := make([]Result, len(assertions))
+	for i, a := range assertions {
+		results[i] = Result{Assertion: a, Message: "request was not sent: " + reason}
+	}
+	return results
+}
+
+// Passed - все ли проверки прошли.
+func Passed(results []Result) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate применяет оператор к найденному значению. found = false - значения нет.
+func (r *Result) evaluate(value any, found bool, lookupErr error) {
+	a := r.Assertion
+
+	switch a.Operator {
+	case OpExists:
+		r.Passed = found
+	case OpNotExists:
+		r.Passed = !found
+	}
+	if !found {
+		if a.Operator != OpNotExists {
+			r.Message = lookupErr.Error()
+		}
+		return
+	}
+
+	actual, err := extract.Stringify(value)
+	if err != nil {
+		r.Message = err.Error()
+		return
+	}
+	r.Actual = actual
+
+	switch a.Operator {
+	case OpExists, OpNotExists:
+	case OpEquals, OpNotEquals:
+		r.Passed = equal(value, actual, a.Expected) == (a.Operator == OpEquals)
+	case OpContains:
+		r.Passed = contains(value, actual, a.Expected)
+	case OpMatches:
+		re, err := regexp.Compile(a.Expected)
+		if err != nil {
+			r.Message = err.Error()
+			return
+		}
+		r.Passed = re.MatchString(actual)
+	case OpGreater, OpGreaterOrEq, OpLess, OpLessOrEq:
+		got, err := number(value)
+		if err != nil {
+			r.Message = err.Error()
+			return
+		}
+		limit, err := strconv.ParseFloat(strings.TrimSpace(a.Expected), 64)
+		if err != nil {
+			r.Message = fmt.Sprintf("%s expects a number, got %q", a.Operator, a.Expected)
+			return
+		}
+		r.Passed = compare(a.Operator, got, limit)
+	default:
+		r.Message = fmt.Sprintf("unknown operator %q", a.Operator)
+	}
+}
+
+// equal сравнивает текстом; ожидаемое значение можно записать JSON: "42", "\"text\"", "true", "[1,2]".
+func equal(value any, actual, expected string) bool {
+	if actual == expected {
+		return true
+	}
+
+	var want any
+	decoder := json.NewDecoder(strings.NewReader(expected))
+	decoder.UseNumber()
+	if err := decoder.Decode(&want); err != nil {
+		return false
+	}
+	wantText, err := extract.Stringify(want)
+	if err != nil {
+		return false
+	}
+	if wantText == actual {
+		return true
+	}
+
+	// 1.0 и 1 - одно и то же число
+	got, errGot := number(value)
+	w, errWant := number(want)
+	return errGot == nil && errWant == nil && got == w
+}
+
+// contains для списка ищет элемент, для остального - подстроку.
+func contains(value any, actual, expected string) bool {
+	if list, ok := value.([]any); ok {
+		for _, item := range list {
+			text, err := extract.Stringify(item)
+			if err == nil && equal(item, text, expected) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(actual, expected)
+}
+
+func number(value any) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
+	case float64:
+		return v, nil
+	case string:
+		// int64 в protojson приходит строкой
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, nil
+		}
+	case []any:
+		return float64(len(v)), nil
+	}
+	return 0, fmt.Errorf("value is not a number")
+}
+
+func compare(op Operator, got, limit float64) bool {
+	switch op {
+	case OpGreater:
+		return got > limit
+	case OpGreaterOrEq:
+		return got >= limit
+	case OpLess:
+		return got < limit
+	case OpLessOrEq:
+		return got <= limit
+	}
+	return false
+}
+
+// parseDuration - длительность в формате time.ParseDuration, число без единиц - миллисекунды.
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if ms, err := strconv.Atoi(value); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
+// parseCode принимает число, имя кода в стиле Go (NotFound) или протокола (NOT_FOUND).
+func parseCode(value string) (codes.Code, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseUint(value, 10, 32); err == nil && n <= uint64(codes.Unauthenticated) {
+		return codes.Code(n), nil
+	}
+
+	normalized := strings.ToLower(strings.ReplaceAll(value, "_", ""))
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if strings.ToLower(code.String()) == normalized {
+			return code, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown status code %q", value)
+}
+
+func codeName(code codes.Code) string {
+	return fmt.Sprintf("%s (%d)", code.String(), code)
+}
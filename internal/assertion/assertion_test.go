@@ -0,0 +1,139 @@
+package assertion
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestEvaluate(t *testing.T) {
+	resp := Response{
+		Code:     codes.OK,
+		Body:     `{"count": 3, "id": "9007199254740993", "name": "Alice", "tags": ["a", "b"], "ratio": 1.0, "empty": null}`,
+		Headers:  map[string][]string{"x-version": {"1.2"}},
+		Trailers: map[string][]string{"x-retry": {"a", "b"}},
+		Duration: 150 * time.Millisecond,
+	}
+
+	tests := []struct {
+		assertion Assertion
+		passed    bool
+	}{
+		{Assertion{Kind: KindStatus, Operator: OpEquals, Expected: "OK"}, true},
+		{Assertion{Kind: KindStatus, Operator: OpEquals, Expected: "0"}, true},
+		{Assertion{Kind: KindStatus, Operator: OpNotEquals, Expected: "NOT_FOUND"}, true},
+		{Assertion{Kind: KindStatus, Operator: OpEquals, Expected: "NotFound"}, false},
+
+		{Assertion{Kind: KindBody, Path: "$.count", Operator: OpGreater, Expected: "0"}, true},
+		{Assertion{Kind: KindBody, Path: "$.count", Operator: OpEquals, Expected: "3"}, true},
+		{Assertion{Kind: KindBody, Path: "$.count", Operator: OpLessOrEq, Expected: "2"}, false},
+		{Assertion{Kind: KindBody, Path: "$.id", Operator: OpEquals, Expected: "9007199254740993"}, true},
+		{Assertion{Kind: KindBody, Path: "$.id", Operator: OpGreater, Expected: "1"}, true},
+		{Assertion{Kind: KindBody, Path: ".name", Operator: OpEquals, Expected: `"Alice"`}, true},
+		{Assertion{Kind: KindBody, Path: ".name", Operator: OpMatches, Expected: "^A"}, true},
+		{Assertion{Kind: KindBody, Path: "$.tags", Operator: OpContains, Expected: "b"}, true},
+		{Assertion{Kind: KindBody, Path: "$.tags", Operator: OpEquals, Expected: `["a","b"]`}, true},
+		{Assertion{Kind: KindBody, Path: "$.tags", Operator: OpGreaterOrEq, Expected: "2"}, true},
+		{Assertion{Kind: KindBody, Path: "$.ratio", Operator: OpEquals, Expected: "1"}, true},
+		{Assertion{Kind: KindBody, Path: "$.empty", Operator: OpExists}, true},
+		{Assertion{Kind: KindBody, Path: "$.missing", Operator: OpNotExists}, true},
+		{Assertion{Kind: KindBody, Path: "$.missing", Operator: OpEquals, Expected: "x"}, false},
+
+		{Assertion{Kind: KindHeader, Path: "X-Version", Operator: OpExists}, true},
+		{Assertion{Kind: KindHeader, Path: "x-version", Operator: OpEquals, Expected: "1.2"}, true},
+		{Assertion{Kind: KindHeader, Path: "x-missing", Operator: OpExists}, false},
+		{Assertion{Kind: KindTrailer, Path: "x-retry", Operator: OpEquals, Expected: "a,b"}, true},
+
+		{Assertion{Kind: KindDuration, Operator: OpLess, Expected: "200ms"}, true},
+		{Assertion{Kind: KindDuration, Operator: OpLess, Expected: "100"}, false},
+
+		{Assertion{Kind: KindSchema, Expected: `{"type": "object", "required": ["count"], "properties": {"count": {"type": "integer"}}}`}, true},
+		{Assertion{Kind: KindSchema, Expected: `{"type": "object", "properties": {"name": {"type": "integer"}}}`}, false},
+	}
+
+	assertions := make([]Assertion, len(tests))
+	for i, tt := range tests {
+		assertions[i] = tt.assertion
+	}
+	results := Evaluate(assertions, resp)
+
+	for i, tt := range tests {
+		result := results[i]
+		if result.Passed != tt.passed {
+			t.Errorf("%s: expected passed=%v, got %+v", tt.assertion, tt.passed, result)
+		}
+		if !result.Passed && result.Message == "" {
+			t.Errorf("%s: expected failure message", tt.assertion)
+		}
+	}
+
+	if results[0].Actual != "OK (0)" || results[4].Actual != "3" {
+		t.Errorf("unexpected actual values: %q %q", results[0].Actual, results[4].Actual)
+	}
+	if Passed(results) {
+		t.Error("expected some assertions to fail")
+	}
+}
+
+func TestEvaluate_InvalidBody(t *testing.T) {
+	results := Evaluate([]Assertion{
+		{Kind: KindBody, Path: "$.a", Operator: OpExists},
+		{Kind: KindSchema, Expected: `{"type": "object"}`},
+		{Kind: KindStatus, Operator: OpEquals, Expected: "Unavailable"},
+	}, Response{Code: codes.Unavailable, Body: ""})
+
+	if results[0].Passed || !strings.Contains(results[0].Message, "empty") {
+		t.Errorf("expected body error, got %+v", results[0])
+	}
+	if results[1].Passed || !strings.Contains(results[1].Message, "not JSON") {
+		t.Errorf("expected schema error, got %+v", results[1])
+	}
+	if !results[2].Passed {
+		t.Errorf("expected status to match, got %+v", results[2])
+	}
+}
+
+func TestNotSent(t *testing.T) {
+	results := NotSent([]Assertion{{Kind: KindStatus, Operator: OpEquals, Expected: "OK"}}, "unresolved variables: token")
+	if len(results) != 1 || results[0].Passed || !strings.Contains(results[0].Message, "token") {
+		t.Errorf("unexpected results: %+v", results)
+	}
+	if Passed(results) {
+		t.Error("expected not sent request to fail assertions")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []Assertion{
+		{Kind: KindStatus, Operator: OpEquals, Expected: "DEADLINE_EXCEEDED"},
+		{Kind: KindBody, Path: "$.items[0].id", Operator: OpExists},
+		{Kind: KindHeader, Path: "x-version", Operator: OpMatches, Expected: `^\d+`},
+		{Kind: KindDuration, Operator: OpLessOrEq, Expected: "1s"},
+		{Kind: KindSchema, Expected: `{"type": "object"}`},
+	}
+	for _, a := range valid {
+		if err := a.Validate(); err != nil {
+			t.Errorf("%s: unexpected error %v", a, err)
+		}
+	}
+
+	invalid := []Assertion{
+		{Kind: KindStatus, Operator: OpGreater, Expected: "OK"},
+		{Kind: KindStatus, Operator: OpEquals, Expected: "NOPE"},
+		{Kind: KindBody, Path: "$..id", Operator: OpExists},
+		{Kind: KindBody, Path: "$.count", Operator: OpGreater, Expected: "many"},
+		{Kind: KindBody, Path: "$.name", Operator: OpMatches, Expected: "("},
+		{Kind: KindHeader, Operator: OpExists},
+		{Kind: KindDuration, Operator: OpEquals, Expected: "1s"},
+		{Kind: KindDuration, Operator: OpLess, Expected: "soon"},
+		{Kind: KindSchema, Expected: `{"type": 5}`},
+		{Kind: "cookie"},
+	}
+	for _, a := range invalid {
+		if err := a.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", a)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package assertion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const schemaURL = "assertion.json"
+
+func compileSchema(schema string) (*jsonschema.Schema, error) {
+	if strings.TrimSpace(schema) == "" {
+		return nil, fmt.Errorf("JSON schema is required")
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	return compiled, nil
+}
+
+// validateSchema проверяет тело ответа по схеме. Схема без $schema считается draft 2020-12.
+func validateSchema(schema, body string) error {
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("response body is not JSON: %w", err)
+	}
+	return compiled.Validate(instance)
+}
@@ -9,6 +9,22 @@ const (
 
 	MaxHistorySize = 500
 
+	SearchDefaultLimit = 50
+
 	ReflectionCacheTTL           = 10 * time.Minute
 	ReflectionCacheRefreshEvery  = 20
+	ReflectionTimeout            = 5 * time.Second
+	ReflectionSchemaTimeout      = 2 * time.Minute
+	ReflectionRefreshWorkers     = 4
+	ReflectionBackgroundInterval = 2 * time.Minute
+
+	HealthCheckTimeout       = 3 * time.Second
+	HealthCheckInterval      = 30 * time.Second
+	HealthWatchRetryDelay    = 10 * time.Second
+	MaxHealthChecksPerServer = 100
+
+	EventServerReflection = "server:reflection"
+	EventSchemaChanged    = "server:schema-changed"
+	EventServerHealth     = "server:health"
+	EventCollectionRun    = "collection:run"
 )
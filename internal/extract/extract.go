@@ -0,0 +1,176 @@
+package extract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Source string
+
+const (
+	// SourceBody - выражение пути по JSON ответа
+	SourceBody Source = "body"
+	// SourceHeader и SourceTrailer - имя ключа метаданных ответа
+	SourceHeader  Source = "header"
+	SourceTrailer Source = "trailer"
+)
+
+// Rule - правило, которое после ответа записывает значение в переменную.
+type Rule struct {
+	Variable   string `json:"variable"`
+	Source     Source `json:"source"`
+	Expression string `json:"expression"`
+}
+
+// Value - результат правила. При ошибке Value пустое, переменная не меняется.
+type Value struct {
+	Variable string `json:"variable"`
+	Value    string `json:"value,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Response - то, из чего извлекаются значения.
+type Response struct {
+	Body     string
+	Headers  map[string][]string
+	Trailers map[string][]string
+}
+
+// Validate проверяет источник и синтаксис выражения, не выполняя его.
+func (r Rule) Validate() error {
+	switch r.Source {
+	case SourceBody:
+		_, err := parsePath(r.Expression)
+		return err
+	case SourceHeader, SourceTrailer:
+		if strings.TrimSpace(r.Expression) == "" {
+			return fmt.Errorf("metadata key is required")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown source %q", r.Source)
+	}
+}
+
+// Apply выполняет правила по порядку. Ошибка одного правила не мешает остальным.
+func Apply(rules []Rule, resp Response) []Value {
+	var body any
+	var bodyErr error
+	bodyParsed := false
+
+	values := make([]Value, 0, len(rules))
+	for _, rule := range rules {
+		value := Value{Variable: rule.Variable}
+
+		var result string
+		var err error
+		switch rule.Source {
+		case SourceBody:
+			if !bodyParsed {
+				body, bodyErr = ParseJSON(resp.Body)
+				bodyParsed = true
+			}
+			if bodyErr != nil {
+				err = bodyErr
+				break
+			}
+			result, err = Body(body, rule.Expression)
+		case SourceHeader:
+			result, err = metadataValue(resp.Headers, rule.Expression)
+		case SourceTrailer:
+			result, err = metadataValue(resp.Trailers, rule.Expression)
+		default:
+			err = fmt.Errorf("unknown source %q", rule.Source)
+		}
+
+		if err != nil {
+			value.Error = err.Error()
+		} else {
+			value.Value = result
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// Body вычисляет путь по разобранному JSON. Строки возвращаются как есть, остальное - компактным JSON.
+func Body(data any, expr string) (string, error) {
+	value, err := Query(data, expr)
+	if err != nil {
+		return "", err
+	}
+	return Stringify(value)
+}
+
+// Query вычисляет путь по разобранному JSON и возвращает значение как есть.
+func Query(data any, expr string) (any, error) {
+	steps, err := parsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return evalPath(data, steps)
+}
+
+// ValidatePath проверяет синтаксис пути без вычисления.
+func ValidatePath(expr string) error {
+	_, err := parsePath(expr)
+	return err
+}
+
+// ParseJSON разбирает тело ответа, числа остаются json.Number.
+func ParseJSON(text string) (any, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("response body is empty")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(text))
+	// Числа оставляем текстом, чтобы не терять точность больших int64
+	decoder.UseNumber()
+
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("response body is not JSON: %w", err)
+	}
+	return data, nil
+}
+
+// Stringify печатает значение: строки как есть, числа текстом, остальное компактным JSON.
+func Stringify(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		return "", err
+	}
+	return compact.String(), nil
+}
+
+// metadataValue - значение ключа метаданных, несколько значений склеиваются через запятую.
+func metadataValue(md map[string][]string, key string) (string, error) {
+	values, ok := md[strings.ToLower(strings.TrimSpace(key))]
+	if !ok || len(values) == 0 {
+		return "", fmt.Errorf("metadata %q not found", key)
+	}
+	return strings.Join(values, ","), nil
+}
+
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,111 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBody = `{
+	"session": {"id": "s-1", "ttl": 3600, "active": true},
+	"users": [{"id": "9007199254740993", "name": "Alice"}, {"id": "2", "name": "Bob"}],
+	"meta data": {"next page": null}
+}`
+
+func TestApply_Body(t *testing.T) {
+	cases := map[string]string{
+		"$.session.id":                "s-1",
+		".session.id":                 "s-1",
+		"$.session.ttl":               "3600",
+		"$.session.active":            "true",
+		"$.session":                   `{"active":true,"id":"s-1","ttl":3600}`,
+		"$.users[0].id":               "9007199254740993",
+		".users[-1].name":             "Bob",
+		"$.users[*].name":             `["Alice","Bob"]`,
+		".users[].id":                 `["9007199254740993","2"]`,
+		"$['meta data']":              `{"next page":null}`,
+		`$["meta data"]['next page']`: "null",
+		".":                           `{"meta data":{"next page":null},"session":{"active":true,"id":"s-1","ttl":3600},"users":[{"id":"9007199254740993","name":"Alice"},{"id":"2","name":"Bob"}]}`,
+	}
+
+	for expr, want := range cases {
+		values := Apply([]Rule{{Variable: "v", Source: SourceBody, Expression: expr}}, Response{Body: testBody})
+		if values[0].Error != "" || values[0].Value != want {
+			t.Errorf("%s: expected %s, got %q (%s)", expr, want, values[0].Value, values[0].Error)
+		}
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	cases := map[string]string{
+		"$.session.missing": `field "missing" not found`,
+		"$.users[5]":        "index 5 out of range",
+		"$.session.id.x":    "cannot take field",
+		"$.session[0]":      "cannot index object",
+		"session.id":        "must start with $ or .",
+		"$..id":             "recursive descent",
+		"$.users[x]":        "invalid index",
+	}
+	for expr, want := range cases {
+		values := Apply([]Rule{{Variable: "v", Source: SourceBody, Expression: expr}}, Response{Body: testBody})
+		if !strings.Contains(values[0].Error, want) {
+			t.Errorf("%s: expected error %q, got %+v", expr, want, values[0])
+		}
+	}
+
+	values := Apply([]Rule{{Variable: "v", Source: SourceBody, Expression: "$.a"}}, Response{Body: ""})
+	if !strings.Contains(values[0].Error, "empty") {
+		t.Errorf("expected empty body error, got %+v", values[0])
+	}
+}
+
+func TestApply_Metadata(t *testing.T) {
+	resp := Response{
+		Body:     `{"ok": true}`,
+		Headers:  map[string][]string{"x-session": {"abc"}, "x-multi": {"1", "2"}},
+		Trailers: map[string][]string{"x-cost": {"42"}},
+	}
+	rules := []Rule{
+		{Variable: "session", Source: SourceHeader, Expression: "X-Session"},
+		{Variable: "multi", Source: SourceHeader, Expression: "x-multi"},
+		{Variable: "cost", Source: SourceTrailer, Expression: "x-cost"},
+		{Variable: "missing", Source: SourceTrailer, Expression: "x-session"},
+		{Variable: "ok", Source: SourceBody, Expression: "$.ok"},
+	}
+
+	values := Apply(rules, resp)
+	want := []Value{
+		{Variable: "session", Value: "abc"},
+		{Variable: "multi", Value: "1,2"},
+		{Variable: "cost", Value: "42"},
+		{Variable: "missing", Error: `metadata "x-session" not found`},
+		{Variable: "ok", Value: "true"},
+	}
+	for i := range want {
+		if values[i] != want[i] {
+			t.Errorf("rule %d: expected %+v, got %+v", i, want[i], values[i])
+		}
+	}
+}
+
+func TestRule_Validate(t *testing.T) {
+	valid := []Rule{
+		{Source: SourceBody, Expression: "$.a[0]"},
+		{Source: SourceHeader, Expression: "x-id"},
+	}
+	for _, rule := range valid {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("%+v: unexpected error %v", rule, err)
+		}
+	}
+
+	invalid := []Rule{
+		{Source: SourceBody, Expression: "a"},
+		{Source: SourceTrailer, Expression: " "},
+		{Source: "cookie", Expression: "x"},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("%+v: expected error", rule)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type stepKind int
+
+const (
+	stepField stepKind = iota
+	stepIndex
+	stepWildcard
+)
+
+type step struct {
+	kind  stepKind
+	field string
+	index int
+}
+
+// parsePath разбирает выражение пути: JSONPath ($.items[0].id, $['a b'], $.items[*].id)
+// или такой же путь в стиле jq (.items[0].id, .items[].id).
+func parsePath(expr string) ([]step, error) {
+	expr = strings.TrimSpace(expr)
+	rest := expr
+	switch {
+	case strings.HasPrefix(rest, "$"):
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "."):
+		if rest == "." {
+			return nil, nil
+		}
+	default:
+		return nil, fmt.Errorf("path %q must start with $ or .", expr)
+	}
+
+	var steps []step
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("recursive descent is not supported in %q", expr)
+
+		case rest[0] == '.':
+			rest = rest[1:]
+			if strings.HasPrefix(rest, "*") {
+				steps = append(steps, step{kind: stepWildcard})
+				rest = rest[1:]
+				continue
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				// В jq ".[0]" - индекс без имени поля
+				if strings.HasPrefix(rest, "[") {
+					continue
+				}
+				return nil, fmt.Errorf("empty field name in %q", expr)
+			}
+			steps = append(steps, step{kind: stepField, field: rest[:end]})
+			rest = rest[end:]
+
+		case rest[0] == '[':
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated [ in %q", expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+
+			switch {
+			case inner == "*" || inner == "":
+				steps = append(steps, step{kind: stepWildcard})
+			case inner[0] == '\'' || inner[0] == '"':
+				field, err := unquote(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid field %s in %q", inner, expr)
+				}
+				steps = append(steps, step{kind: stepField, field: field})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %s in %q", inner, expr)
+				}
+				steps = append(steps, step{kind: stepIndex, index: index})
+			}
+
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest[:1], expr)
+		}
+	}
+
+	return steps, nil
+}
+
+func closingBracket(s string) int {
+	quote := byte(0)
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '\'' || s[i] == '"'):
+			quote = s[i]
+		case quote == 0 && s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[len(s)-1] != s[0] {
+		return "", fmt.Errorf("unterminated string")
+	}
+	if s[0] == '\'' {
+		s = `"` + strings.ReplaceAll(strings.ReplaceAll(s[1:len(s)-1], `\'`, `'`), `"`, `\"`) + `"`
+	}
+	return strconv.Unquote(s)
+}
+
+// evalPath применяет путь к разобранному JSON. Если в пути есть [*], результат - список совпадений.
+func evalPath(data any, steps []step) (any, error) {
+	values := []any{data}
+	multi := false
+
+	for _, st := range steps {
+		var next []any
+		for _, value := range values {
+			switch st.kind {
+			case stepField:
+				obj, ok := value.(map[string]any)
+				if !ok {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("cannot take field %q of %s", st.field, typeName(value))
+				}
+				field, ok := obj[st.field]
+				if !ok {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("field %q not found", st.field)
+				}
+				next = append(next, field)
+
+			case stepIndex:
+				list, ok := value.([]any)
+				if !ok {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("cannot index %s", typeName(value))
+				}
+				index := st.index
+				if index < 0 {
+					index += len(list)
+				}
+				if index < 0 || index >= len(list) {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("index %d out of range, length %d", st.index, len(list))
+				}
+				next = append(next, list[index])
+
+			case stepWildcard:
+				multi = true
+				switch v := value.(type) {
+				case []any:
+					next = append(next, v...)
+				case map[string]any:
+					for _, key := range sortedKeys(v) {
+						next = append(next, v[key])
+					}
+				}
+			}
+		}
+		values = next
+	}
+
+	if multi {
+		if values == nil {
+			values = []any{}
+		}
+		return values, nil
+	}
+	return values[0], nil
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
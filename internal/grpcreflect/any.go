@@ -0,0 +1,156 @@
+package grpcreflect
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const AnyTypeURLPrefix = "type.googleapis.com/"
+
+// TypeResolver находит типы сообщений и расширений для protojson:
+// сначала среди слинкованных в бинарник, затем через рефлексию сервера.
+type TypeResolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+type reflectionTypeResolver struct {
+	client *grpcreflect.Client
+}
+
+// TypeResolver возвращает резолвер, который разворачивает google.protobuf.Any
+// в сообщения по описанию с сервера, в том числе не импортируемые файлом метода.
+func (r *Reflector) TypeResolver() TypeResolver {
+	return &reflectionTypeResolver{client: r.client}
+}
+
+func (r *reflectionTypeResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	// Well-known типы берем сгенерированными, protojson знает их JSON форму
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(name); err == nil {
+		return mt, nil
+	}
+
+	md, err := r.client.ResolveMessage(string(name))
+	if err != nil {
+		return nil, protoregistry.NotFound
+	}
+
+	return dynamicpb.NewMessageType(md.UnwrapMessage()), nil
+}
+
+func (r *reflectionTypeResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	name := url
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return r.FindMessageByName(protoreflect.FullName(name))
+}
+
+func (r *reflectionTypeResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if xt, err := protoregistry.GlobalTypes.FindExtensionByName(field); err == nil {
+		return xt, nil
+	}
+
+	fd, err := r.client.FileContainingSymbol(string(field))
+	if err != nil {
+		return nil, protoregistry.NotFound
+	}
+
+	ext, ok := fd.FindSymbol(string(field)).(*desc.FieldDescriptor)
+	if !ok || !ext.IsExtension() {
+		return nil, protoregistry.NotFound
+	}
+
+	return dynamicpb.NewExtensionType(ext.UnwrapField()), nil
+}
+
+func (r *reflectionTypeResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	if xt, err := protoregistry.GlobalTypes.FindExtensionByNumber(message, field); err == nil {
+		return xt, nil
+	}
+
+	ext, err := r.client.ResolveExtension(string(message), int32(field))
+	if err != nil {
+		return nil, protoregistry.NotFound
+	}
+
+	return dynamicpb.NewExtensionType(ext.UnwrapField()), nil
+}
+
+// DescribeMessage возвращает описание произвольного сообщения сервера по полному имени.
+func (r *Reflector) DescribeMessage(name string) (*MessageInfo, error) {
+	md, err := r.client.ResolveMessage(strings.TrimPrefix(name, AnyTypeURLPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve message %s: %w", name, err)
+	}
+
+	return extractMessageInfo(md.UnwrapMessage()), nil
+}
+
+// collectMessageTypes собирает все сообщения из файлов и их зависимостей.
+// Используется как список подсказок при выборе типа для Any.
+func collectMessageTypes(files []*desc.FileDescriptor) []string {
+	seenFiles := make(map[string]bool)
+	seenTypes := make(map[string]bool)
+
+	var walkMessages func(msgs []*desc.MessageDescriptor)
+	walkMessages = func(msgs []*desc.MessageDescriptor) {
+		for _, md := range msgs {
+			if md.IsMapEntry() {
+				continue
+			}
+			seenTypes[md.GetFullyQualifiedName()] = true
+			walkMessages(md.GetNestedMessageTypes())
+		}
+	}
+
+	var walkFile func(fd *desc.FileDescriptor)
+	walkFile = func(fd *desc.FileDescriptor) {
+		if fd == nil || seenFiles[fd.GetName()] {
+			return
+		}
+		seenFiles[fd.GetName()] = true
+		walkMessages(fd.GetMessageTypes())
+		for _, dep := range fd.GetDependencies() {
+			walkFile(dep)
+		}
+	}
+
+	for _, fd := range files {
+		walkFile(fd)
+	}
+
+	types := make([]string, 0, len(seenTypes))
+	for name := range seenTypes {
+		types = append(types, name)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// GenerateAnyExample генерирует JSON значения google.protobuf.Any с заданным типом.
+// Для well-known типов значение кладется в поле "value", как того требует protojson.
+func GenerateAnyExample(msg *MessageInfo) ([]byte, error) {
+	if msg == nil {
+		return []byte(`{"@type": ""}`), nil
+	}
+
+	result := map[string]interface{}{}
+	if isWellKnown, wellKnownType := isWellKnownType(msg.Name); isWellKnown {
+		result["value"] = getDefaultValueForField(FieldInfo{IsWellKnown: true, WellKnownType: wellKnownType})
+	} else {
+		result = GenerateJSONValue(msg, make(map[string]bool))
+	}
+	result["@type"] = AnyTypeURLPrefix + msg.Name
+
+	return json.MarshalIndent(result, "", "  ")
+}
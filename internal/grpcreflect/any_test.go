@@ -0,0 +1,125 @@
+package grpcreflect
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"grpc-gui/internal/utils"
+)
+
+func TestDescribeMessage(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	// AuditRecord не импортируется файлом сервисов, но доступен через рефлексию
+	msg, err := reflector.DescribeMessage("type.googleapis.com/testserver.AuditRecord")
+	if err != nil {
+		t.Fatalf("DescribeMessage failed: %v", err)
+	}
+	if msg.Name != "testserver.AuditRecord" || len(msg.Fields) != 3 {
+		t.Errorf("unexpected message info: %+v", msg)
+	}
+
+	if _, err := reflector.DescribeMessage("testserver.Missing"); err == nil {
+		t.Error("expected error for unknown message")
+	}
+}
+
+func TestTypeResolver(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	resolver := reflector.TypeResolver()
+
+	mt, err := resolver.FindMessageByURL("type.googleapis.com/google.protobuf.Duration")
+	if err != nil {
+		t.Fatalf("FindMessageByURL failed: %v", err)
+	}
+	if _, ok := mt.New().Interface().(*durationpb.Duration); !ok {
+		t.Errorf("expected generated well-known type, got %T", mt.New().Interface())
+	}
+
+	if _, err := resolver.FindMessageByURL("type.googleapis.com/testserver.Missing"); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+func TestGetAllServicesInfo_MessageTypes(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+
+	types := make(map[string]bool)
+	for _, name := range servicesInfo.MessageTypes {
+		types[name] = true
+	}
+
+	for _, expected := range []string{"testserver.User", "testserver.Event", "google.protobuf.Any", "google.protobuf.Timestamp"} {
+		if !types[expected] {
+			t.Errorf("expected %s in message types", expected)
+		}
+	}
+}
+
+func TestGenerateAnyExample(t *testing.T) {
+	msg := &MessageInfo{
+		Name: "testserver.AuditRecord",
+		Fields: []FieldInfo{
+			{Name: "actor", Type: "string"},
+			{Name: "at", Type: "message", IsWellKnown: true, WellKnownType: "timestamp"},
+		},
+	}
+
+	data, err := GenerateAnyExample(msg)
+	if err != nil {
+		t.Fatalf("GenerateAnyExample failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	if result["@type"] != "type.googleapis.com/testserver.AuditRecord" {
+		t.Errorf("unexpected @type: %v", result["@type"])
+	}
+	if _, ok := result["actor"]; !ok {
+		t.Errorf("expected message fields inline, got %s", data)
+	}
+
+	data, err = GenerateAnyExample(&MessageInfo{Name: "google.protobuf.Duration"})
+	if err != nil {
+		t.Fatalf("GenerateAnyExample failed: %v", err)
+	}
+	result = nil
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	if result["value"] != "1.5s" {
+		t.Errorf("expected well-known value under 'value', got %s", data)
+	}
+}
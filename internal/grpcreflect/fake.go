@@ -0,0 +1,489 @@
+package grpcreflect
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fakeRepeatedMin = 2
+	fakeRepeatedMax = 3
+	fakeMaxDepth    = 4
+)
+
+var (
+	fakeBaseTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	fakeFirstNames = []string{"Ivan", "Maria", "Alex", "Olga", "John", "Emma", "Dmitry", "Anna", "Peter", "Sofia"}
+	fakeLastNames  = []string{"Petrov", "Smith", "Ivanova", "Johnson", "Sokolov", "Brown", "Volkova", "Miller"}
+	fakeCities     = []string{"Moscow", "Berlin", "London", "Paris", "Amsterdam", "Tbilisi", "Belgrade", "Almaty"}
+	fakeCountries  = []string{"RU", "DE", "GB", "FR", "NL", "GE", "RS", "KZ"}
+	fakeStreets    = []string{"Lenina st.", "Baker Street", "Main St.", "Nevsky pr.", "Park Ave.", "Oak Lane"}
+	fakeCompanies  = []string{"Acme Corp", "Globex", "Initech", "Umbrella", "Hooli", "Stark Industries"}
+	fakeDomains    = []string{"example.com", "mail.test", "corp.local", "example.org"}
+	fakeWords      = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor"}
+	fakeTags       = []string{"new", "vip", "beta", "internal", "archived", "priority", "trial"}
+)
+
+type fakeGenerator struct {
+	rnd *rand.Rand
+}
+
+// GenerateFakeJSONExample генерирует правдоподобный пример запроса.
+// Значения подбираются по имени и типу поля с учетом правил валидации,
+// при одинаковом seed результат всегда одинаковый.
+func GenerateFakeJSONExample(msg *MessageInfo, seed int64) ([]byte, error) {
+	if msg == nil {
+		return []byte("{}"), nil
+	}
+
+	g := &fakeGenerator{rnd: rand.New(rand.NewSource(seed))}
+	data := g.message(msg, make(map[string]bool), 0)
+	return json.MarshalIndent(data, "", "  ")
+}
+
+func (g *fakeGenerator) message(msg *MessageInfo, visited map[string]bool, depth int) map[string]interface{} {
+	result := make(map[string]interface{})
+	if msg == nil || visited[msg.Name] || depth > fakeMaxDepth {
+		return result
+	}
+	visited[msg.Name] = true
+	defer delete(visited, msg.Name)
+
+	// Для каждой oneof группы выбираем случайный вариант
+	selection := make(OneofSelection)
+	for _, field := range msg.Fields {
+		key := oneofKey(msg, field.OneofGroup)
+		if field.OneofGroup == "" || selection[key] != "" {
+			continue
+		}
+		variants := oneofVariants(msg, field.OneofGroup)
+		selection[key] = variants[g.rnd.Intn(len(variants))]
+	}
+
+	for _, field := range msg.Fields {
+		if !isOneofVariantSelected(msg, field, selection) {
+			continue
+		}
+		result[field.Name] = g.field(field, visited, depth)
+	}
+
+	return result
+}
+
+func (g *fakeGenerator) field(field FieldInfo, visited map[string]bool, depth int) interface{} {
+	if field.IsMap {
+		count := g.count(field.Validation)
+		result := make(map[string]interface{}, count)
+		for i := 0; i < count; i++ {
+			key := fmt.Sprint(g.mapKey(field, i))
+			if isWellKnown, wellKnownType := isWellKnownType(field.MapValue); isWellKnown {
+				result[key] = g.value(FieldInfo{IsWellKnown: true, WellKnownType: wellKnownType}, field.MapValue, field.Name)
+			} else if field.Message != nil {
+				result[key] = g.message(field.Message, visited, depth+1)
+			} else {
+				result[key] = g.value(field, field.MapValue, field.Name)
+			}
+		}
+		return result
+	}
+
+	if field.Repeated {
+		count := g.count(field.Validation)
+		result := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			result = append(result, g.single(field, visited, depth))
+		}
+		return result
+	}
+
+	return g.single(field, visited, depth)
+}
+
+func (g *fakeGenerator) single(field FieldInfo, visited map[string]bool, depth int) interface{} {
+	if field.Message != nil && !field.IsWellKnown {
+		return g.message(field.Message, visited, depth+1)
+	}
+	return g.value(field, field.Type, field.Name)
+}
+
+func (g *fakeGenerator) count(v *FieldValidation) int {
+	min, max := fakeRepeatedMin, fakeRepeatedMax
+	if v != nil {
+		if v.MinItems != nil && int(*v.MinItems) > min {
+			min = int(*v.MinItems)
+		}
+		if v.MaxItems != nil && int(*v.MaxItems) < max {
+			max = int(*v.MaxItems)
+		}
+	}
+	if max < min {
+		max = min
+	}
+	return min + g.rnd.Intn(max-min+1)
+}
+
+func (g *fakeGenerator) mapKey(field FieldInfo, index int) interface{} {
+	switch field.MapKey {
+	case "string":
+		return fmt.Sprintf("%s_%d", fakeWords[g.rnd.Intn(len(fakeWords))], index+1)
+	case "bool":
+		return index%2 == 0
+	default:
+		return index + 1 + g.rnd.Intn(100)*10
+	}
+}
+
+func (g *fakeGenerator) value(field FieldInfo, typeName, name string) interface{} {
+	if field.IsWellKnown {
+		if scalar, ok := WrapperScalarType(field.WellKnownType); ok {
+			field.IsWellKnown = false
+			return g.value(field, scalar, name)
+		}
+		if field.WellKnownType == fieldMaskType {
+			return g.fieldMask(field.FieldMaskPaths)
+		}
+		return g.wellKnown(field.WellKnownType)
+	}
+
+	if len(field.EnumValues) > 0 {
+		return g.enum(field)
+	}
+
+	v := field.Validation
+	if v != nil && len(v.In) > 0 {
+		choice := v.In[g.rnd.Intn(len(v.In))]
+		if typeName == "string" {
+			return choice
+		}
+		if n, err := strconv.ParseFloat(choice, 64); err == nil {
+			return n
+		}
+	}
+
+	switch typeName {
+	case "string":
+		return g.str(strings.ToLower(name), v)
+	case "bytes":
+		buf := make([]byte, 8+g.rnd.Intn(8))
+		g.rnd.Read(buf)
+		return base64.StdEncoding.EncodeToString(buf)
+	case "bool":
+		return g.rnd.Intn(2) == 1
+	case "float", "double":
+		return g.float(strings.ToLower(name), v)
+	case "int32", "sint32", "sfixed32", "uint32", "fixed32",
+		"int64", "sint64", "sfixed64", "uint64", "fixed64":
+		return g.integer(strings.ToLower(name), v, typeName)
+	default:
+		return g.str(strings.ToLower(name), v)
+	}
+}
+
+func (g *fakeGenerator) wellKnown(wellKnownType string) interface{} {
+	switch wellKnownType {
+	case "timestamp":
+		offset := time.Duration(g.rnd.Intn(365*24)) * time.Hour
+		offset += time.Duration(g.rnd.Intn(3600)) * time.Second
+		return fakeBaseTime.Add(offset).Format(time.RFC3339)
+	case "duration":
+		return fmt.Sprintf("%ds", 1+g.rnd.Intn(3600))
+	case "empty", "struct":
+		return map[string]interface{}{}
+	case "value":
+		return fakeWords[g.rnd.Intn(len(fakeWords))]
+	case "list_value":
+		return []interface{}{fakeWords[g.rnd.Intn(len(fakeWords))], fakeWords[g.rnd.Intn(len(fakeWords))]}
+	case "any":
+		return map[string]interface{}{"@type": ""}
+	default:
+		return nil
+	}
+}
+
+// fieldMask выбирает несколько путей верхнего уровня из полей сообщения.
+func (g *fakeGenerator) fieldMask(paths []string) string {
+	paths = topLevelPaths(paths)
+	if len(paths) == 0 {
+		return ""
+	}
+
+	var picked []string
+	for _, i := range g.rnd.Perm(len(paths))[:1+g.rnd.Intn(len(paths))] {
+		picked = append(picked, paths[i])
+	}
+	return strings.Join(picked, ",")
+}
+
+// Нулевое значение enum обычно UNSPECIFIED/UNKNOWN, поэтому предпочитаем остальные.
+func (g *fakeGenerator) enum(field FieldInfo) interface{} {
+	candidates := field.EnumValues
+	if field.Validation != nil && len(field.Validation.In) > 0 {
+		var allowed []EnumValueInfo
+		for _, ev := range field.EnumValues {
+			for _, in := range field.Validation.In {
+				if in == strconv.Itoa(int(ev.Number)) {
+					allowed = append(allowed, ev)
+				}
+			}
+		}
+		if len(allowed) > 0 {
+			candidates = allowed
+		}
+	} else if len(field.EnumValues) > 1 {
+		candidates = field.EnumValues[1:]
+	}
+
+	return candidates[g.rnd.Intn(len(candidates))].Name
+}
+
+func (g *fakeGenerator) str(name string, v *FieldValidation) string {
+	format := ""
+	if v != nil {
+		format = v.Format
+	}
+
+	var s string
+	switch {
+	case format == "email" || hasWord(name, "email", "emails"):
+		s = g.email()
+	case format == "uuid" || name == "uuid" || strings.HasSuffix(name, "_uuid") || name == "id" || strings.HasSuffix(name, "_id"):
+		s = g.uuid()
+	case format == "uri" || hasWord(name, "url", "urls", "uri", "website"):
+		s = "https://" + fakeDomains[g.rnd.Intn(len(fakeDomains))] + "/" + fakeWords[g.rnd.Intn(len(fakeWords))]
+	case format == "hostname" || hasWord(name, "host", "hostname", "hosts", "domain"):
+		s = fakeWords[g.rnd.Intn(len(fakeWords))] + "." + fakeDomains[g.rnd.Intn(len(fakeDomains))]
+	case format == "ipv4" || hasWord(name, "ip", "ip_address"):
+		s = fmt.Sprintf("10.%d.%d.%d", g.rnd.Intn(256), g.rnd.Intn(256), 1+g.rnd.Intn(254))
+	case format == "ipv6":
+		s = fmt.Sprintf("2001:db8::%x:%x", g.rnd.Intn(0xffff), 1+g.rnd.Intn(0xfffe))
+	case hasWord(name, "first_name", "firstname"):
+		s = fakeFirstNames[g.rnd.Intn(len(fakeFirstNames))]
+	case hasWord(name, "last_name", "lastname", "surname"):
+		s = fakeLastNames[g.rnd.Intn(len(fakeLastNames))]
+	case hasWord(name, "username", "login"):
+		s = strings.ToLower(fakeFirstNames[g.rnd.Intn(len(fakeFirstNames))]) + strconv.Itoa(10+g.rnd.Intn(90))
+	case hasWord(name, "company", "organization"):
+		s = fakeCompanies[g.rnd.Intn(len(fakeCompanies))]
+	case name == "name" || strings.HasSuffix(name, "_name") || hasWord(name, "author"):
+		s = fakeFirstNames[g.rnd.Intn(len(fakeFirstNames))] + " " + fakeLastNames[g.rnd.Intn(len(fakeLastNames))]
+	case hasWord(name, "phone", "phones"):
+		s = fmt.Sprintf("+7%03d%07d", 900+g.rnd.Intn(100), g.rnd.Intn(10000000))
+	case hasWord(name, "city"):
+		s = fakeCities[g.rnd.Intn(len(fakeCities))]
+	case hasWord(name, "country"):
+		s = fakeCountries[g.rnd.Intn(len(fakeCountries))]
+	case hasWord(name, "street", "address"):
+		s = fmt.Sprintf("%s %d", fakeStreets[g.rnd.Intn(len(fakeStreets))], 1+g.rnd.Intn(200))
+	case hasWord(name, "zip", "zipcode", "postal", "postcode"):
+		s = fmt.Sprintf("%05d", 10000+g.rnd.Intn(90000))
+	case hasWord(name, "tag", "tags"):
+		s = fakeTags[g.rnd.Intn(len(fakeTags))]
+	case hasWord(name, "token", "secret", "key"):
+		s = g.hex(32)
+	case hasWord(name, "title"):
+		s = g.words(2 + g.rnd.Intn(2))
+		s = strings.ToUpper(s[:1]) + s[1:]
+	case hasWord(name, "description", "message", "text", "comment", "comments", "data"):
+		s = g.words(4 + g.rnd.Intn(5))
+	default:
+		s = g.words(1 + g.rnd.Intn(2))
+	}
+
+	return g.fitString(s, v)
+}
+
+func (g *fakeGenerator) fitString(s string, v *FieldValidation) string {
+	if v == nil {
+		return s
+	}
+
+	if v.Pattern != "" {
+		if re, err := regexp.Compile(v.Pattern); err == nil && !re.MatchString(s) {
+			for _, candidate := range []string{g.uuid(), g.hex(16), g.words(1), strconv.Itoa(g.rnd.Intn(100000)), g.email()} {
+				if re.MatchString(candidate) {
+					s = candidate
+					break
+				}
+			}
+		}
+	}
+
+	if v.Prefix != "" && !strings.HasPrefix(s, v.Prefix) {
+		s = v.Prefix + s
+	}
+	if v.Suffix != "" && !strings.HasSuffix(s, v.Suffix) {
+		s = s + v.Suffix
+	}
+
+	if v.MinLen != nil {
+		for uint64(len([]rune(s))) < *v.MinLen {
+			s += string(rune('a' + g.rnd.Intn(26)))
+		}
+	}
+	if v.MaxLen != nil && uint64(len([]rune(s))) > *v.MaxLen {
+		s = string([]rune(s)[:*v.MaxLen])
+	}
+
+	return s
+}
+
+func (g *fakeGenerator) integer(name string, v *FieldValidation, typeName string) int64 {
+	min, max := int64(1), int64(1000)
+	switch {
+	case hasWord(name, "age"):
+		min, max = 18, 80
+	case hasWord(name, "zip", "zipcode", "postal"):
+		min, max = 10000, 99999
+	case name == "id" || strings.HasSuffix(name, "_id"):
+		min, max = 1, 100000
+	case hasWord(name, "count", "quantity", "size"):
+		min, max = 1, 100
+	case hasWord(name, "year"):
+		min, max = 1990, 2026
+	case hasWord(name, "port"):
+		min, max = 1024, 65535
+	case strings.HasSuffix(name, "_at") || hasWord(name, "timestamp"):
+		min, max = fakeBaseTime.Unix(), fakeBaseTime.Unix()+365*24*3600
+	}
+
+	if strings.HasPrefix(typeName, "sint") || strings.HasPrefix(typeName, "sfixed") {
+		if hasWord(name, "signed", "delta", "offset") {
+			min = -max
+		}
+	}
+
+	if v != nil {
+		if v.Min != nil {
+			min = clampInt64(math.Ceil(*v.Min))
+			if v.ExclusiveMin && float64(min) == *v.Min && min < math.MaxInt64 {
+				min++
+			}
+			if max < min {
+				max = math.MaxInt64
+				if min <= math.MaxInt64-100 {
+					max = min + 100
+				}
+			}
+		}
+		if v.Max != nil {
+			max = clampInt64(math.Floor(*v.Max))
+			if v.ExclusiveMax && float64(max) == *v.Max && max > math.MinInt64 {
+				max--
+			}
+			if min > max {
+				min = max
+			}
+		}
+	}
+
+	// Ширина считается в uint64: для правил во весь диапазон int64 max-min+1 переполняется
+	span := uint64(max) - uint64(min)
+	if span >= math.MaxInt64 {
+		for {
+			if offset := g.rnd.Uint64(); offset <= span {
+				return min + int64(offset)
+			}
+		}
+	}
+	return min + g.rnd.Int63n(int64(span)+1)
+}
+
+// clampInt64 переводит границу правила в int64, насыщая значения за пределами диапазона.
+func clampInt64(f float64) int64 {
+	switch {
+	case math.IsNaN(f):
+		return 0
+	case f >= math.MaxInt64:
+		return math.MaxInt64
+	case f <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(f)
+}
+
+func (g *fakeGenerator) float(name string, v *FieldValidation) float64 {
+	min, max := 0.0, 1000.0
+	switch {
+	case hasWord(name, "score", "rating"):
+		min, max = 0, 5
+	case hasWord(name, "lat", "latitude"):
+		min, max = -90, 90
+	case hasWord(name, "lon", "lng", "longitude"):
+		min, max = -180, 180
+	case hasWord(name, "percent", "percentage", "ratio"):
+		min, max = 0, 100
+	}
+
+	if v != nil {
+		if v.Min != nil {
+			min = *v.Min
+			if max < min {
+				max = min + 100
+			}
+		}
+		if v.Max != nil {
+			max = *v.Max
+			if min > max {
+				min = max
+			}
+		}
+	}
+
+	value := min + g.rnd.Float64()*(max-min)
+	value = math.Round(value*100) / 100
+	if v != nil && v.ExclusiveMin && v.Min != nil && value <= *v.Min {
+		value = *v.Min + (max-*v.Min)/2
+	}
+	if v != nil && v.ExclusiveMax && v.Max != nil && value >= *v.Max {
+		value = min + (*v.Max-min)/2
+	}
+
+	return value
+}
+
+// hasWord проверяет слова и сочетания слов snake_case имени целиком: "age" не должно совпадать с page_size.
+func hasWord(name string, words ...string) bool {
+	name = "_" + name + "_"
+	for _, word := range words {
+		if strings.Contains(name, "_"+word+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *fakeGenerator) email() string {
+	first := strings.ToLower(fakeFirstNames[g.rnd.Intn(len(fakeFirstNames))])
+	last := strings.ToLower(fakeLastNames[g.rnd.Intn(len(fakeLastNames))])
+	return first + "." + last + "@" + fakeDomains[g.rnd.Intn(len(fakeDomains))]
+}
+
+func (g *fakeGenerator) uuid() string {
+	b := make([]byte, 16)
+	g.rnd.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func (g *fakeGenerator) hex(n int) string {
+	b := make([]byte, (n+1)/2)
+	g.rnd.Read(b)
+	return fmt.Sprintf("%x", b)[:n]
+}
+
+func (g *fakeGenerator) words(n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fakeWords[g.rnd.Intn(len(fakeWords))]
+	}
+	return strings.Join(words, " ")
+}
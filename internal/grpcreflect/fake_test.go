@@ -0,0 +1,363 @@
+package grpcreflect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"grpc-gui/internal/utils"
+	"grpc-gui/testserver/proto"
+)
+
+func fakeUserMessage() *MessageInfo {
+	minLen, maxLen := uint64(3), uint64(5)
+	min, max := 18.0, 30.0
+	minItems := uint64(4)
+
+	return &MessageInfo{
+		Name: "testserver.User",
+		Fields: []FieldInfo{
+			{Name: "id", Type: "int64"},
+			{Name: "name", Type: "string"},
+			{Name: "email", Type: "string"},
+			{Name: "zip_code", Type: "int32"},
+			{Name: "code", Type: "string", Validation: &FieldValidation{MinLen: &minLen, MaxLen: &maxLen}},
+			{Name: "age", Type: "int32", Validation: &FieldValidation{Min: &min, Max: &max}},
+			{Name: "role", Type: "string", Validation: &FieldValidation{In: []string{"admin", "editor"}}},
+			{Name: "contact", Type: "string", Validation: &FieldValidation{Format: "email"}},
+			{Name: "tags", Type: "string", Repeated: true, Validation: &FieldValidation{MinItems: &minItems}},
+			{Name: "created_at", Type: "google.protobuf.Timestamp", IsWellKnown: true, WellKnownType: "timestamp"},
+			{
+				Name:       "status",
+				Type:       "testserver.Status",
+				IsEnum:     true,
+				EnumValues: []EnumValueInfo{{Name: "UNKNOWN", Number: 0}, {Name: "ACTIVE", Number: 1}, {Name: "DELETED", Number: 2}},
+			},
+		},
+	}
+}
+
+func TestGenerateFakeJSONExample_Deterministic(t *testing.T) {
+	msg := fakeUserMessage()
+
+	first, err := GenerateFakeJSONExample(msg, 42)
+	if err != nil {
+		t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+	}
+
+	second, err := GenerateFakeJSONExample(msg, 42)
+	if err != nil {
+		t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("expected identical output for the same seed:\n%s\n%s", first, second)
+	}
+
+	other, err := GenerateFakeJSONExample(msg, 43)
+	if err != nil {
+		t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+	}
+
+	if bytes.Equal(first, other) {
+		t.Error("expected different output for different seeds")
+	}
+}
+
+func TestGenerateFakeJSONExample_FieldNames(t *testing.T) {
+	jsonBytes, err := GenerateFakeJSONExample(fakeUserMessage(), 1)
+	if err != nil {
+		t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+	}
+
+	t.Logf("Generated JSON:\n%s", string(jsonBytes))
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	emailRe := regexp.MustCompile(`^[a-z.]+@[a-z.]+$`)
+	if email, _ := result["email"].(string); !emailRe.MatchString(email) {
+		t.Errorf("expected email-like value, got %v", result["email"])
+	}
+
+	if name, _ := result["name"].(string); !strings.Contains(name, " ") {
+		t.Errorf("expected full name, got %v", result["name"])
+	}
+
+	if id, _ := result["id"].(float64); id <= 0 {
+		t.Errorf("expected positive id, got %v", result["id"])
+	}
+
+	if zip, _ := result["zip_code"].(float64); zip < 10000 || zip > 99999 {
+		t.Errorf("expected 5-digit zip_code, got %v", result["zip_code"])
+	}
+
+	if status := result["status"]; status == "UNKNOWN" {
+		t.Error("expected non-zero enum value")
+	}
+
+	if _, ok := result["created_at"].(string); !ok {
+		t.Errorf("expected RFC3339 string for created_at, got %T", result["created_at"])
+	}
+}
+
+func TestGenerateFakeJSONExample_NameWords(t *testing.T) {
+	msg := &MessageInfo{
+		Name: "testserver.Page",
+		Fields: []FieldInfo{
+			{Name: "page_size", Type: "int32"},
+			{Name: "user_age", Type: "int32"},
+			{Name: "report_number", Type: "int32"},
+			{Name: "translation_score", Type: "double"},
+			{Name: "start_lat", Type: "double"},
+		},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		jsonBytes, err := GenerateFakeJSONExample(msg, seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var result map[string]float64
+		if err := json.Unmarshal(jsonBytes, &result); err != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", err)
+		}
+		if n := result["page_size"]; n < 1 || n > 100 {
+			t.Errorf("seed %d: page_size %v out of [1, 100]", seed, n)
+		}
+		if n := result["user_age"]; n < 18 || n > 80 {
+			t.Errorf("seed %d: user_age %v out of [18, 80]", seed, n)
+		}
+		if n := result["report_number"]; n > 1000 {
+			t.Errorf("seed %d: report_number %v looks like a port", seed, n)
+		}
+		if n := result["translation_score"]; n > 5 {
+			t.Errorf("seed %d: translation_score %v out of [0, 5]", seed, n)
+		}
+		if n := result["start_lat"]; n < -90 || n > 90 {
+			t.Errorf("seed %d: start_lat %v out of [-90, 90]", seed, n)
+		}
+	}
+}
+
+func TestGenerateFakeJSONExample_StringNameWords(t *testing.T) {
+	plain := []string{"capacity", "velocity", "stage", "percentage", "hotkey", "monkey", "ghost", "unzip_path", "metadata", "context"}
+	msg := &MessageInfo{
+		Name: "testserver.Profile",
+		Fields: []FieldInfo{
+			{Name: "home_city", Type: "string"},
+			{Name: "user_tags", Type: "string"},
+			{Name: "api_key", Type: "string"},
+			{Name: "client_ip", Type: "string"},
+		},
+	}
+	for _, name := range plain {
+		msg.Fields = append(msg.Fields, FieldInfo{Name: name, Type: "string"})
+	}
+
+	words := make(map[string]bool, len(fakeWords))
+	for _, word := range fakeWords {
+		words[word] = true
+	}
+	hexKey := regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	for seed := int64(0); seed < 20; seed++ {
+		jsonBytes, err := GenerateFakeJSONExample(msg, seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var result map[string]string
+		if err := json.Unmarshal(jsonBytes, &result); err != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", err)
+		}
+		if !containsString(fakeCities, result["home_city"]) {
+			t.Errorf("seed %d: home_city %q is not a city", seed, result["home_city"])
+		}
+		if !containsString(fakeTags, result["user_tags"]) {
+			t.Errorf("seed %d: user_tags %q is not a tag", seed, result["user_tags"])
+		}
+		if !hexKey.MatchString(result["api_key"]) {
+			t.Errorf("seed %d: api_key %q is not a token", seed, result["api_key"])
+		}
+		if !strings.HasPrefix(result["client_ip"], "10.") {
+			t.Errorf("seed %d: client_ip %q is not an ip", seed, result["client_ip"])
+		}
+
+		// Слово внутри другого слова не считается: такие поля получают обычный текст из одного-двух слов
+		for _, name := range plain {
+			parts := strings.Fields(result[name])
+			if len(parts) == 0 || len(parts) > 2 {
+				t.Errorf("seed %d: %s %q is not a short text", seed, name, result[name])
+				continue
+			}
+			for _, part := range parts {
+				if !words[part] {
+					t.Errorf("seed %d: %s %q is not a short text", seed, name, result[name])
+				}
+			}
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGenerateFakeJSONExample_Validation(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		jsonBytes, err := GenerateFakeJSONExample(fakeUserMessage(), seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var result map[string]interface{}
+		if err := json.Unmarshal(jsonBytes, &result); err != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", err)
+		}
+
+		code, _ := result["code"].(string)
+		if len(code) < 3 || len(code) > 5 {
+			t.Errorf("seed %d: code length %d out of [3, 5]: %q", seed, len(code), code)
+		}
+
+		age, _ := result["age"].(float64)
+		if age < 18 || age > 30 {
+			t.Errorf("seed %d: age %v out of [18, 30]", seed, age)
+		}
+
+		role := result["role"]
+		if role != "admin" && role != "editor" {
+			t.Errorf("seed %d: role %v not in allowed values", seed, role)
+		}
+
+		if contact, _ := result["contact"].(string); !strings.Contains(contact, "@") {
+			t.Errorf("seed %d: expected email for contact, got %q", seed, contact)
+		}
+
+		if tags, _ := result["tags"].([]interface{}); len(tags) < 4 {
+			t.Errorf("seed %d: expected at least 4 tags, got %d", seed, len(tags))
+		}
+	}
+}
+
+func TestGenerateFakeJSONExample_NilMessage(t *testing.T) {
+	jsonBytes, err := GenerateFakeJSONExample(nil, 1)
+	if err != nil {
+		t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+	}
+
+	if string(jsonBytes) != "{}" {
+		t.Errorf("expected '{}', got %s", string(jsonBytes))
+	}
+}
+
+func TestGenerateFakeJSONExample_Integration(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+
+	var userMsg, scheduleMsg *MessageInfo
+	for _, service := range servicesInfo.Services {
+		for _, method := range service.Methods {
+			switch method.Name {
+			case "GetUser":
+				userMsg = method.Response
+			case "ScheduleTask":
+				scheduleMsg = method.Request
+			}
+		}
+	}
+
+	if userMsg == nil || scheduleMsg == nil {
+		t.Fatal("User or ScheduleRequest message not found")
+	}
+
+	for seed := int64(0); seed < 10; seed++ {
+		userJSON, err := GenerateFakeJSONExample(userMsg, seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var user proto.User
+		if err := protojson.Unmarshal(userJSON, &user); err != nil {
+			t.Fatalf("seed %d: generated User is not valid protojson: %v\n%s", seed, err, userJSON)
+		}
+		if user.Age < 18 || user.Age > 120 {
+			t.Errorf("seed %d: age %d violates validation rules", seed, user.Age)
+		}
+		if len(user.Tags) < 1 || len(user.Tags) > 5 {
+			t.Errorf("seed %d: tags count %d violates validation rules", seed, len(user.Tags))
+		}
+		if !strings.Contains(user.Email, "@") {
+			t.Errorf("seed %d: expected email, got %q", seed, user.Email)
+		}
+
+		scheduleJSON, err := GenerateFakeJSONExample(scheduleMsg, seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var schedule proto.ScheduleRequest
+		if err := protojson.Unmarshal(scheduleJSON, &schedule); err != nil {
+			t.Fatalf("seed %d: generated ScheduleRequest is not valid protojson: %v\n%s", seed, err, scheduleJSON)
+		}
+	}
+}
+
+func TestGenerateFakeJSONExample_IntegerBounds(t *testing.T) {
+	full := func(min, max float64) *FieldValidation { return &FieldValidation{Min: &min, Max: &max} }
+	msg := &MessageInfo{
+		Name: "testserver.Bounds",
+		Fields: []FieldInfo{
+			{Name: "int64_range", Type: "int64", Validation: full(math.MinInt64, math.MaxInt64)},
+			{Name: "uint64_range", Type: "uint64", Validation: full(0, math.MaxUint64)},
+			{Name: "near_max", Type: "int64", Validation: full(math.MaxInt64-512, math.MaxUint64)},
+			{Name: "beyond", Type: "int64", Validation: full(-1e30, -1e20)},
+		},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		jsonBytes, err := GenerateFakeJSONExample(msg, seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var result map[string]json.Number
+		decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+		decoder.UseNumber()
+		if err := decoder.Decode(&result); err != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", err)
+		}
+		if n, err := result["uint64_range"].Int64(); err != nil || n < 0 {
+			t.Errorf("seed %d: uint64_range = %s", seed, result["uint64_range"])
+		}
+		if n, err := result["beyond"].Int64(); err != nil || n != math.MinInt64 {
+			t.Errorf("seed %d: beyond = %s", seed, result["beyond"])
+		}
+	}
+}
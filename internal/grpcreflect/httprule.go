@@ -0,0 +1,77 @@
+package grpcreflect
+
+import (
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+)
+
+type HTTPRule struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	Body         string `json:"body,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+}
+
+// Опции, полученные через рефлексию, могут содержать google.api.http
+// как неизвестное поле, поэтому перечитываем их с глобальным реестром типов.
+func extractHTTPRules(opts proto.Message) []HTTPRule {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	data, err := proto.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+
+	methodOpts := &descriptorpb.MethodOptions{}
+	err = proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}.Unmarshal(data, methodOpts)
+	if err != nil || !proto.HasExtension(methodOpts, annotations.E_Http) {
+		return nil
+	}
+
+	rule, ok := proto.GetExtension(methodOpts, annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return nil
+	}
+
+	var rules []HTTPRule
+	if r, ok := convertHTTPRule(rule); ok {
+		rules = append(rules, r)
+	}
+	for _, binding := range rule.GetAdditionalBindings() {
+		if r, ok := convertHTTPRule(binding); ok {
+			rules = append(rules, r)
+		}
+	}
+
+	return rules
+}
+
+func convertHTTPRule(rule *annotations.HttpRule) (HTTPRule, bool) {
+	result := HTTPRule{
+		Body:         rule.GetBody(),
+		ResponseBody: rule.GetResponseBody(),
+	}
+
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		result.Method, result.Path = "get", pattern.Get
+	case *annotations.HttpRule_Put:
+		result.Method, result.Path = "put", pattern.Put
+	case *annotations.HttpRule_Post:
+		result.Method, result.Path = "post", pattern.Post
+	case *annotations.HttpRule_Delete:
+		result.Method, result.Path = "delete", pattern.Delete
+	case *annotations.HttpRule_Patch:
+		result.Method, result.Path = "patch", pattern.Patch
+	case *annotations.HttpRule_Custom:
+		result.Method, result.Path = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return HTTPRule{}, false
+	}
+
+	return result, result.Path != ""
+}
@@ -0,0 +1,194 @@
+package grpcreflect
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jhump/protoreflect/desc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ListServicesInfo возвращает только имена сервисов: это один вызов ListServices без разрешения
+// дескрипторов, поэтому список открывается сразу даже для сотен сервисов. Методы сервиса
+// загружаются через GetServiceInfo, полная схема с хешами и дескрипторами - через ResolveSchema.
+func (r *Reflector) ListServicesInfo() (*ServicesInfo, error) {
+	serviceNames, err := r.client.ListServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	services := make([]ServiceInfo, 0, len(serviceNames))
+	for _, serviceName := range serviceNames {
+		services = append(services, ServiceInfo{Name: serviceName, Methods: []MethodInfo{}})
+	}
+
+	return &ServicesInfo{Services: services}, nil
+}
+
+// GetServiceInfo возвращает сигнатуры методов одного сервиса.
+func (r *Reflector) GetServiceInfo(serviceName string) (*ServiceInfo, error) {
+	service, _, err := r.resolveService(serviceName, newSchemaHasher())
+	return service, err
+}
+
+// ResolveSchema разрешает все сервисы: сигнатуры и хеши методов, типы сообщений и набор
+// дескрипторов для офлайн-режима. На больших API это сотни запросов, поэтому вызывается в фоне.
+// Если часть сервисов получить не удалось, список возвращается вместе с ошибкой, а дескрипторы нет:
+// неполный набор не должен заменять сохраненный.
+func (r *Reflector) ResolveSchema(serviceNames []string) (*ServicesInfo, []byte, error) {
+	var services []ServiceInfo
+	var files []*desc.FileDescriptor
+	var failed []error
+	hasher := newSchemaHasher()
+
+	for _, serviceName := range serviceNames {
+		service, file, err := r.resolveService(serviceName, hasher)
+		if err != nil {
+			failed = append(failed, err)
+			services = append(services, ServiceInfo{Name: serviceName, Methods: []MethodInfo{}})
+			continue
+		}
+		if file != nil {
+			files = append(files, file)
+		}
+		services = append(services, *service)
+	}
+
+	info := &ServicesInfo{Services: services, MessageTypes: collectMessageTypes(files), SchemaHash: servicesSchemaHash(services)}
+	if len(failed) > 0 {
+		return info, nil, fmt.Errorf("failed to resolve %d of %d services: %w", len(failed), len(serviceNames), failed[0])
+	}
+
+	descriptors, err := descriptorSet(files)
+	if err != nil {
+		return info, nil, err
+	}
+	return info, descriptors, nil
+}
+
+// resolveService строит сигнатуры методов сервиса с хешами схемы. Если полная рефлексия сервиса
+// не работает, методы берутся низкоуровневым запросом, и файла дескрипторов у сервиса нет.
+func (r *Reflector) resolveService(serviceName string, hasher *schemaHasher) (*ServiceInfo, *desc.FileDescriptor, error) {
+	service := &ServiceInfo{Name: serviceName, Methods: []MethodInfo{}}
+
+	serviceDesc, err := r.client.ResolveService(serviceName)
+	if err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		lowLevelMethods, lowLevelErr := r.getServiceMethodsLowLevel(ctx, serviceName)
+		if lowLevelErr != nil {
+			return nil, nil, fmt.Errorf("failed to resolve service %s: %w", serviceName, err)
+		}
+		service.Methods = lowLevelMethods
+		return service, nil, nil
+	}
+
+	methods := serviceDesc.UnwrapService().Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		service.Methods = append(service.Methods, MethodInfo{
+			Name:         string(method.Name()),
+			RequestType:  string(method.Input().FullName()),
+			ResponseType: string(method.Output().FullName()),
+			HTTPRules:    extractHTTPRules(method.Options()),
+			SchemaHash:   hasher.methodHash(method),
+		})
+	}
+
+	return service, serviceDesc.GetFile(), nil
+}
+
+// GetMethodInfo возвращает полное описание одного метода.
+func (r *Reflector) GetMethodInfo(serviceName, methodName string) (*MethodInfo, error) {
+	serviceDesc, err := r.client.ResolveService(serviceName)
+	if err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		lowLevelMethods, lowLevelErr := r.getServiceMethodsLowLevel(ctx, serviceName)
+		if lowLevelErr != nil {
+			return nil, fmt.Errorf("failed to resolve service %s: %w", serviceName, err)
+		}
+		for i := range lowLevelMethods {
+			if lowLevelMethods[i].Name == methodName {
+				return &lowLevelMethods[i], nil
+			}
+		}
+		return nil, fmt.Errorf("method %s not found in service %s", methodName, serviceName)
+	}
+
+	unwrapped := serviceDesc.UnwrapService()
+	method := unwrapped.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", methodName, serviceName)
+	}
+
+	info := buildMethodInfo(method, buildExtensionIndex(unwrapped.ParentFile()))
+	return &info, nil
+}
+
+type methodCacheKey struct {
+	serverID uint
+	service  string
+	method   string
+}
+
+type methodCacheEntry struct {
+	info     *MethodInfo
+	cachedAt time.Time
+}
+
+// MethodCache хранит детали методов, загруженные по требованию, отдельно от списка сервисов.
+type MethodCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[methodCacheKey]methodCacheEntry
+}
+
+func NewMethodCache(ttl time.Duration) *MethodCache {
+	return &MethodCache{
+		ttl:     ttl,
+		entries: make(map[methodCacheKey]methodCacheEntry),
+	}
+}
+
+func (c *MethodCache) Get(serverID uint, service, method string) (*MethodInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := methodCacheKey{serverID: serverID, service: service, method: method}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.cachedAt) > c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.info, true
+}
+
+func (c *MethodCache) Put(serverID uint, service, method string, info *MethodInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[methodCacheKey{serverID: serverID, service: service, method: method}] = methodCacheEntry{
+		info:     info,
+		cachedAt: time.Now(),
+	}
+}
+
+// Invalidate сбрасывает все закешированные методы сервера.
+func (c *MethodCache) Invalidate(serverID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.serverID == serverID {
+			delete(c.entries, key)
+		}
+	}
+}
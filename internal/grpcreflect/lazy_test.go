@@ -0,0 +1,174 @@
+package grpcreflect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"grpc-gui/internal/utils"
+)
+
+func TestListServicesInfo(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.ListServicesInfo()
+	if err != nil {
+		t.Fatalf("ListServicesInfo failed: %v", err)
+	}
+
+	var found bool
+	for _, service := range servicesInfo.Services {
+		found = found || service.Name == "testserver.TestService"
+		if len(service.Methods) != 0 {
+			t.Errorf("expected listing without methods, got %+v", service)
+		}
+	}
+	if !found {
+		t.Error("expected testserver.TestService in listing")
+	}
+	if len(servicesInfo.MessageTypes) != 0 || servicesInfo.SchemaHash != "" {
+		t.Error("expected listing without resolved schema")
+	}
+}
+
+func TestGetServiceInfo(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	service, err := reflector.GetServiceInfo("testserver.TestService")
+	if err != nil {
+		t.Fatalf("GetServiceInfo failed: %v", err)
+	}
+	method := findMethod(t, &ServicesInfo{Services: []ServiceInfo{*service}}, "testserver.TestService", "SimpleCall")
+	if method.RequestType != "testserver.SimpleRequest" || method.ResponseType != "testserver.SimpleResponse" || method.SchemaHash == "" {
+		t.Errorf("unexpected method signature: %+v", method)
+	}
+	if method.Request != nil || method.RequestExample != nil || method.RequestSchema != nil {
+		t.Error("expected signature without message details")
+	}
+
+	if _, err := reflector.GetServiceInfo("testserver.Missing"); err == nil {
+		t.Error("expected error for unknown service")
+	}
+}
+
+func TestResolveSchema(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, descriptors, err := reflector.ResolveSchema([]string{"testserver.TestService", "testserver.AnotherService"})
+	if err != nil {
+		t.Fatalf("ResolveSchema failed: %v", err)
+	}
+	if servicesInfo.SchemaHash == "" || len(servicesInfo.MessageTypes) == 0 {
+		t.Errorf("expected schema hash and message types, got %+v", servicesInfo)
+	}
+	if method := findMethod(t, servicesInfo, "testserver.AnotherService", "GetUser"); method.Request != nil {
+		t.Error("expected signatures without message details")
+	}
+
+	// Полное описание для документации строится из сохраненных дескрипторов без сервера
+	files, err := FilesFromDescriptorSet(descriptors)
+	if err != nil {
+		t.Fatalf("FilesFromDescriptorSet failed: %v", err)
+	}
+	full := ServicesInfoFromDescriptorSet(files, servicesInfo)
+	live, err := reflector.GetMethodInfo("testserver.TestService", "ComplexCall")
+	if err != nil {
+		t.Fatalf("GetMethodInfo failed: %v", err)
+	}
+	if method := findMethod(t, full, "testserver.TestService", "ComplexCall"); method.RequestExampleString != live.RequestExampleString {
+		t.Errorf("expected offline details to match live reflection:\n%s\n%s", method.RequestExampleString, live.RequestExampleString)
+	}
+
+	// Неполный набор дескрипторов не отдается
+	partial, descriptors, err := reflector.ResolveSchema([]string{"testserver.TestService", "testserver.Missing"})
+	if err == nil || descriptors != nil || len(partial.Services) != 2 {
+		t.Errorf("expected partial listing with error and no descriptors, got %v %d", err, len(descriptors))
+	}
+}
+
+func TestGetMethodInfo(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	info, err := reflector.GetMethodInfo("testserver.TestService", "ComplexCall")
+	if err != nil {
+		t.Fatalf("GetMethodInfo failed: %v", err)
+	}
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+	full := findMethod(t, servicesInfo, "testserver.TestService", "ComplexCall")
+
+	if info.Request == nil || len(info.Request.Fields) != len(full.Request.Fields) {
+		t.Errorf("expected same request as full reflection, got %+v", info.Request)
+	}
+	if info.RequestExampleString != full.RequestExampleString {
+		t.Errorf("expected same example as full reflection:\n%s\n%s", info.RequestExampleString, full.RequestExampleString)
+	}
+
+	if _, err := reflector.GetMethodInfo("testserver.TestService", "Missing"); err == nil {
+		t.Error("expected error for unknown method")
+	}
+	if _, err := reflector.GetMethodInfo("testserver.Missing", "SimpleCall"); err == nil {
+		t.Error("expected error for unknown service")
+	}
+}
+
+func TestMethodCache(t *testing.T) {
+	cache := NewMethodCache(time.Minute)
+
+	if _, ok := cache.Get(1, "svc", "A"); ok {
+		t.Fatal("expected empty cache")
+	}
+
+	cache.Put(1, "svc", "A", &MethodInfo{Name: "A"})
+	cache.Put(2, "svc", "A", &MethodInfo{Name: "A"})
+
+	if info, ok := cache.Get(1, "svc", "A"); !ok || info.Name != "A" {
+		t.Errorf("expected cached method, got %+v", info)
+	}
+
+	cache.Invalidate(1)
+	if _, ok := cache.Get(1, "svc", "A"); ok {
+		t.Error("expected invalidated entry")
+	}
+	if _, ok := cache.Get(2, "svc", "A"); !ok {
+		t.Error("expected other server entry to survive invalidation")
+	}
+
+	expired := NewMethodCache(0)
+	expired.Put(1, "svc", "A", &MethodInfo{Name: "A"})
+	time.Sleep(time.Millisecond)
+	if _, ok := expired.Get(1, "svc", "A"); ok {
+		t.Error("expected expired entry")
+	}
+}
@@ -0,0 +1,113 @@
+package grpcreflect
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jhump/protoreflect/desc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// descriptorSet сериализует файлы с зависимостями в детерминированном порядке.
+func descriptorSet(fileDescs []*desc.FileDescriptor) ([]byte, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	var addFile func(fd *desc.FileDescriptor)
+	addFile = func(fd *desc.FileDescriptor) {
+		if _, ok := files[fd.GetName()]; ok {
+			return
+		}
+		files[fd.GetName()] = fd.AsFileDescriptorProto()
+		for _, dep := range fd.GetDependencies() {
+			addFile(dep)
+		}
+	}
+	for _, fd := range fileDescs {
+		addFile(fd)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, name := range names {
+		set.File = append(set.File, files[name])
+	}
+
+	return proto.MarshalOptions{Deterministic: true}.Marshal(set)
+}
+
+// FilesFromDescriptorSet разбирает сохраненный набор дескрипторов.
+func FilesFromDescriptorSet(data []byte) (*protoregistry.Files, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no cached descriptors")
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached descriptors: %w", err)
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cached descriptors: %w", err)
+	}
+	return files, nil
+}
+
+// MethodInfoFromDescriptorSet строит описание метода по сохраненному набору дескрипторов.
+func MethodInfoFromDescriptorSet(data []byte, serviceName, methodName string) (*MethodInfo, error) {
+	files, err := FilesFromDescriptorSet(data)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found in cached descriptors", serviceName)
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", methodName, serviceName)
+	}
+
+	info := buildMethodInfo(method, buildExtensionIndex(service.ParentFile()))
+	return &info, nil
+}
+
+// ServicesInfoFromDescriptorSet дополняет сохраненный список сервисов полными описаниями методов
+// из набора дескрипторов. Сервисы без дескрипторов остаются с сигнатурами из списка.
+func ServicesInfoFromDescriptorSet(files *protoregistry.Files, listing *ServicesInfo) *ServicesInfo {
+	result := &ServicesInfo{MessageTypes: listing.MessageTypes, SchemaHash: listing.SchemaHash}
+
+	for _, listed := range listing.Services {
+		d, err := files.FindDescriptorByName(protoreflect.FullName(listed.Name))
+		service, ok := d.(protoreflect.ServiceDescriptor)
+		if err != nil || !ok {
+			result.Services = append(result.Services, listed)
+			continue
+		}
+
+		info := ServiceInfo{Name: listed.Name, Methods: []MethodInfo{}}
+		extensions := buildExtensionIndex(service.ParentFile())
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			info.Methods = append(info.Methods, buildMethodInfo(methods.Get(i), extensions))
+		}
+		result.Services = append(result.Services, info)
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package grpcreflect
+
+import (
+	"context"
+	"testing"
+
+	"grpc-gui/internal/utils"
+)
+
+func TestMethodInfoFromDescriptorSet(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	// Тот же набор дескрипторов, что сохраняется в кеш сервера после фоновой загрузки схемы
+	_, data, err := reflector.ResolveSchema([]string{"testserver.TestService", "testserver.LegacyService", "testserver.EditionsService"})
+	if err != nil {
+		t.Fatalf("ResolveSchema failed: %v", err)
+	}
+
+	cases := []struct{ service, method string }{
+		{"testserver.TestService", "ComplexCall"},
+		{"testserver.LegacyService", "Describe"},
+		{"testserver.EditionsService", "Echo"},
+	}
+	for _, c := range cases {
+		live, err := reflector.GetMethodInfo(c.service, c.method)
+		if err != nil {
+			t.Fatalf("GetMethodInfo failed: %v", err)
+		}
+
+		offline, err := MethodInfoFromDescriptorSet(data, c.service, c.method)
+		if err != nil {
+			t.Fatalf("%s/%s: MethodInfoFromDescriptorSet failed: %v", c.service, c.method, err)
+		}
+
+		if offline.RequestExampleString != live.RequestExampleString || offline.SchemaHash != live.SchemaHash {
+			t.Errorf("%s/%s: expected offline details to match live reflection:\n%s\n%s", c.service, c.method, offline.RequestExampleString, live.RequestExampleString)
+		}
+	}
+
+	if _, err := MethodInfoFromDescriptorSet(data, "testserver.Missing", "GetUser"); err == nil {
+		t.Error("expected error for unknown service")
+	}
+	if _, err := MethodInfoFromDescriptorSet(nil, "testserver.TestService", "SimpleCall"); err == nil {
+		t.Error("expected error without cached descriptors")
+	}
+}
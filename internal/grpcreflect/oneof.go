@@ -0,0 +1,46 @@
+package grpcreflect
+
+// OneofSelection задает выбранный вариант для oneof групп при генерации примеров.
+// Ключ - "<полное имя сообщения>.<имя группы>", значение - имя поля.
+type OneofSelection map[string]string
+
+func oneofKey(msg *MessageInfo, group string) string {
+	return msg.Name + "." + group
+}
+
+// oneofVariants возвращает имена полей группы в порядке объявления.
+func oneofVariants(msg *MessageInfo, group string) []string {
+	var variants []string
+	for _, field := range msg.Fields {
+		if field.OneofGroup == group {
+			variants = append(variants, field.Name)
+		}
+	}
+	return variants
+}
+
+// selectedOneofVariant возвращает поле, которое попадет в пример.
+// Неизвестный выбор игнорируется, по умолчанию берется первый вариант.
+func selectedOneofVariant(msg *MessageInfo, group string, selection OneofSelection) string {
+	variants := oneofVariants(msg, group)
+	if len(variants) == 0 {
+		return ""
+	}
+
+	if name, ok := selection[oneofKey(msg, group)]; ok {
+		for _, variant := range variants {
+			if variant == name {
+				return name
+			}
+		}
+	}
+
+	return variants[0]
+}
+
+func isOneofVariantSelected(msg *MessageInfo, field FieldInfo, selection OneofSelection) bool {
+	if field.OneofGroup == "" {
+		return true
+	}
+	return selectedOneofVariant(msg, field.OneofGroup, selection) == field.Name
+}
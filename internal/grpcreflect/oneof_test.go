@@ -0,0 +1,170 @@
+package grpcreflect
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func oneofTestMessage() *MessageInfo {
+	return &MessageInfo{
+		Name: "testserver.OneofRequest",
+		Fields: []FieldInfo{
+			{Name: "id", Type: "string", Number: 1},
+			{Name: "text", Type: "string", Number: 2, OneofGroup: "payload"},
+			{Name: "number", Type: "int32", Number: 3, OneofGroup: "payload"},
+			{
+				Name: "user", Type: "message", Number: 4, OneofGroup: "payload",
+				Message: &MessageInfo{
+					Name: "testserver.User",
+					Fields: []FieldInfo{
+						{Name: "name", Type: "string", Number: 1},
+						{Name: "age", Type: "int32", Number: 2},
+					},
+				},
+			},
+			{Name: "limit", Type: "int32", Number: 5, OneofGroup: "_limit"},
+		},
+	}
+}
+
+// stripLineComments повторяет то, что делает фронтенд перед отправкой запроса.
+func stripLineComments(s string) string {
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+func TestGenerateJSONExample_OneofSingleVariant(t *testing.T) {
+	jsonBytes, err := GenerateJSONExample(oneofTestMessage())
+	if err != nil {
+		t.Fatalf("GenerateJSONExample failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if _, ok := result["text"]; !ok {
+		t.Errorf("expected first oneof variant 'text' to be selected by default")
+	}
+	for _, name := range []string{"number", "user"} {
+		if _, ok := result[name]; ok {
+			t.Errorf("unexpected non-selected oneof variant %q in %s", name, jsonBytes)
+		}
+	}
+	if _, ok := result["limit"]; !ok {
+		t.Errorf("proto3 optional field 'limit' should always be present")
+	}
+}
+
+func TestGenerateJSONExample_OneofSelection(t *testing.T) {
+	selection := OneofSelection{"testserver.OneofRequest.payload": "user"}
+
+	jsonBytes, err := GenerateJSONExampleWithSelection(oneofTestMessage(), selection)
+	if err != nil {
+		t.Fatalf("GenerateJSONExampleWithSelection failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if _, ok := result["user"].(map[string]interface{}); !ok {
+		t.Errorf("expected selected variant 'user', got %s", jsonBytes)
+	}
+	if _, ok := result["text"]; ok {
+		t.Errorf("unexpected variant 'text' in %s", jsonBytes)
+	}
+
+	// Неизвестный вариант игнорируется
+	jsonBytes, err = GenerateJSONExampleWithSelection(oneofTestMessage(), OneofSelection{"testserver.OneofRequest.payload": "missing"})
+	if err != nil {
+		t.Fatalf("GenerateJSONExampleWithSelection failed: %v", err)
+	}
+	result = nil
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	if _, ok := result["text"]; !ok {
+		t.Errorf("expected fallback to the first variant, got %s", jsonBytes)
+	}
+}
+
+func TestGenerateJSONExampleWithComments_OneofValidAfterStrip(t *testing.T) {
+	selection := OneofSelection{"testserver.OneofRequest.payload": "number"}
+	result := GenerateJSONExampleWithCommentsSelection(oneofTestMessage(), selection)
+	t.Logf("Generated JSON:\n%s", result)
+
+	for _, expected := range []string{`// "text": ""`, `// "user": {`, `//   "name": ""`, `"number": 0`} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected to find %q in result", expected)
+		}
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(stripLineComments(result)), &parsed); err != nil {
+		t.Fatalf("payload without comments is not valid JSON: %v", err)
+	}
+
+	if len(parsed) != 3 {
+		t.Errorf("expected id, number and limit, got %v", parsed)
+	}
+	if _, ok := parsed["number"]; !ok {
+		t.Errorf("expected selected variant 'number', got %v", parsed)
+	}
+}
+
+func TestGenerateSchemaValue_OneofVariants(t *testing.T) {
+	schema := GenerateSchemaValue(oneofTestMessage(), make(map[string]bool))
+
+	number, ok := schema["number"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected schema for 'number'")
+	}
+
+	variants, _ := number["oneofVariants"].([]string)
+	if strings.Join(variants, ",") != "text,number,user" {
+		t.Errorf("unexpected oneofVariants: %v", number["oneofVariants"])
+	}
+	if number["oneofSelected"] != false {
+		t.Errorf("expected 'number' not to be selected by default")
+	}
+
+	text := schema["text"].(map[string]interface{})
+	if text["oneofSelected"] != true {
+		t.Errorf("expected 'text' to be selected by default")
+	}
+}
+
+func TestGenerateFakeJSONExample_OneofSingleVariant(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		data, err := GenerateFakeJSONExample(oneofTestMessage(), seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+
+		var result map[string]interface{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", err)
+		}
+
+		count := 0
+		for _, name := range []string{"text", "number", "user"} {
+			if _, ok := result[name]; ok {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("seed %d: expected exactly one oneof variant, got %s", seed, data)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package grpcreflect
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// extensionIndex - расширения, сгруппированные по полному имени расширяемого сообщения.
+type extensionIndex map[protoreflect.FullName][]protoreflect.ExtensionDescriptor
+
+// buildExtensionIndex собирает расширения из файла и всех его зависимостей.
+func buildExtensionIndex(fd protoreflect.FileDescriptor) extensionIndex {
+	index := make(extensionIndex)
+	seen := make(map[string]bool)
+
+	var addExtensions func(exts protoreflect.ExtensionDescriptors)
+	addExtensions = func(exts protoreflect.ExtensionDescriptors) {
+		for i := 0; i < exts.Len(); i++ {
+			ext := exts.Get(i)
+			extendee := ext.ContainingMessage().FullName()
+			// Расширения опций (google.api.http, validate.rules) в примерах не нужны
+			if strings.HasPrefix(string(extendee), "google.protobuf.") {
+				continue
+			}
+			index[extendee] = append(index[extendee], ext)
+		}
+	}
+
+	var walkMessages func(msgs protoreflect.MessageDescriptors)
+	walkMessages = func(msgs protoreflect.MessageDescriptors) {
+		for i := 0; i < msgs.Len(); i++ {
+			addExtensions(msgs.Get(i).Extensions())
+			walkMessages(msgs.Get(i).Messages())
+		}
+	}
+
+	var walkFile func(fd protoreflect.FileDescriptor)
+	walkFile = func(fd protoreflect.FileDescriptor) {
+		if fd == nil || seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+
+		addExtensions(fd.Extensions())
+		walkMessages(fd.Messages())
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			walkFile(imports.Get(i).FileDescriptor)
+		}
+	}
+
+	walkFile(fd)
+
+	return index
+}
+
+// defaultValueString возвращает явное значение по умолчанию (proto2 [default = ...])
+// в JSON-совместимом виде: имя для enum, base64 для bytes.
+func defaultValueString(field protoreflect.FieldDescriptor) string {
+	if !field.HasDefault() {
+		return ""
+	}
+
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		if value := field.DefaultEnumValue(); value != nil {
+			return string(value.Name())
+		}
+		return ""
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(field.Default().Bytes())
+	default:
+		return fmt.Sprint(field.Default().Interface())
+	}
+}
+
+// typedDefaultValue преобразует DefaultValue к типу поля для JSON примера.
+func typedDefaultValue(field FieldInfo) (interface{}, bool) {
+	if field.DefaultValue == "" {
+		return nil, false
+	}
+
+	switch field.Type {
+	case "bool":
+		v, err := strconv.ParseBool(field.DefaultValue)
+		return v, err == nil
+	case "int32", "sint32", "sfixed32", "uint32", "fixed32":
+		v, err := strconv.ParseInt(field.DefaultValue, 10, 64)
+		return v, err == nil
+	case "int64", "sint64", "sfixed64", "uint64", "fixed64":
+		// 64-битные целые в JSON передаются строками
+		return field.DefaultValue, true
+	case "float", "double":
+		v, err := strconv.ParseFloat(field.DefaultValue, 64)
+		return v, err == nil
+	default:
+		return field.DefaultValue, true
+	}
+}
+
+// lowLevelFieldPresence вычисляет наличие явного присутствия и обязательность поля
+// по сырому дескриптору, в том числе с учетом features из Editions.
+func lowLevelFieldPresence(field *descriptorpb.FieldDescriptorProto, fd *descriptorpb.FileDescriptorProto) (hasPresence, required bool) {
+	if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return false, false
+	}
+	// Одиночные сообщения присутствуют явно при любом синтаксисе
+	message := field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+		field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP
+
+	switch fd.GetSyntax() {
+	case "proto3":
+		return message || field.GetProto3Optional() || field.OneofIndex != nil, false
+	case "editions":
+		presence := descriptorpb.FeatureSet_EXPLICIT
+		if p := fd.GetOptions().GetFeatures().GetFieldPresence(); p != descriptorpb.FeatureSet_FIELD_PRESENCE_UNKNOWN {
+			presence = p
+		}
+		if p := field.GetOptions().GetFeatures().GetFieldPresence(); p != descriptorpb.FeatureSet_FIELD_PRESENCE_UNKNOWN {
+			presence = p
+		}
+		return message || presence != descriptorpb.FeatureSet_IMPLICIT, presence == descriptorpb.FeatureSet_LEGACY_REQUIRED
+	default:
+		return true, field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED
+	}
+}
+
+// lowLevelDelimited - group в proto2 или message_encoding = DELIMITED в Editions.
+func lowLevelDelimited(field *descriptorpb.FieldDescriptorProto, fd *descriptorpb.FileDescriptorProto) bool {
+	if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+		return true
+	}
+	if field.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		return false
+	}
+
+	encoding := fd.GetOptions().GetFeatures().GetMessageEncoding()
+	if e := field.GetOptions().GetFeatures().GetMessageEncoding(); e != descriptorpb.FeatureSet_MESSAGE_ENCODING_UNKNOWN {
+		encoding = e
+	}
+	return encoding == descriptorpb.FeatureSet_DELIMITED
+}
+
+type lowLevelExtension struct {
+	fullName string
+	field    *descriptorpb.FieldDescriptorProto
+	file     *descriptorpb.FileDescriptorProto
+}
+
+// lowLevelExtensions находит расширения сообщения среди сырых дескрипторов файлов.
+func lowLevelExtensions(msgFullName string, files map[string]*descriptorpb.FileDescriptorProto) []lowLevelExtension {
+	var result []lowLevelExtension
+
+	var collect func(scope string, exts []*descriptorpb.FieldDescriptorProto, msgs []*descriptorpb.DescriptorProto, fd *descriptorpb.FileDescriptorProto)
+	collect = func(scope string, exts []*descriptorpb.FieldDescriptorProto, msgs []*descriptorpb.DescriptorProto, fd *descriptorpb.FileDescriptorProto) {
+		for _, ext := range exts {
+			if strings.TrimPrefix(ext.GetExtendee(), ".") == msgFullName {
+				result = append(result, lowLevelExtension{fullName: scopedName(scope, ext.GetName()), field: ext, file: fd})
+			}
+		}
+		for _, msg := range msgs {
+			collect(scopedName(scope, msg.GetName()), msg.GetExtension(), msg.GetNestedType(), fd)
+		}
+	}
+
+	for _, fd := range files {
+		collect(fd.GetPackage(), fd.GetExtension(), fd.GetMessageType(), fd)
+	}
+
+	// Порядок обхода map не определен, а поля в примере должны идти стабильно
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].field.GetNumber() < result[j].field.GetNumber()
+	})
+
+	return result
+}
+
+// scopedName - полное имя в пакете или сообщении scope. Файл без package дает пустой scope.
+func scopedName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// fieldHintComment - подсказка над полем в примере с комментариями.
+func fieldHintComment(field FieldInfo) string {
+	var hints []string
+	if field.Required {
+		hints = append(hints, "required")
+	}
+	if field.IsExtension {
+		hints = append(hints, "extension")
+	}
+	if field.DefaultValue != "" {
+		hints = append(hints, "default: "+field.DefaultValue)
+	}
+	return strings.Join(hints, ", ")
+}
@@ -0,0 +1,244 @@
+package grpcreflect
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"grpc-gui/internal/utils"
+	"grpc-gui/testserver/proto"
+)
+
+func findMethod(t *testing.T, servicesInfo *ServicesInfo, serviceName, methodName string) *MethodInfo {
+	for _, service := range servicesInfo.Services {
+		for i := range service.Methods {
+			if service.Name == serviceName && service.Methods[i].Name == methodName {
+				return &service.Methods[i]
+			}
+		}
+	}
+
+	t.Fatalf("method %s.%s not found", serviceName, methodName)
+	return nil
+}
+
+func servicesInfoForTest(t *testing.T) *ServicesInfo {
+	addr, cleanup := startTestServer(t)
+	t.Cleanup(cleanup)
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+	return servicesInfo
+}
+
+func fieldsByName(msg *MessageInfo) map[string]FieldInfo {
+	fields := make(map[string]FieldInfo)
+	for _, field := range msg.Fields {
+		fields[field.Name] = field
+	}
+	return fields
+}
+
+func TestProto2_Extraction(t *testing.T) {
+	method := findMethod(t, servicesInfoForTest(t), "testserver.LegacyService", "Describe")
+	fields := fieldsByName(method.Request)
+
+	if !fields["name"].Required {
+		t.Error("expected name to be required")
+	}
+	if !fields["filter"].Required || !fields["filter"].HasPresence {
+		t.Errorf("expected required message filter with presence, got %+v", fields["filter"])
+	}
+
+	defaults := map[string]string{
+		"page_size": "25",
+		"locale":    "en-US",
+		"verbose":   "true",
+		"level":     "LEVEL_FULL",
+		"ratio":     "0.5",
+	}
+	for name, expected := range defaults {
+		if fields[name].DefaultValue != expected {
+			t.Errorf("field %s: expected default %q, got %q", name, expected, fields[name].DefaultValue)
+		}
+		if !fields[name].HasPresence {
+			t.Errorf("field %s: expected explicit presence", name)
+		}
+	}
+
+	options := fields["options"]
+	if !options.Delimited || options.Message == nil || len(options.Message.Fields) != 1 {
+		t.Errorf("expected group options to be a delimited message, got %+v", options)
+	}
+
+	ext, ok := fields["[testserver.retry_budget]"]
+	if !ok || !ext.IsExtension || ext.DefaultValue != "3" {
+		t.Errorf("expected retry_budget extension with default 3, got %+v", ext)
+	}
+	if _, ok := fields["[testserver.trace_tag]"]; !ok {
+		t.Error("expected trace_tag extension field")
+	}
+}
+
+func TestProto2_ExampleIsValidProtoJSON(t *testing.T) {
+	method := findMethod(t, servicesInfoForTest(t), "testserver.LegacyService", "Describe")
+
+	var example map[string]interface{}
+	if err := json.Unmarshal(method.RequestExample, &example); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+	if example["page_size"] != float64(25) || example["level"] != "LEVEL_FULL" || example["verbose"] != true {
+		t.Errorf("expected defaults in example, got %s", method.RequestExample)
+	}
+
+	// Расширения в примере резолвятся через зарегистрированные типы
+	var req proto.LegacyRequest
+	if err := protojson.Unmarshal(method.RequestExample, &req); err != nil {
+		t.Fatalf("request example is not valid protojson: %v\n%s", err, method.RequestExample)
+	}
+
+	if !strings.Contains(method.RequestExampleString, "// required") || !strings.Contains(method.RequestExampleString, "// extension, default: 3") {
+		t.Errorf("expected field hints in commented example, got %s", method.RequestExampleString)
+	}
+
+	commented := stripLineComments(method.RequestExampleString)
+	if err := protojson.Unmarshal([]byte(commented), &req); err != nil {
+		t.Fatalf("commented request example is not valid protojson: %v\n%s", err, commented)
+	}
+}
+
+func TestEditions_Extraction(t *testing.T) {
+	method := findMethod(t, servicesInfoForTest(t), "testserver.EditionsService", "Echo")
+	fields := fieldsByName(method.Request)
+
+	if !fields["id"].HasPresence {
+		t.Error("expected explicit presence for id (edition 2023 default)")
+	}
+	if fields["count"].HasPresence {
+		t.Error("expected implicit presence for count")
+	}
+	if !fields["label"].Required {
+		t.Error("expected LEGACY_REQUIRED label to be required")
+	}
+	if !fields["scope"].Required || !fields["scope"].HasPresence {
+		t.Errorf("expected LEGACY_REQUIRED message scope with presence, got %+v", fields["scope"])
+	}
+	if fields["nested"].Required || !fields["nested"].HasPresence {
+		t.Errorf("expected optional message nested with presence, got %+v", fields["nested"])
+	}
+	if !fields["nested"].Delimited {
+		t.Error("expected DELIMITED nested message")
+	}
+	if fields["limit"].DefaultValue != "10" {
+		t.Errorf("expected default 10 for limit, got %q", fields["limit"].DefaultValue)
+	}
+
+	var req proto.EditionsRequest
+	if err := protojson.Unmarshal(method.RequestExample, &req); err != nil {
+		t.Fatalf("request example is not valid protojson: %v\n%s", err, method.RequestExample)
+	}
+}
+
+func TestGenerateSchemaValue_Proto2Flags(t *testing.T) {
+	msg := &MessageInfo{
+		Name: "testserver.Legacy",
+		Fields: []FieldInfo{
+			{Name: "page_size", Type: "int32", DefaultValue: "25", HasPresence: true},
+			{Name: "[testserver.trace_tag]", Type: "string", IsExtension: true},
+		},
+	}
+
+	schema := GenerateSchemaValue(msg, make(map[string]bool))
+
+	pageSize := schema["page_size"].(map[string]interface{})
+	if pageSize["default"] != "25" || pageSize["hasPresence"] != true || pageSize["value"] != int64(25) {
+		t.Errorf("unexpected page_size schema: %v", pageSize)
+	}
+	if ext := schema["[testserver.trace_tag]"].(map[string]interface{}); ext["isExtension"] != true {
+		t.Errorf("unexpected extension schema: %v", ext)
+	}
+}
+
+func TestProto2_LowLevelExtraction(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	for _, serviceName := range []string{"testserver.LegacyService", "testserver.EditionsService"} {
+		methods, err := reflector.getServiceMethodsLowLevel(context.Background(), serviceName)
+		if err != nil {
+			t.Fatalf("getServiceMethodsLowLevel failed: %v", err)
+		}
+		if len(methods) != 1 || methods[0].Request == nil {
+			t.Fatalf("%s: unexpected methods: %+v", serviceName, methods)
+		}
+
+		fields := fieldsByName(methods[0].Request)
+		switch serviceName {
+		case "testserver.LegacyService":
+			if !fields["name"].Required || fields["page_size"].DefaultValue != "25" ||
+				!fields["filter"].Required || !fields["filter"].HasPresence {
+				t.Errorf("unexpected legacy fields: %+v", fields)
+			}
+			if !fields["options"].Delimited || fields["options"].Message == nil {
+				t.Errorf("expected group to be resolved, got %+v", fields["options"])
+			}
+			if !fields["[testserver.trace_tag]"].IsExtension {
+				t.Error("expected trace_tag extension field")
+			}
+		case "testserver.EditionsService":
+			if !fields["label"].Required || fields["count"].HasPresence || !fields["id"].HasPresence || !fields["nested"].Delimited ||
+				!fields["scope"].Required || !fields["scope"].HasPresence || !fields["nested"].HasPresence {
+				t.Errorf("unexpected editions fields: %+v", fields)
+			}
+		}
+	}
+}
+
+func TestLowLevelExtensions_NoPackage(t *testing.T) {
+	extension := func(name string) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     protobuf.String(name),
+			Number:   protobuf.Int32(100),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Extendee: protobuf.String(".Base"),
+		}
+	}
+	inner := extension("inner")
+	inner.Number = protobuf.Int32(101)
+
+	files := map[string]*descriptorpb.FileDescriptorProto{
+		"plain.proto": {
+			Name:      protobuf.String("plain.proto"),
+			Extension: []*descriptorpb.FieldDescriptorProto{extension("ext")},
+			MessageType: []*descriptorpb.DescriptorProto{
+				{Name: protobuf.String("Base")},
+				{Name: protobuf.String("Scope"), Extension: []*descriptorpb.FieldDescriptorProto{inner}},
+			},
+		},
+	}
+
+	extensions := lowLevelExtensions("Base", files)
+	if len(extensions) != 2 || extensions[0].fullName != "ext" || extensions[1].fullName != "Scope.inner" {
+		t.Errorf("expected extension names without leading dot, got %+v", extensions)
+	}
+}
@@ -28,21 +28,27 @@ type EnumValueInfo struct {
 }
 
 type FieldInfo struct {
- 	Name          string          `json:"name"`
-	Type          string          `json:"type"`
-	Number        int32           `json:"number"`
-	Repeated      bool            `json:"repeated"`
-	Optional      bool            `json:"optional"`
-	Required      bool            `json:"required"`
-	IsMap         bool            `json:"isMap"`
-	IsEnum        bool            `json:"isEnum"`
-	IsWellKnown   bool            `json:"isWellKnown"`
-	WellKnownType string          `json:"wellKnownType,omitempty"`
-	MapKey        string          `json:"mapKey"`
-	MapValue      string          `json:"mapValue"`
-	OneofGroup    string          `json:"oneofGroup,omitempty"`
-	Message       *MessageInfo    `json:"message,omitempty"`
-	EnumValues    []EnumValueInfo `json:"enumValues,omitempty"`
+	Name           string           `json:"name"`
+	Type           string           `json:"type"`
+	Number         int32            `json:"number"`
+	Repeated       bool             `json:"repeated"`
+	Optional       bool             `json:"optional"`
+	Required       bool             `json:"required"`
+	IsMap          bool             `json:"isMap"`
+	IsEnum         bool             `json:"isEnum"`
+	IsWellKnown    bool             `json:"isWellKnown"`
+	WellKnownType  string           `json:"wellKnownType,omitempty"`
+	MapKey         string           `json:"mapKey"`
+	MapValue       string           `json:"mapValue"`
+	OneofGroup     string           `json:"oneofGroup,omitempty"`
+	Message        *MessageInfo     `json:"message,omitempty"`
+	EnumValues     []EnumValueInfo  `json:"enumValues,omitempty"`
+	Validation     *FieldValidation `json:"validation,omitempty"`
+	FieldMaskPaths []string         `json:"fieldMaskPaths,omitempty"`
+	DefaultValue   string           `json:"defaultValue,omitempty"`
+	HasPresence    bool             `json:"hasPresence,omitempty"`
+	Delimited      bool             `json:"delimited,omitempty"`
+	IsExtension    bool             `json:"isExtension,omitempty"`
 }
 
 type MessageInfo struct {
@@ -60,6 +66,8 @@ type MethodInfo struct {
 	RequestExampleString string          `json:"requestExampleString,omitempty"`
 	ResponseExample      json.RawMessage `json:"responseExample,omitempty"`
 	RequestSchema        json.RawMessage `json:"requestSchema,omitempty"`
+	HTTPRules            []HTTPRule      `json:"httpRules,omitempty"`
+	SchemaHash           string          `json:"schemaHash,omitempty"`
 }
 
 type ServiceInfo struct {
@@ -68,7 +76,9 @@ type ServiceInfo struct {
 }
 
 type ServicesInfo struct {
-	Services []ServiceInfo `json:"services"`
+	Services     []ServiceInfo `json:"services"`
+	MessageTypes []string      `json:"messageTypes,omitempty"`
+	SchemaHash   string        `json:"schemaHash,omitempty"`
 }
 
 func NewReflector(ctx context.Context, url string, opts *utils.GRPCConnectOptions) (*Reflector, error) {
@@ -153,6 +163,7 @@ func (r *Reflector) getServiceMethodsLowLevel(ctx context.Context, serviceName s
 				Name:         method.GetName(),
 				RequestType:  method.GetInputType(),
 				ResponseType: method.GetOutputType(),
+				HTTPRules:    extractHTTPRules(method.GetOptions()),
 			}
 
 			requestMsg := r.findAndBuildMessage(method.GetInputType(), allFiles)
@@ -184,18 +195,32 @@ func (r *Reflector) findAndBuildMessage(typeName string, files map[string]*descr
 	cleanName := strings.TrimPrefix(typeName, ".")
 
 	for _, fd := range files {
-		pkg := fd.GetPackage()
-		for _, msg := range fd.GetMessageType() {
-			fullName := pkg + "." + msg.GetName()
-			if fullName == cleanName {
-				return r.buildMessageFromProto(msg, fd, files)
-			}
+		if msg := findNestedMessageProto(fd.GetPackage(), fd.GetMessageType(), cleanName); msg != nil {
+			info := r.buildMessageFromProto(msg, fd, files)
+			info.Name = cleanName
+			return info
 		}
 	}
 
 	return nil
 }
 
+// findNestedMessageProto ищет сообщение по полному имени, в том числе среди вложенных (group в proto2).
+func findNestedMessageProto(scope string, msgs []*descriptorpb.DescriptorProto, fullName string) *descriptorpb.DescriptorProto {
+	for _, msg := range msgs {
+		name := scope + "." + msg.GetName()
+		if name == fullName {
+			return msg
+		}
+		if strings.HasPrefix(fullName, name+".") {
+			if nested := findNestedMessageProto(name, msg.GetNestedType(), fullName); nested != nil {
+				return nested
+			}
+		}
+	}
+	return nil
+}
+
 func (r *Reflector) findEnumValues(typeName string, files map[string]*descriptorpb.FileDescriptorProto) []EnumValueInfo {
 	cleanName := strings.TrimPrefix(typeName, ".")
 
@@ -228,26 +253,48 @@ func (r *Reflector) buildMessageFromProto(msgProto *descriptorpb.DescriptorProto
 		Fields: []FieldInfo{},
 	}
 
-	for _, field := range msgProto.GetField() {
+	fields := msgProto.GetField()
+	extensions := lowLevelExtensions(fullName, files)
+	for _, ext := range extensions {
+		fields = append(fields, ext.field)
+	}
+
+	for i, field := range fields {
+		hasPresence, required := lowLevelFieldPresence(field, fd)
 		fieldInfo := FieldInfo{
-			Name:     field.GetName(),
-			Number:   field.GetNumber(),
-			Repeated: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
-			Optional: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL,
-			Required: field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED,
+			Name:         field.GetName(),
+			Number:       field.GetNumber(),
+			Repeated:     field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED,
+			Optional:     field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL,
+			Required:     required,
+			DefaultValue: field.GetDefaultValue(),
+			HasPresence:  hasPresence,
+			Delimited:    lowLevelDelimited(field, fd),
+		}
+
+		if i >= len(msgProto.GetField()) {
+			ext := extensions[i-len(msgProto.GetField())]
+			fieldInfo.Name = "[" + ext.fullName + "]"
+			fieldInfo.IsExtension = true
+			hasPresence, required = lowLevelFieldPresence(field, ext.file)
+			fieldInfo.HasPresence, fieldInfo.Required = hasPresence, required
 		}
 
-		if field.OneofIndex != nil {
+		fieldInfo.Validation = extractValidation(field.GetOptions())
+
+		if field.OneofIndex != nil && !fieldInfo.IsExtension {
 			oneofDecl := msgProto.GetOneofDecl()[*field.OneofIndex]
 			fieldInfo.OneofGroup = oneofDecl.GetName()
 		}
 
 		fieldInfo.Type = r.getFieldType(field)
 
-		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE && field.GetTypeName() != "" {
+		isMessage := field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE ||
+			field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP
+		if isMessage && field.GetTypeName() != "" {
 			typeName := strings.TrimPrefix(field.GetTypeName(), ".")
 			isWellKnown, wellKnownType := isWellKnownType(typeName)
-			
+
 			if isWellKnown {
 				fieldInfo.IsWellKnown = true
 				fieldInfo.WellKnownType = wellKnownType
@@ -260,6 +307,10 @@ func (r *Reflector) buildMessageFromProto(msgProto *descriptorpb.DescriptorProto
 		}
 
 		if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM && field.GetTypeName() != "" {
+			if strings.TrimPrefix(field.GetTypeName(), ".") == nullValueEnumName {
+				fieldInfo.IsWellKnown = true
+				fieldInfo.WellKnownType = nullValueType
+			}
 			fieldInfo.IsEnum = true
 			enumValues := r.findEnumValues(field.GetTypeName(), files)
 			if len(enumValues) > 0 {
@@ -270,6 +321,12 @@ func (r *Reflector) buildMessageFromProto(msgProto *descriptorpb.DescriptorProto
 		info.Fields = append(info.Fields, fieldInfo)
 	}
 
+	for i := range info.Fields {
+		if info.Fields[i].WellKnownType == fieldMaskType {
+			info.Fields[i].FieldMaskPaths = fieldMaskPaths(fieldMaskTarget(info.Fields))
+		}
+	}
+
 	return info
 }
 
@@ -305,7 +362,7 @@ func (r *Reflector) getFieldType(field *descriptorpb.FieldDescriptorProto) strin
 		return "sint32"
 	case descriptorpb.FieldDescriptorProto_TYPE_SINT64:
 		return "sint64"
-	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE:
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
 		if field.GetTypeName() != "" {
 			return strings.TrimPrefix(field.GetTypeName(), ".")
 		}
@@ -327,6 +384,7 @@ func (r *Reflector) GetAllServicesInfo() (*ServicesInfo, error) {
 	}
 
 	var services []ServiceInfo
+	var files []*desc.FileDescriptor
 
 	for _, serviceName := range serviceNames {
 		serviceInfo := ServiceInfo{
@@ -348,43 +406,57 @@ func (r *Reflector) GetAllServicesInfo() (*ServicesInfo, error) {
 			continue
 		}
 
+		files = append(files, serviceDesc.GetFile())
+
 		unwrapped := serviceDesc.UnwrapService()
+		extensions := buildExtensionIndex(unwrapped.ParentFile())
 		methods := unwrapped.Methods()
 		for i := 0; i < methods.Len(); i++ {
-			method := methods.Get(i)
-
-			requestMsg := extractMessageInfo(method.Input())
-			responseMsg := extractMessageInfo(method.Output())
-
-			requestExample, _ := GenerateJSONExample(requestMsg)
-			responseExample, _ := GenerateJSONExample(responseMsg)
-			requestSchema, _ := GenerateRequestSchema(requestMsg)
-			requestExampleString := GenerateJSONExampleWithComments(requestMsg)
-
-			serviceInfo.Methods = append(serviceInfo.Methods, MethodInfo{
-				Name:                 string(method.Name()),
-				RequestType:          string(method.Input().FullName()),
-				ResponseType:         string(method.Output().FullName()),
-				Request:              requestMsg,
-				Response:             responseMsg,
-				RequestExample:       json.RawMessage(requestExample),
-				RequestExampleString: requestExampleString,
-				ResponseExample:      json.RawMessage(responseExample),
-				RequestSchema:        json.RawMessage(requestSchema),
-			})
+			serviceInfo.Methods = append(serviceInfo.Methods, buildMethodInfo(methods.Get(i), extensions))
 		}
 
 		services = append(services, serviceInfo)
 	}
 
-	return &ServicesInfo{Services: services}, nil
+	return &ServicesInfo{Services: services, MessageTypes: collectMessageTypes(files), SchemaHash: servicesSchemaHash(services)}, nil
+}
+
+// buildMethodInfo строит полное описание метода: сообщения, примеры и схему запроса.
+func buildMethodInfo(method protoreflect.MethodDescriptor, extensions extensionIndex) MethodInfo {
+	requestMsg := extractMessageInfoWithExtensions(method.Input(), extensions)
+	responseMsg := extractMessageInfoWithExtensions(method.Output(), extensions)
+
+	requestExample, _ := GenerateJSONExample(requestMsg)
+	responseExample, _ := GenerateJSONExample(responseMsg)
+	requestSchema, _ := GenerateRequestSchema(requestMsg)
+	requestExampleString := GenerateJSONExampleWithComments(requestMsg)
+
+	return MethodInfo{
+		Name:                 string(method.Name()),
+		RequestType:          string(method.Input().FullName()),
+		ResponseType:         string(method.Output().FullName()),
+		Request:              requestMsg,
+		Response:             responseMsg,
+		RequestExample:       json.RawMessage(requestExample),
+		RequestExampleString: requestExampleString,
+		ResponseExample:      json.RawMessage(responseExample),
+		RequestSchema:        json.RawMessage(requestSchema),
+		HTTPRules:            extractHTTPRules(method.Options()),
+		SchemaHash:           newSchemaHasher().methodHash(method),
+	}
 }
 
 func extractMessageInfo(msgDesc protoreflect.MessageDescriptor) *MessageInfo {
-	return extractMessageInfoRecursive(msgDesc, make(map[string]bool))
+	return extractMessageInfoRecursive(msgDesc, make(map[string]bool), nil)
 }
 
-func extractMessageInfoRecursive(msgDesc protoreflect.MessageDescriptor, visited map[string]bool) *MessageInfo {
+// extractMessageInfoWithExtensions дополнительно добавляет в сообщения
+// известные расширения как поля с именем "[полное.имя]", как в protojson.
+func extractMessageInfoWithExtensions(msgDesc protoreflect.MessageDescriptor, extensions extensionIndex) *MessageInfo {
+	return extractMessageInfoRecursive(msgDesc, make(map[string]bool), extensions)
+}
+
+func extractMessageInfoRecursive(msgDesc protoreflect.MessageDescriptor, visited map[string]bool, extensions extensionIndex) *MessageInfo {
 	if msgDesc == nil {
 		return nil
 	}
@@ -405,111 +477,117 @@ func extractMessageInfoRecursive(msgDesc protoreflect.MessageDescriptor, visited
 
 	fields := msgDesc.Fields()
 	for i := 0; i < fields.Len(); i++ {
-		field := fields.Get(i)
-
-		fieldType := fieldKindToString(field.Kind())
-		var nestedMsg *MessageInfo
-		isMap := false
-		mapKey := ""
-		mapValue := ""
-
-		isWellKnown := false
-		wellKnownType := ""
-
-		if field.IsMap() {
-			isMap = true
-			mapKey = fieldKindToString(field.MapKey().Kind())
-			if field.MapValue().Message() != nil {
-				mapValue = string(field.MapValue().Message().FullName())
-				nestedMsg = extractMessageInfoRecursive(field.MapValue().Message(), visited)
-			} else if field.MapValue().Enum() != nil {
-				mapValue = string(field.MapValue().Enum().FullName())
-			} else {
-				mapValue = fieldKindToString(field.MapValue().Kind())
-			}
-			fieldType = fmt.Sprintf("map<%s, %s>", mapKey, mapValue)
-		} else if field.Message() != nil {
-			fieldType = string(field.Message().FullName())
-			isWellKnown, wellKnownType = isWellKnownType(fieldType)
-			if !isWellKnown {
-				nestedMsg = extractMessageInfoRecursive(field.Message(), visited)
-			}
-		} else if field.Enum() != nil {
-			fieldType = string(field.Enum().FullName())
-		}
-
-		var enumValues []EnumValueInfo
-		isEnum := false
-		if field.Enum() != nil {
-			isEnum = true
-			enumDesc := field.Enum()
-			values := enumDesc.Values()
-			for i := 0; i < values.Len(); i++ {
-				value := values.Get(i)
-				enumValues = append(enumValues, EnumValueInfo{
-					Name:   string(value.Name()),
-					Number: int32(value.Number()),
-				})
-			}
-		} else if field.IsMap() && field.MapValue().Enum() != nil {
-			enumDesc := field.MapValue().Enum()
-			values := enumDesc.Values()
-			for i := 0; i < values.Len(); i++ {
-				value := values.Get(i)
-				enumValues = append(enumValues, EnumValueInfo{
-					Name:   string(value.Name()),
-					Number: int32(value.Number()),
-				})
-			}
-		}
+		info.Fields = append(info.Fields, extractFieldInfo(fields.Get(i), visited, extensions))
+	}
 
-		oneofGroup := ""
-		if oneof := field.ContainingOneof(); oneof != nil {
-			oneofGroup = string(oneof.Name())
-		}
+	for _, ext := range extensions[msgDesc.FullName()] {
+		info.Fields = append(info.Fields, extractFieldInfo(ext, visited, extensions))
+	}
 
-		fieldInfo := FieldInfo{
-			Name:          string(field.Name()),
-			Type:          fieldType,
-			Number:        int32(field.Number()),
-			Repeated:      field.Cardinality() == protoreflect.Repeated && !field.IsMap(),
-			Optional:      field.Cardinality() == protoreflect.Optional,
-			Required:      field.Cardinality() == protoreflect.Required,
-			IsMap:         isMap,
-			IsEnum:        isEnum,
-			IsWellKnown:   isWellKnown,
-			WellKnownType: wellKnownType,
-			MapKey:        mapKey,
-			MapValue:      mapValue,
-			OneofGroup:    oneofGroup,
-			Message:       nestedMsg,
-			EnumValues:    enumValues,
+	for i := range info.Fields {
+		if info.Fields[i].WellKnownType == fieldMaskType {
+			if target := fieldMaskTargetDescriptor(msgDesc); target != nil {
+				info.Fields[i].FieldMaskPaths = fieldMaskPaths(extractMessageInfo(target))
+			}
 		}
-		info.Fields = append(info.Fields, fieldInfo)
 	}
 
 	return info
 }
 
-func isWellKnownType(typeName string) (bool, string) {
-	switch typeName {
-	case "google.protobuf.Timestamp":
-		return true, "timestamp"
-	case "google.protobuf.Duration":
-		return true, "duration"
-	case "google.protobuf.Any":
-		return true, "any"
-	case "google.protobuf.Struct":
-		return true, "struct"
-	case "google.protobuf.Value":
-		return true, "value"
-	case "google.protobuf.ListValue":
-		return true, "list_value"
-	case "google.protobuf.Empty":
-		return true, "empty"
-	default:
-		return false, ""
+func extractFieldInfo(field protoreflect.FieldDescriptor, visited map[string]bool, extensions extensionIndex) FieldInfo {
+	fieldType := fieldKindToString(field.Kind())
+	var nestedMsg *MessageInfo
+	isMap := false
+	mapKey := ""
+	mapValue := ""
+
+	isWellKnown := false
+	wellKnownType := ""
+
+	if field.IsMap() {
+		isMap = true
+		mapKey = fieldKindToString(field.MapKey().Kind())
+		if field.MapValue().Message() != nil {
+			mapValue = string(field.MapValue().Message().FullName())
+			nestedMsg = extractMessageInfoRecursive(field.MapValue().Message(), visited, extensions)
+		} else if field.MapValue().Enum() != nil {
+			mapValue = string(field.MapValue().Enum().FullName())
+		} else {
+			mapValue = fieldKindToString(field.MapValue().Kind())
+		}
+		fieldType = fmt.Sprintf("map<%s, %s>", mapKey, mapValue)
+	} else if field.Message() != nil {
+		fieldType = string(field.Message().FullName())
+		isWellKnown, wellKnownType = isWellKnownType(fieldType)
+		if !isWellKnown {
+			nestedMsg = extractMessageInfoRecursive(field.Message(), visited, extensions)
+		}
+	} else if field.Enum() != nil {
+		fieldType = string(field.Enum().FullName())
+		if fieldType == nullValueEnumName {
+			isWellKnown, wellKnownType = true, nullValueType
+		}
+	}
+
+	var enumValues []EnumValueInfo
+	isEnum := false
+	if field.Enum() != nil {
+		isEnum = true
+		enumDesc := field.Enum()
+		values := enumDesc.Values()
+		for i := 0; i < values.Len(); i++ {
+			value := values.Get(i)
+			enumValues = append(enumValues, EnumValueInfo{
+				Name:   string(value.Name()),
+				Number: int32(value.Number()),
+			})
+		}
+	} else if field.IsMap() && field.MapValue().Enum() != nil {
+		enumDesc := field.MapValue().Enum()
+		values := enumDesc.Values()
+		for i := 0; i < values.Len(); i++ {
+			value := values.Get(i)
+			enumValues = append(enumValues, EnumValueInfo{
+				Name:   string(value.Name()),
+				Number: int32(value.Number()),
+			})
+		}
+	}
+
+	oneofGroup := ""
+	if oneof := field.ContainingOneof(); oneof != nil {
+		oneofGroup = string(oneof.Name())
+	}
+
+	fieldInfo := FieldInfo{
+		Name:          string(field.Name()),
+		Type:          fieldType,
+		Number:        int32(field.Number()),
+		Repeated:      field.Cardinality() == protoreflect.Repeated && !field.IsMap(),
+		Optional:      field.Cardinality() == protoreflect.Optional,
+		Required:      field.Cardinality() == protoreflect.Required,
+		IsMap:         isMap,
+		IsEnum:        isEnum,
+		IsWellKnown:   isWellKnown,
+		WellKnownType: wellKnownType,
+		MapKey:        mapKey,
+		MapValue:      mapValue,
+		OneofGroup:    oneofGroup,
+		Message:       nestedMsg,
+		EnumValues:    enumValues,
+		Validation:    extractValidation(field.Options()),
+		DefaultValue:  defaultValueString(field),
+		HasPresence:   field.HasPresence(),
+		Delimited:     field.Kind() == protoreflect.GroupKind,
+	}
+
+	if field.IsExtension() {
+		fieldInfo.Name = "[" + string(field.FullName()) + "]"
+		fieldInfo.IsExtension = true
 	}
+
+	return fieldInfo
 }
 
 func fieldKindToString(kind protoreflect.Kind) string {
@@ -556,22 +634,39 @@ func fieldKindToString(kind protoreflect.Kind) string {
 }
 
 func GenerateJSONExample(msg *MessageInfo) ([]byte, error) {
+	return GenerateJSONExampleWithSelection(msg, nil)
+}
+
+// GenerateJSONExampleWithSelection генерирует пример, в котором для каждой
+// oneof группы заполнен только выбранный вариант.
+func GenerateJSONExampleWithSelection(msg *MessageInfo, selection OneofSelection) ([]byte, error) {
 	if msg == nil {
 		return []byte("{}"), nil
 	}
 
-	data := GenerateJSONValue(msg, make(map[string]bool))
+	data := GenerateJSONValueWithSelection(msg, make(map[string]bool), selection)
 	return json.MarshalIndent(data, "", "  ")
 }
 
 func GenerateJSONExampleWithComments(msg *MessageInfo) string {
+	return GenerateJSONExampleWithCommentsSelection(msg, nil)
+}
+
+// GenerateJSONExampleWithCommentsSelection - то же, что GenerateJSONExampleWithComments,
+// но с явным выбором вариантов oneof. Остальные варианты остаются закомментированными.
+func GenerateJSONExampleWithCommentsSelection(msg *MessageInfo, selection OneofSelection) string {
 	if msg == nil {
 		return "{}"
 	}
-	return generateJSONWithComments(msg, 0, make(map[string]bool), make(map[string]bool))
+	return generateJSONWithComments(msg, 0, make(map[string]bool), selection)
+}
+
+type commentedEntry struct {
+	text    string
+	comment bool
 }
 
-func generateJSONWithComments(msg *MessageInfo, indent int, visited map[string]bool, processedOneofs map[string]bool) string {
+func generateJSONWithComments(msg *MessageInfo, indent int, visited map[string]bool, selection OneofSelection) string {
 	if msg == nil {
 		return "{}"
 	}
@@ -583,50 +678,81 @@ func generateJSONWithComments(msg *MessageInfo, indent int, visited map[string]b
 	visited[fullName] = true
 	defer delete(visited, fullName)
 
-	oneofGroups := make(map[string]int)
+	indentStr := strings.Repeat("  ", indent+1)
+	processedOneofs := make(map[string]bool)
+	var entries []commentedEntry
+
 	for _, field := range msg.Fields {
-		if field.OneofGroup != "" {
-			oneofGroups[field.OneofGroup]++
+		if field.OneofGroup == "" {
+			if hint := fieldHintComment(field); hint != "" {
+				entries = append(entries, commentedEntry{text: indentStr + "// " + hint, comment: true})
+			}
+			entries = append(entries, commentedEntry{text: indentStr + generateFieldWithComments(field, indent+1, visited, selection)})
+			continue
 		}
-	}
 
-	var result strings.Builder
-	result.WriteString("{\n")
+		if processedOneofs[field.OneofGroup] {
+			continue
+		}
+		processedOneofs[field.OneofGroup] = true
 
-	indentStr := strings.Repeat("  ", indent+1)
-	first := true
+		variants := oneofVariants(msg, field.OneofGroup)
+		selected := selectedOneofVariant(msg, field.OneofGroup, selection)
 
-	for _, field := range msg.Fields {
-		if !first {
-			result.WriteString(",\n")
+		if len(variants) > 1 {
+			entries = append(entries, commentedEntry{text: indentStr + fmt.Sprintf("// oneof %s (choose one):", field.OneofGroup), comment: true})
 		}
-		first = false
 
-		if field.OneofGroup != "" {
-			oneofKey := fullName + "." + field.OneofGroup
-			if !processedOneofs[oneofKey] {
-				processedOneofs[oneofKey] = true
+		for _, variant := range msg.Fields {
+			if variant.OneofGroup != field.OneofGroup || variant.Name != selected {
+				continue
+			}
+			entries = append(entries, commentedEntry{text: indentStr + generateFieldWithComments(variant, indent+1, visited, selection)})
+		}
 
-				if oneofGroups[field.OneofGroup] > 1 {
-					result.WriteString(indentStr)
-					result.WriteString(fmt.Sprintf("// oneof %s (choose one):\n", field.OneofGroup))
-				}
+		// Альтернативы оставляем в виде комментариев, чтобы payload был валидным
+		for _, variant := range msg.Fields {
+			if variant.OneofGroup != field.OneofGroup || variant.Name == selected {
+				continue
+			}
+			text := generateFieldWithComments(variant, indent+1, visited, selection)
+			lines := strings.Split(text, "\n")
+			for i, line := range lines {
+				lines[i] = indentStr + "// " + strings.TrimPrefix(line, indentStr)
 			}
+			entries = append(entries, commentedEntry{text: strings.Join(lines, "\n"), comment: true})
+		}
+	}
+
+	lastValue := -1
+	for i, entry := range entries {
+		if !entry.comment {
+			lastValue = i
 		}
+	}
 
-		result.WriteString(indentStr)
-		result.WriteString(fmt.Sprintf(`"%s": `, field.Name))
-		result.WriteString(generateFieldValueWithComments(field, indent+1, visited, processedOneofs))
+	var result strings.Builder
+	result.WriteString("{\n")
+
+	for i, entry := range entries {
+		result.WriteString(entry.text)
+		if !entry.comment && i < lastValue {
+			result.WriteString(",")
+		}
+		result.WriteString("\n")
 	}
 
-	result.WriteString("\n")
 	result.WriteString(strings.Repeat("  ", indent))
 	result.WriteString("}")
 
 	return result.String()
 }
 
-func generateFieldValueWithComments(field FieldInfo, indent int, visited map[string]bool, processedOneofs map[string]bool) string {
+func generateFieldWithComments(field FieldInfo, indent int, visited map[string]bool, selection OneofSelection) string {
+	return fmt.Sprintf(`"%s": `, field.Name) + generateFieldValueWithComments(field, indent, visited, selection)
+}
+
+func generateFieldValueWithComments(field FieldInfo, indent int, visited map[string]bool, selection OneofSelection) string {
 	if field.Repeated {
 		return "[]"
 	}
@@ -636,26 +762,19 @@ func generateFieldValueWithComments(field FieldInfo, indent int, visited map[str
 	}
 
 	if field.IsWellKnown {
-		switch field.WellKnownType {
-		case "timestamp":
-			return `"2026-02-05T14:05:47Z"`
-		case "duration":
-			return `"1.5s"`
-		case "empty":
-			return "{}"
-		case "struct":
-			return "{}"
-		case "value":
-			return "null"
-		case "list_value":
-			return "[]"
-		case "any":
-			return `{"@type": ""}`
+		if example, ok := wellKnownExampleJSON(field); ok {
+			return example
 		}
 	}
 
 	if field.Message != nil {
-		return generateJSONWithComments(field.Message, indent, visited, processedOneofs)
+		return generateJSONWithComments(field.Message, indent, visited, selection)
+	}
+
+	if value, ok := typedDefaultValue(field); ok {
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
 	}
 
 	if field.IsEnum && len(field.EnumValues) > 0 {
@@ -680,6 +799,10 @@ func generateFieldValueWithComments(field FieldInfo, indent int, visited map[str
 }
 
 func GenerateJSONValue(msg *MessageInfo, visited map[string]bool) map[string]interface{} {
+	return GenerateJSONValueWithSelection(msg, visited, nil)
+}
+
+func GenerateJSONValueWithSelection(msg *MessageInfo, visited map[string]bool, selection OneofSelection) map[string]interface{} {
 	if msg == nil {
 		return nil
 	}
@@ -693,27 +816,30 @@ func GenerateJSONValue(msg *MessageInfo, visited map[string]bool) map[string]int
 	result := make(map[string]interface{})
 
 	for _, field := range msg.Fields {
-		result[field.Name] = generateFieldValue(field, visited)
+		if !isOneofVariantSelected(msg, field, selection) {
+			continue
+		}
+		result[field.Name] = generateFieldValue(field, visited, selection)
 	}
 
 	delete(visited, fullName)
 	return result
 }
 
-func generateFieldValue(field FieldInfo, visited map[string]bool) interface{} {
+func generateFieldValue(field FieldInfo, visited map[string]bool, selection OneofSelection) interface{} {
 	if field.IsMap {
 		return map[string]interface{}{}
 	}
 
 	if field.Repeated {
 		if field.Message != nil {
-			return []interface{}{GenerateJSONValue(field.Message, visited)}
+			return []interface{}{GenerateJSONValueWithSelection(field.Message, visited, selection)}
 		}
 		return []interface{}{getDefaultValueForField(field)}
 	}
 
 	if field.Message != nil {
-		return GenerateJSONValue(field.Message, visited)
+		return GenerateJSONValueWithSelection(field.Message, visited, selection)
 	}
 
 	return getDefaultValueForField(field)
@@ -749,24 +875,15 @@ func getDefaultValueForType(typeStr string) interface{} {
 
 func getDefaultValueForField(field FieldInfo) interface{} {
 	if field.IsWellKnown {
-		switch field.WellKnownType {
-		case "timestamp":
-			return "2026-02-05T14:05:47Z"
-		case "duration":
-			return "1.5s"
-		case "empty":
-			return map[string]interface{}{}
-		case "struct":
-			return map[string]interface{}{}
-		case "value":
-			return nil
-		case "list_value":
-			return []interface{}{}
-		case "any":
-			return map[string]interface{}{"@type": ""}
-		}
-	}
-	
+		if example, ok := wellKnownExample(field); ok {
+			return example
+		}
+	}
+
+	if value, ok := typedDefaultValue(field); ok {
+		return value
+	}
+
 	if len(field.EnumValues) > 0 {
 		return field.EnumValues[0].Name
 	}
@@ -798,7 +915,7 @@ func GenerateSchemaValue(msg *MessageInfo, visited map[string]bool) map[string]i
 	for _, field := range msg.Fields {
 		fieldSchema := make(map[string]interface{})
 		fieldSchema["type"] = field.Type
-		fieldSchema["value"] = generateFieldValue(field, visited)
+		fieldSchema["value"] = generateFieldValue(field, visited, nil)
 
 		if field.Repeated {
 			fieldSchema["repeated"] = true
@@ -809,6 +926,18 @@ func GenerateSchemaValue(msg *MessageInfo, visited map[string]bool) map[string]i
 		if field.Required {
 			fieldSchema["required"] = true
 		}
+		if field.DefaultValue != "" {
+			fieldSchema["default"] = field.DefaultValue
+		}
+		if field.HasPresence {
+			fieldSchema["hasPresence"] = true
+		}
+		if field.Delimited {
+			fieldSchema["delimited"] = true
+		}
+		if field.IsExtension {
+			fieldSchema["isExtension"] = true
+		}
 		if field.IsMap {
 			fieldSchema["isMap"] = true
 			fieldSchema["mapKey"] = field.MapKey
@@ -824,6 +953,8 @@ func GenerateSchemaValue(msg *MessageInfo, visited map[string]bool) map[string]i
 		}
 		if field.OneofGroup != "" {
 			fieldSchema["oneofGroup"] = field.OneofGroup
+			fieldSchema["oneofVariants"] = oneofVariants(msg, field.OneofGroup)
+			fieldSchema["oneofSelected"] = isOneofVariantSelected(msg, field, nil)
 		}
 		if field.Message != nil {
 			fieldSchema["message"] = GenerateSchemaValue(field.Message, visited)
@@ -25,6 +25,8 @@ func startTestServer(t *testing.T) (string, func()) {
 	s := grpc.NewServer()
 	proto.RegisterTestServiceServer(s, &testServer{})
 	proto.RegisterAnotherServiceServer(s, &anotherServer{})
+	proto.RegisterLegacyServiceServer(s, &proto.UnimplementedLegacyServiceServer{})
+	proto.RegisterEditionsServiceServer(s, &proto.UnimplementedEditionsServiceServer{})
 	reflection.Register(s)
 
 	go func() {
@@ -143,12 +145,12 @@ func TestGetAllServicesInfo(t *testing.T) {
 		t.Fatal("AnotherService not found")
 	}
 
-	if len(testService.Methods) != 6 {
-		t.Errorf("expected 6 methods in TestService, got %d", len(testService.Methods))
+	if len(testService.Methods) != 7 {
+		t.Errorf("expected 7 methods in TestService, got %d", len(testService.Methods))
 	}
 
-	if len(anotherService.Methods) != 3 {
-		t.Errorf("expected 3 methods in AnotherService, got %d", len(anotherService.Methods))
+	if len(anotherService.Methods) != 4 {
+		t.Errorf("expected 4 methods in AnotherService, got %d", len(anotherService.Methods))
 	}
 }
 
@@ -1209,3 +1211,120 @@ func TestIsSystemService(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPRules(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	reflector, err := NewReflector(ctx, addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+
+	methods := make(map[string]*MethodInfo)
+	for i := range servicesInfo.Services {
+		if servicesInfo.Services[i].Name != "testserver.AnotherService" {
+			continue
+		}
+		for j := range servicesInfo.Services[i].Methods {
+			methods[servicesInfo.Services[i].Methods[j].Name] = &servicesInfo.Services[i].Methods[j]
+		}
+	}
+
+	getUser, ok := methods["GetUser"]
+	if !ok {
+		t.Fatal("GetUser method not found")
+	}
+	if len(getUser.HTTPRules) != 1 {
+		t.Fatalf("expected 1 http rule for GetUser, got %d", len(getUser.HTTPRules))
+	}
+	if getUser.HTTPRules[0].Method != "get" || getUser.HTTPRules[0].Path != "/v1/users/{message}" {
+		t.Errorf("unexpected GetUser http rule: %+v", getUser.HTTPRules[0])
+	}
+
+	updateStatus, ok := methods["UpdateStatus"]
+	if !ok {
+		t.Fatal("UpdateStatus method not found")
+	}
+	if len(updateStatus.HTTPRules) != 2 {
+		t.Fatalf("expected 2 http rules for UpdateStatus, got %d", len(updateStatus.HTTPRules))
+	}
+	if updateStatus.HTTPRules[0].Body != "*" {
+		t.Errorf("expected body '*', got %q", updateStatus.HTTPRules[0].Body)
+	}
+	if updateStatus.HTTPRules[1].Method != "patch" || updateStatus.HTTPRules[1].Body != "status" {
+		t.Errorf("unexpected additional binding: %+v", updateStatus.HTTPRules[1])
+	}
+
+	getUsers, ok := methods["GetUsers"]
+	if !ok {
+		t.Fatal("GetUsers method not found")
+	}
+	if len(getUsers.HTTPRules) != 0 {
+		t.Errorf("expected no http rules for GetUsers, got %d", len(getUsers.HTTPRules))
+	}
+}
+
+func TestValidationRules(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	reflector, err := NewReflector(ctx, addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+
+	var userMsg *MessageInfo
+	for _, service := range servicesInfo.Services {
+		for _, method := range service.Methods {
+			if method.Name == "GetUser" {
+				userMsg = method.Response
+			}
+		}
+	}
+
+	if userMsg == nil {
+		t.Fatal("User message not found")
+	}
+
+	fieldMap := make(map[string]*FieldInfo)
+	for i := range userMsg.Fields {
+		fieldMap[userMsg.Fields[i].Name] = &userMsg.Fields[i]
+	}
+
+	email := fieldMap["email"]
+	if email.Validation == nil || email.Validation.Format != "email" {
+		t.Errorf("expected email format validation, got %+v", email.Validation)
+	}
+
+	age := fieldMap["age"]
+	if age.Validation == nil || age.Validation.Min == nil || age.Validation.Max == nil {
+		t.Fatalf("expected min/max validation for age, got %+v", age.Validation)
+	}
+	if *age.Validation.Min != 18 || *age.Validation.Max != 120 {
+		t.Errorf("expected age range [18, 120], got [%v, %v]", *age.Validation.Min, *age.Validation.Max)
+	}
+
+	tags := fieldMap["tags"]
+	if tags.Validation == nil || tags.Validation.MinItems == nil || *tags.Validation.MinItems != 1 {
+		t.Errorf("expected min_items 1 for tags, got %+v", tags.Validation)
+	}
+
+	if fieldMap["name"].Validation != nil {
+		t.Errorf("expected no validation for name, got %+v", fieldMap["name"].Validation)
+	}
+}
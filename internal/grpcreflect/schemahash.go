@@ -0,0 +1,174 @@
+package grpcreflect
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SchemaDiff - что поменялось в схеме сервера между двумя обновлениями рефлексии.
+// Методы записываются как "<сервис>/<метод>".
+type SchemaDiff struct {
+	AddedServices   []string `json:"addedServices,omitempty"`
+	RemovedServices []string `json:"removedServices,omitempty"`
+	AddedMethods    []string `json:"addedMethods,omitempty"`
+	RemovedMethods  []string `json:"removedMethods,omitempty"`
+	ChangedMethods  []string `json:"changedMethods,omitempty"`
+}
+
+func (d SchemaDiff) IsEmpty() bool {
+	return len(d.AddedServices) == 0 && len(d.RemovedServices) == 0 &&
+		len(d.AddedMethods) == 0 && len(d.RemovedMethods) == 0 && len(d.ChangedMethods) == 0
+}
+
+// schemaHasher считает хеши сообщений методов с учетом всех вложенных типов.
+// Сериализованные дескрипторы кешируются, потому что типы у методов в основном общие.
+type schemaHasher struct {
+	encoded map[protoreflect.FullName][]byte
+}
+
+func newSchemaHasher() *schemaHasher {
+	return &schemaHasher{encoded: make(map[protoreflect.FullName][]byte)}
+}
+
+func (h *schemaHasher) methodHash(method protoreflect.MethodDescriptor) string {
+	reachable := make(map[protoreflect.FullName]bool)
+	h.collect(method.Input(), reachable)
+	h.collect(method.Output(), reachable)
+
+	names := make([]string, 0, len(reachable))
+	for name := range reachable {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	sum := sha256.New()
+	sum.Write([]byte(method.Input().FullName() + "\n" + method.Output().FullName() + "\n"))
+	if method.IsStreamingClient() {
+		sum.Write([]byte("client-stream\n"))
+	}
+	if method.IsStreamingServer() {
+		sum.Write([]byte("server-stream\n"))
+	}
+	for _, name := range names {
+		sum.Write([]byte(name))
+		sum.Write(h.encoded[protoreflect.FullName(name)])
+	}
+
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+func (h *schemaHasher) collect(msg protoreflect.MessageDescriptor, reachable map[protoreflect.FullName]bool) {
+	if reachable[msg.FullName()] {
+		return
+	}
+	reachable[msg.FullName()] = true
+
+	if _, ok := h.encoded[msg.FullName()]; !ok {
+		h.encoded[msg.FullName()] = marshalDeterministic(protodesc.ToDescriptorProto(msg))
+	}
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() != nil {
+			h.collect(field.Message(), reachable)
+		}
+		if enum := field.Enum(); enum != nil && !reachable[enum.FullName()] {
+			reachable[enum.FullName()] = true
+			if _, ok := h.encoded[enum.FullName()]; !ok {
+				h.encoded[enum.FullName()] = marshalDeterministic(protodesc.ToEnumDescriptorProto(enum))
+			}
+		}
+	}
+}
+
+func marshalDeterministic(m proto.Message) []byte {
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	return data
+}
+
+// servicesSchemaHash - общий хеш схемы по именам сервисов, методов и их хешам.
+func servicesSchemaHash(services []ServiceInfo) string {
+	sorted := make([]ServiceInfo, len(services))
+	copy(sorted, services)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	sum := sha256.New()
+	for _, service := range sorted {
+		sum.Write([]byte("service " + service.Name + "\n"))
+
+		methods := make([]MethodInfo, len(service.Methods))
+		copy(methods, service.Methods)
+		sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+
+		for _, method := range methods {
+			sum.Write([]byte(method.Name + " " + method.RequestType + " " + method.ResponseType + " " + method.SchemaHash + "\n"))
+		}
+	}
+
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+// DiffServices сравнивает два списка сервисов.
+func DiffServices(previous, current *ServicesInfo) SchemaDiff {
+	var diff SchemaDiff
+
+	index := func(info *ServicesInfo) map[string]map[string]MethodInfo {
+		result := make(map[string]map[string]MethodInfo)
+		if info == nil {
+			return result
+		}
+		for _, service := range info.Services {
+			methods := make(map[string]MethodInfo)
+			for _, method := range service.Methods {
+				methods[method.Name] = method
+			}
+			result[service.Name] = methods
+		}
+		return result
+	}
+
+	before := index(previous)
+	after := index(current)
+
+	for serviceName, methods := range after {
+		previousMethods, existed := before[serviceName]
+		if !existed {
+			diff.AddedServices = append(diff.AddedServices, serviceName)
+		}
+		for methodName, method := range methods {
+			previousMethod, ok := previousMethods[methodName]
+			switch {
+			case !ok:
+				diff.AddedMethods = append(diff.AddedMethods, serviceName+"/"+methodName)
+			case previousMethod.SchemaHash != method.SchemaHash ||
+				previousMethod.RequestType != method.RequestType ||
+				previousMethod.ResponseType != method.ResponseType:
+				diff.ChangedMethods = append(diff.ChangedMethods, serviceName+"/"+methodName)
+			}
+		}
+	}
+
+	for serviceName, methods := range before {
+		currentMethods, exists := after[serviceName]
+		if !exists {
+			diff.RemovedServices = append(diff.RemovedServices, serviceName)
+		}
+		for methodName := range methods {
+			if _, ok := currentMethods[methodName]; !ok {
+				diff.RemovedMethods = append(diff.RemovedMethods, serviceName+"/"+methodName)
+			}
+		}
+	}
+
+	for _, list := range [][]string{diff.AddedServices, diff.RemovedServices, diff.AddedMethods, diff.RemovedMethods, diff.ChangedMethods} {
+		sort.Strings(list)
+	}
+
+	return diff
+}
@@ -0,0 +1,81 @@
+package grpcreflect
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"grpc-gui/internal/utils"
+)
+
+func TestSchemaHash_Stable(t *testing.T) {
+	addr, cleanup := startTestServer(t)
+	defer cleanup()
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	services := []string{"testserver.TestService", "testserver.AnotherService"}
+	first, _, err := reflector.ResolveSchema(services)
+	if err != nil {
+		t.Fatalf("ResolveSchema failed: %v", err)
+	}
+	second, _, err := reflector.ResolveSchema(services)
+	if err != nil {
+		t.Fatalf("ResolveSchema failed: %v", err)
+	}
+
+	if first.SchemaHash == "" || first.SchemaHash != second.SchemaHash {
+		t.Errorf("expected stable schema hash, got %q and %q", first.SchemaHash, second.SchemaHash)
+	}
+
+	simple := findMethod(t, first, "testserver.TestService", "SimpleCall")
+	complexCall := findMethod(t, first, "testserver.TestService", "ComplexCall")
+	if simple.SchemaHash == "" || simple.SchemaHash == complexCall.SchemaHash {
+		t.Errorf("expected distinct method hashes, got %q and %q", simple.SchemaHash, complexCall.SchemaHash)
+	}
+
+	detail, err := reflector.GetMethodInfo("testserver.TestService", "ComplexCall")
+	if err != nil {
+		t.Fatalf("GetMethodInfo failed: %v", err)
+	}
+	if detail.SchemaHash != complexCall.SchemaHash {
+		t.Error("expected method details to carry the listing hash")
+	}
+}
+
+func TestDiffServices(t *testing.T) {
+	previous := &ServicesInfo{Services: []ServiceInfo{
+		{Name: "a.Users", Methods: []MethodInfo{
+			{Name: "Get", SchemaHash: "1"},
+			{Name: "Delete", SchemaHash: "2"},
+		}},
+		{Name: "a.Legacy", Methods: []MethodInfo{{Name: "Ping", SchemaHash: "3"}}},
+	}}
+	current := &ServicesInfo{Services: []ServiceInfo{
+		{Name: "a.Users", Methods: []MethodInfo{
+			{Name: "Get", SchemaHash: "changed"},
+			{Name: "List", SchemaHash: "4"},
+		}},
+		{Name: "a.Orders", Methods: []MethodInfo{{Name: "Create", SchemaHash: "5"}}},
+	}}
+
+	expected := SchemaDiff{
+		AddedServices:   []string{"a.Orders"},
+		RemovedServices: []string{"a.Legacy"},
+		AddedMethods:    []string{"a.Orders/Create", "a.Users/List"},
+		RemovedMethods:  []string{"a.Legacy/Ping", "a.Users/Delete"},
+		ChangedMethods:  []string{"a.Users/Get"},
+	}
+
+	if diff := DiffServices(previous, current); !reflect.DeepEqual(diff, expected) {
+		t.Errorf("unexpected diff:\n%+v\nwant:\n%+v", diff, expected)
+	}
+
+	if diff := DiffServices(current, current); !diff.IsEmpty() {
+		t.Errorf("expected empty diff, got %+v", diff)
+	}
+}
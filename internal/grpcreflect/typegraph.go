@@ -0,0 +1,261 @@
+package grpcreflect
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// TypeUsage - метод, в запрос или ответ которого входит тип.
+// RequestPath и ResponsePath - цепочка полей от сообщения метода до типа, например ["ComplexRequest.user", "User.address"].
+type TypeUsage struct {
+	Service      string   `json:"service"`
+	Method       string   `json:"method"`
+	InRequest    bool     `json:"inRequest"`
+	InResponse   bool     `json:"inResponse"`
+	RequestPath  []string `json:"requestPath,omitempty"`
+	ResponsePath []string `json:"responsePath,omitempty"`
+}
+
+type TypeNodeKind string
+
+const (
+	TypeNodeMethod  TypeNodeKind = "method"
+	TypeNodeMessage TypeNodeKind = "message"
+	TypeNodeEnum    TypeNodeKind = "enum"
+)
+
+type TypeNode struct {
+	Name string       `json:"name"`
+	Kind TypeNodeKind `json:"kind"`
+}
+
+// TypeEdge - ссылка из сообщения на тип поля. У метода ребра "request" и "response".
+type TypeEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Field    string `json:"field"`
+	Repeated bool   `json:"repeated,omitempty"`
+	Map      bool   `json:"map,omitempty"`
+}
+
+// TypeGraph - граф зависимостей типов метода.
+type TypeGraph struct {
+	Root  string     `json:"root"`
+	Nodes []TypeNode `json:"nodes"`
+	Edges []TypeEdge `json:"edges"`
+}
+
+// FindTypeUsages ищет методы перечисленных сервисов, которые транзитивно используют тип.
+func FindTypeUsages(files *protoregistry.Files, serviceNames []string, typeName string) ([]TypeUsage, error) {
+	target := protoreflect.FullName(strings.TrimPrefix(typeName, "."))
+	if _, err := files.FindDescriptorByName(target); err != nil {
+		return nil, fmt.Errorf("type %s not found", typeName)
+	}
+
+	usages := []TypeUsage{}
+	for _, serviceName := range serviceNames {
+		service, err := findService(files, serviceName)
+		if err != nil {
+			continue
+		}
+
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			requestPath, inRequest := typePath(method.Input(), target)
+			responsePath, inResponse := typePath(method.Output(), target)
+			if !inRequest && !inResponse {
+				continue
+			}
+
+			usages = append(usages, TypeUsage{
+				Service:      serviceName,
+				Method:       string(method.Name()),
+				InRequest:    inRequest,
+				InResponse:   inResponse,
+				RequestPath:  requestPath,
+				ResponsePath: responsePath,
+			})
+		}
+	}
+
+	return usages, nil
+}
+
+// typePath ищет кратчайшую цепочку полей от сообщения до типа обходом в ширину.
+func typePath(root protoreflect.MessageDescriptor, target protoreflect.FullName) ([]string, bool) {
+	if root.FullName() == target {
+		return nil, true
+	}
+
+	type step struct {
+		msg  protoreflect.MessageDescriptor
+		path []string
+	}
+
+	visited := map[protoreflect.FullName]bool{root.FullName(): true}
+	queue := []step{{msg: root}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		fields := current.msg.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			path := append(append([]string{}, current.path...), fieldLabel(current.msg, field))
+
+			valueField := field
+			if field.IsMap() {
+				valueField = field.MapValue()
+			}
+
+			if enum := valueField.Enum(); enum != nil && enum.FullName() == target {
+				return path, true
+			}
+
+			next := valueField.Message()
+			if next == nil || visited[next.FullName()] {
+				continue
+			}
+			if next.FullName() == target {
+				return path, true
+			}
+			visited[next.FullName()] = true
+			queue = append(queue, step{msg: next, path: path})
+		}
+	}
+
+	return nil, false
+}
+
+func fieldLabel(msg protoreflect.MessageDescriptor, field protoreflect.FieldDescriptor) string {
+	return string(msg.Name()) + "." + string(field.Name())
+}
+
+// MethodTypeGraph строит граф типов запроса и ответа метода.
+// Well-known типы попадают в граф листьями, их внутренности не раскрываются.
+func MethodTypeGraph(files *protoregistry.Files, serviceName, methodName string) (*TypeGraph, error) {
+	service, err := findService(files, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", methodName, serviceName)
+	}
+
+	root := serviceName + "/" + methodName
+	graph := &TypeGraph{
+		Root:  root,
+		Nodes: []TypeNode{{Name: root, Kind: TypeNodeMethod}},
+		Edges: []TypeEdge{
+			{From: root, To: string(method.Input().FullName()), Field: "request", Repeated: method.IsStreamingClient()},
+			{From: root, To: string(method.Output().FullName()), Field: "response", Repeated: method.IsStreamingServer()},
+		},
+	}
+
+	seen := make(map[protoreflect.FullName]bool)
+	var walk func(msg protoreflect.MessageDescriptor)
+	walk = func(msg protoreflect.MessageDescriptor) {
+		if seen[msg.FullName()] {
+			return
+		}
+		seen[msg.FullName()] = true
+		graph.Nodes = append(graph.Nodes, TypeNode{Name: string(msg.FullName()), Kind: TypeNodeMessage})
+
+		if strings.HasPrefix(string(msg.FullName()), "google.protobuf.") {
+			return
+		}
+
+		fields := msg.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			edge := TypeEdge{From: string(msg.FullName()), Field: string(field.Name()), Repeated: field.IsList(), Map: field.IsMap()}
+
+			valueField := field
+			if field.IsMap() {
+				valueField = field.MapValue()
+			}
+
+			switch {
+			case valueField.Message() != nil:
+				edge.To = string(valueField.Message().FullName())
+				graph.Edges = append(graph.Edges, edge)
+				walk(valueField.Message())
+			case valueField.Enum() != nil:
+				enum := valueField.Enum()
+				edge.To = string(enum.FullName())
+				graph.Edges = append(graph.Edges, edge)
+				if !seen[enum.FullName()] {
+					seen[enum.FullName()] = true
+					graph.Nodes = append(graph.Nodes, TypeNode{Name: string(enum.FullName()), Kind: TypeNodeEnum})
+				}
+			}
+		}
+	}
+
+	walk(method.Input())
+	walk(method.Output())
+
+	return graph, nil
+}
+
+// DOT выгружает граф в формате Graphviz.
+func (g *TypeGraph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph types {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+
+	nodes := make([]TypeNode, len(g.Nodes))
+	copy(nodes, g.Nodes)
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	for _, node := range nodes {
+		shape := "box"
+		switch node.Kind {
+		case TypeNodeMethod:
+			shape = "ellipse, style=bold"
+		case TypeNodeEnum:
+			shape = "box, style=rounded"
+		}
+		fmt.Fprintf(&b, "  %s [shape=%s];\n", dotQuote(node.Name), shape)
+	}
+
+	for _, edge := range g.Edges {
+		label := edge.Field
+		switch {
+		case edge.Map:
+			label = "map " + label
+		case edge.Repeated:
+			label = "repeated " + label
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(label))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+func findService(files *protoregistry.Files, serviceName string) (protoreflect.ServiceDescriptor, error) {
+	d, err := files.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("service %s not found", serviceName)
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+	return service, nil
+}
@@ -0,0 +1,131 @@
+package grpcreflect
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"grpc-gui/internal/utils"
+)
+
+func testDescriptorFiles(t *testing.T, services []string) *protoregistry.Files {
+	addr, cleanup := startTestServer(t)
+	t.Cleanup(cleanup)
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	_, data, err := reflector.ResolveSchema(services)
+	if err != nil {
+		t.Fatalf("ResolveSchema failed: %v", err)
+	}
+
+	files, err := FilesFromDescriptorSet(data)
+	if err != nil {
+		t.Fatalf("FilesFromDescriptorSet failed: %v", err)
+	}
+	return files
+}
+
+func TestFindTypeUsages(t *testing.T) {
+	services := []string{"testserver.TestService", "testserver.AnotherService", "testserver.LegacyService"}
+	files := testDescriptorFiles(t, services)
+
+	usages, err := FindTypeUsages(files, services, "testserver.Address")
+	if err != nil {
+		t.Fatalf("FindTypeUsages failed: %v", err)
+	}
+
+	byMethod := make(map[string]TypeUsage)
+	for _, usage := range usages {
+		byMethod[usage.Service+"/"+usage.Method] = usage
+	}
+
+	complexCall, ok := byMethod["testserver.TestService/ComplexCall"]
+	if !ok || !complexCall.InRequest || !complexCall.InResponse {
+		t.Fatalf("expected ComplexCall to use Address in request and response, got %+v", complexCall)
+	}
+	if strings.Join(complexCall.RequestPath, " > ") != "ComplexRequest.user > User.address" {
+		t.Errorf("unexpected request path: %v", complexCall.RequestPath)
+	}
+
+	getUser, ok := byMethod["testserver.AnotherService/GetUser"]
+	if !ok || getUser.InRequest || !getUser.InResponse {
+		t.Errorf("expected GetUser to use Address only in response, got %+v", getUser)
+	}
+
+	if _, ok := byMethod["testserver.TestService/SimpleCall"]; ok {
+		t.Error("expected SimpleCall not to use Address")
+	}
+	if _, ok := byMethod["testserver.LegacyService/Describe"]; ok {
+		t.Error("expected Describe not to use Address")
+	}
+
+	// Перечисления тоже ищутся, в том числе через значения map
+	usages, err = FindTypeUsages(files, services, "testserver.Status")
+	if err != nil || len(usages) == 0 {
+		t.Errorf("expected usages of Status enum, got %v, %v", usages, err)
+	}
+
+	if _, err := FindTypeUsages(files, services, "testserver.Missing"); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+func TestMethodTypeGraph(t *testing.T) {
+	files := testDescriptorFiles(t, []string{"testserver.TestService"})
+
+	graph, err := MethodTypeGraph(files, "testserver.TestService", "ComplexCall")
+	if err != nil {
+		t.Fatalf("MethodTypeGraph failed: %v", err)
+	}
+
+	nodes := make(map[string]TypeNodeKind)
+	for _, node := range graph.Nodes {
+		nodes[node.Name] = node.Kind
+	}
+	expected := map[string]TypeNodeKind{
+		"testserver.TestService/ComplexCall": TypeNodeMethod,
+		"testserver.ComplexRequest":          TypeNodeMessage,
+		"testserver.ComplexResponse":         TypeNodeMessage,
+		"testserver.User":                    TypeNodeMessage,
+		"testserver.Address":                 TypeNodeMessage,
+		"testserver.Status":                  TypeNodeEnum,
+	}
+	for name, kind := range expected {
+		if nodes[name] != kind {
+			t.Errorf("expected node %s of kind %q, got %q", name, kind, nodes[name])
+		}
+	}
+
+	var userMap *TypeEdge
+	for i := range graph.Edges {
+		if graph.Edges[i].From == "testserver.ComplexRequest" && graph.Edges[i].Field == "user_map" {
+			userMap = &graph.Edges[i]
+		}
+	}
+	if userMap == nil || userMap.To != "testserver.User" || !userMap.Map {
+		t.Errorf("expected map edge to User, got %+v", userMap)
+	}
+
+	dot := graph.DOT()
+	for _, fragment := range []string{
+		"digraph types {",
+		`"testserver.TestService/ComplexCall" -> "testserver.ComplexRequest" [label="request"];`,
+		`"testserver.ComplexRequest" -> "testserver.User" [label="map user_map"];`,
+		`"testserver.Status" [shape=box, style=rounded];`,
+	} {
+		if !strings.Contains(dot, fragment) {
+			t.Errorf("expected DOT to contain %q:\n%s", fragment, dot)
+		}
+	}
+
+	if _, err := MethodTypeGraph(files, "testserver.TestService", "Missing"); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
@@ -0,0 +1,202 @@
+package grpcreflect
+
+import (
+	"strconv"
+
+	"github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+)
+
+// FieldValidation - упрощенное представление правил protoc-gen-validate,
+// достаточное для генерации правдоподобных значений.
+type FieldValidation struct {
+	Required     bool     `json:"required,omitempty"`
+	In           []string `json:"in,omitempty"`
+	MinLen       *uint64  `json:"minLen,omitempty"`
+	MaxLen       *uint64  `json:"maxLen,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+	Prefix       string   `json:"prefix,omitempty"`
+	Suffix       string   `json:"suffix,omitempty"`
+	Format       string   `json:"format,omitempty"`
+	Min          *float64 `json:"min,omitempty"`
+	Max          *float64 `json:"max,omitempty"`
+	ExclusiveMin bool     `json:"exclusiveMin,omitempty"`
+	ExclusiveMax bool     `json:"exclusiveMax,omitempty"`
+	MinItems     *uint64  `json:"minItems,omitempty"`
+	MaxItems     *uint64  `json:"maxItems,omitempty"`
+	DefinedOnly  bool     `json:"definedOnly,omitempty"`
+}
+
+func extractValidation(opts proto.Message) *FieldValidation {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil
+	}
+
+	data, err := proto.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+
+	fieldOpts := &descriptorpb.FieldOptions{}
+	err = proto.UnmarshalOptions{Resolver: protoregistry.GlobalTypes}.Unmarshal(data, fieldOpts)
+	if err != nil || !proto.HasExtension(fieldOpts, validate.E_Rules) {
+		return nil
+	}
+
+	rules, ok := proto.GetExtension(fieldOpts, validate.E_Rules).(*validate.FieldRules)
+	if !ok || rules == nil {
+		return nil
+	}
+
+	v := &FieldValidation{}
+	applyFieldRules(rules, v)
+
+	return v
+}
+
+func applyFieldRules(rules *validate.FieldRules, v *FieldValidation) {
+	if rules.GetMessage().GetRequired() {
+		v.Required = true
+	}
+
+	switch {
+	case rules.GetString_() != nil:
+		applyStringRules(rules.GetString_(), v)
+	case rules.GetEnum() != nil:
+		v.DefinedOnly = rules.GetEnum().GetDefinedOnly()
+		if rules.GetEnum().Const != nil {
+			v.In = []string{strconv.Itoa(int(rules.GetEnum().GetConst()))}
+		}
+		for _, n := range rules.GetEnum().GetIn() {
+			v.In = append(v.In, strconv.Itoa(int(n)))
+		}
+	case rules.GetRepeated() != nil:
+		repeated := rules.GetRepeated()
+		if repeated.MinItems != nil {
+			v.MinItems = proto.Uint64(repeated.GetMinItems())
+		}
+		if repeated.MaxItems != nil {
+			v.MaxItems = proto.Uint64(repeated.GetMaxItems())
+		}
+		if repeated.GetItems() != nil {
+			applyFieldRules(repeated.GetItems(), v)
+		}
+	case rules.GetMap() != nil:
+		m := rules.GetMap()
+		if m.MinPairs != nil {
+			v.MinItems = proto.Uint64(m.GetMinPairs())
+		}
+		if m.MaxPairs != nil {
+			v.MaxItems = proto.Uint64(m.GetMaxPairs())
+		}
+	default:
+		applyNumericRules(rules, v)
+	}
+}
+
+func applyStringRules(rules *validate.StringRules, v *FieldValidation) {
+	if rules.Const != nil {
+		v.In = []string{rules.GetConst()}
+	}
+	v.In = append(v.In, rules.GetIn()...)
+
+	if rules.Len != nil {
+		v.MinLen = proto.Uint64(rules.GetLen())
+		v.MaxLen = proto.Uint64(rules.GetLen())
+	}
+	if rules.MinLen != nil {
+		v.MinLen = proto.Uint64(rules.GetMinLen())
+	}
+	if rules.MaxLen != nil {
+		v.MaxLen = proto.Uint64(rules.GetMaxLen())
+	}
+
+	v.Pattern = rules.GetPattern()
+	v.Prefix = rules.GetPrefix()
+	v.Suffix = rules.GetSuffix()
+
+	switch {
+	case rules.GetEmail():
+		v.Format = "email"
+	case rules.GetUuid():
+		v.Format = "uuid"
+	case rules.GetUri(), rules.GetUriRef():
+		v.Format = "uri"
+	case rules.GetHostname(), rules.GetAddress():
+		v.Format = "hostname"
+	case rules.GetIpv4(), rules.GetIp():
+		v.Format = "ipv4"
+	case rules.GetIpv6():
+		v.Format = "ipv6"
+	}
+}
+
+// Числовые правила (int32, uint64, double, ...) устроены одинаково,
+// поэтому читаем их через protoreflect, а не отдельным case на каждый тип.
+func applyNumericRules(rules *validate.FieldRules, v *FieldValidation) {
+	msg := rules.ProtoReflect()
+	oneof := msg.Descriptor().Oneofs().ByName("type")
+	if oneof == nil {
+		return
+	}
+
+	active := msg.WhichOneof(oneof)
+	if active == nil || active.Message() == nil {
+		return
+	}
+
+	typed := msg.Get(active).Message()
+	fields := typed.Descriptor().Fields()
+
+	get := func(name protoreflect.Name) (float64, bool) {
+		fd := fields.ByName(name)
+		if fd == nil || fd.IsList() || !typed.Has(fd) {
+			return 0, false
+		}
+		return numericValue(typed.Get(fd), fd.Kind())
+	}
+
+	if c, ok := get("const"); ok {
+		v.Min, v.Max = &c, &c
+		return
+	}
+	if gt, ok := get("gt"); ok {
+		v.Min, v.ExclusiveMin = &gt, true
+	}
+	if gte, ok := get("gte"); ok {
+		v.Min, v.ExclusiveMin = &gte, false
+	}
+	if lt, ok := get("lt"); ok {
+		v.Max, v.ExclusiveMax = &lt, true
+	}
+	if lte, ok := get("lte"); ok {
+		v.Max, v.ExclusiveMax = &lte, false
+	}
+
+	if fd := fields.ByName("in"); fd != nil && fd.IsList() {
+		list := typed.Get(fd).List()
+		for i := 0; i < list.Len(); i++ {
+			if n, ok := numericValue(list.Get(i), fd.Kind()); ok {
+				v.In = append(v.In, strconv.FormatFloat(n, 'f', -1, 64))
+			}
+		}
+	}
+}
+
+func numericValue(value protoreflect.Value, kind protoreflect.Kind) (float64, bool) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return float64(value.Int()), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return float64(value.Uint()), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,187 @@
+package grpcreflect
+
+import (
+	"encoding/json"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	fieldMaskType     = "field_mask"
+	nullValueType     = "null_value"
+	nullValueEnumName = "google.protobuf.NullValue"
+
+	fieldMaskMaxDepth = 3
+)
+
+var wellKnownTypes = map[string]string{
+	"google.protobuf.Timestamp":   "timestamp",
+	"google.protobuf.Duration":    "duration",
+	"google.protobuf.Any":         "any",
+	"google.protobuf.Struct":      "struct",
+	"google.protobuf.Value":       "value",
+	"google.protobuf.ListValue":   "list_value",
+	"google.protobuf.Empty":       "empty",
+	"google.protobuf.FieldMask":   fieldMaskType,
+	"google.protobuf.DoubleValue": "double_value",
+	"google.protobuf.FloatValue":  "float_value",
+	"google.protobuf.Int64Value":  "int64_value",
+	"google.protobuf.UInt64Value": "uint64_value",
+	"google.protobuf.Int32Value":  "int32_value",
+	"google.protobuf.UInt32Value": "uint32_value",
+	"google.protobuf.BoolValue":   "bool_value",
+	"google.protobuf.StringValue": "string_value",
+	"google.protobuf.BytesValue":  "bytes_value",
+}
+
+// Обертки в JSON представлены значением вложенного скаляра.
+var wrapperScalarTypes = map[string]string{
+	"double_value": "double",
+	"float_value":  "float",
+	"int64_value":  "int64",
+	"uint64_value": "uint64",
+	"int32_value":  "int32",
+	"uint32_value": "uint32",
+	"bool_value":   "bool",
+	"string_value": "string",
+	"bytes_value":  "bytes",
+}
+
+func isWellKnownType(typeName string) (bool, string) {
+	wellKnownType, ok := wellKnownTypes[strings.TrimPrefix(typeName, ".")]
+	return ok, wellKnownType
+}
+
+// WrapperScalarType возвращает тип скаляра для оберток вроде google.protobuf.StringValue.
+func WrapperScalarType(wellKnownType string) (string, bool) {
+	scalar, ok := wrapperScalarTypes[wellKnownType]
+	return scalar, ok
+}
+
+// wellKnownExample возвращает пример в каноническом JSON представлении protojson.
+func wellKnownExample(field FieldInfo) (interface{}, bool) {
+	if scalar, ok := WrapperScalarType(field.WellKnownType); ok {
+		switch scalar {
+		// 64-битные целые protojson пишет строками
+		case "int64", "uint64":
+			return "0", true
+		default:
+			return getDefaultValueForType(scalar), true
+		}
+	}
+
+	switch field.WellKnownType {
+	case "timestamp":
+		return "2026-02-05T14:05:47Z", true
+	case "duration":
+		return "1.5s", true
+	case "empty", "struct":
+		return map[string]interface{}{}, true
+	case "value", nullValueType:
+		return nil, true
+	case "list_value":
+		return []interface{}{}, true
+	case "any":
+		return map[string]interface{}{"@type": ""}, true
+	case fieldMaskType:
+		return strings.Join(topLevelPaths(field.FieldMaskPaths), ","), true
+	default:
+		return nil, false
+	}
+}
+
+func wellKnownExampleJSON(field FieldInfo) (string, bool) {
+	if field.WellKnownType == "any" {
+		return `{"@type": ""}`, true
+	}
+
+	value, ok := wellKnownExample(field)
+	if !ok {
+		return "", false
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// fieldMaskTarget выбирает сообщение, к которому относится FieldMask.
+// По соглашению AIP-134 это соседнее поле-сообщение (UpdateXRequest{x, update_mask}).
+func fieldMaskTarget(fields []FieldInfo) *MessageInfo {
+	for _, field := range fields {
+		if field.Message != nil && !field.IsWellKnown && !field.Repeated && !field.IsMap {
+			return field.Message
+		}
+	}
+	return nil
+}
+
+func fieldMaskTargetDescriptor(msgDesc protoreflect.MessageDescriptor) protoreflect.MessageDescriptor {
+	fields := msgDesc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() == nil || field.IsMap() || field.IsList() {
+			continue
+		}
+		if isWellKnown, _ := isWellKnownType(string(field.Message().FullName())); !isWellKnown {
+			return field.Message()
+		}
+	}
+	return nil
+}
+
+// fieldMaskPaths перечисляет пути сообщения в JSON форме FieldMask (lowerCamelCase через точку).
+func fieldMaskPaths(msg *MessageInfo) []string {
+	var paths []string
+
+	var walk func(prefix string, msg *MessageInfo, depth int, visited map[string]bool)
+	walk = func(prefix string, msg *MessageInfo, depth int, visited map[string]bool) {
+		if msg == nil || depth > fieldMaskMaxDepth || visited[msg.Name] {
+			return
+		}
+		visited[msg.Name] = true
+		defer delete(visited, msg.Name)
+
+		for _, field := range msg.Fields {
+			path := prefix + snakeToLowerCamel(field.Name)
+			paths = append(paths, path)
+			if field.Message != nil && !field.IsWellKnown && !field.Repeated && !field.IsMap {
+				walk(path+".", field.Message, depth+1, visited)
+			}
+		}
+	}
+
+	walk("", msg, 0, make(map[string]bool))
+
+	return paths
+}
+
+func topLevelPaths(paths []string) []string {
+	var result []string
+	for _, path := range paths {
+		if !strings.Contains(path, ".") {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+func snakeToLowerCamel(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,154 @@
+package grpcreflect
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"grpc-gui/internal/utils"
+	"grpc-gui/testserver/proto"
+)
+
+func updateUserMethod(t *testing.T) *MethodInfo {
+	addr, cleanup := startTestServer(t)
+	t.Cleanup(cleanup)
+
+	reflector, err := NewReflector(context.Background(), addr, &utils.GRPCConnectOptions{UseTLS: false})
+	if err != nil {
+		t.Fatalf("NewReflector failed: %v", err)
+	}
+	defer reflector.Close()
+
+	servicesInfo, err := reflector.GetAllServicesInfo()
+	if err != nil {
+		t.Fatalf("GetAllServicesInfo failed: %v", err)
+	}
+
+	for _, service := range servicesInfo.Services {
+		for i := range service.Methods {
+			if service.Name == "testserver.AnotherService" && service.Methods[i].Name == "UpdateUser" {
+				return &service.Methods[i]
+			}
+		}
+	}
+
+	t.Fatal("UpdateUser method not found")
+	return nil
+}
+
+func TestWellKnownTypes_Extraction(t *testing.T) {
+	method := updateUserMethod(t)
+
+	expected := map[string]string{
+		"update_mask": "field_mask",
+		"comment":     "string_value",
+		"version":     "int64_value",
+		"notify":      "bool_value",
+		"score":       "double_value",
+		"signature":   "bytes_value",
+		"attributes":  "struct",
+		"nothing":     "null_value",
+	}
+
+	fields := make(map[string]FieldInfo)
+	for _, field := range method.Request.Fields {
+		fields[field.Name] = field
+	}
+
+	for name, wellKnownType := range expected {
+		field := fields[name]
+		if !field.IsWellKnown || field.WellKnownType != wellKnownType {
+			t.Errorf("field %s: expected well-known type %s, got %q", name, wellKnownType, field.WellKnownType)
+		}
+		if field.Message != nil {
+			t.Errorf("field %s: well-known type should not be expanded as a nested message", name)
+		}
+	}
+
+	paths := strings.Join(fields["update_mask"].FieldMaskPaths, ",")
+	for _, path := range []string{"name", "signedPoints", "address.city"} {
+		if !strings.Contains(","+paths+",", ","+path+",") {
+			t.Errorf("expected FieldMask path %q in %s", path, paths)
+		}
+	}
+}
+
+func TestWellKnownTypes_ExamplesAreCanonicalJSON(t *testing.T) {
+	method := updateUserMethod(t)
+
+	var req proto.UpdateUserRequest
+	if err := protojson.Unmarshal(method.RequestExample, &req); err != nil {
+		t.Fatalf("request example is not valid protojson: %v\n%s", err, method.RequestExample)
+	}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		t.Errorf("expected update_mask example to contain paths, got %s", method.RequestExample)
+	}
+
+	commented := stripLineComments(method.RequestExampleString)
+	if err := protojson.Unmarshal([]byte(commented), &req); err != nil {
+		t.Fatalf("commented request example is not valid protojson: %v\n%s", err, commented)
+	}
+
+	for seed := int64(0); seed < 5; seed++ {
+		data, err := GenerateFakeJSONExample(method.Request, seed)
+		if err != nil {
+			t.Fatalf("GenerateFakeJSONExample failed: %v", err)
+		}
+		if err := protojson.Unmarshal(data, &req); err != nil {
+			t.Fatalf("seed %d: fake example is not valid protojson: %v\n%s", seed, err, data)
+		}
+	}
+}
+
+func TestWellKnownExample_Wrappers(t *testing.T) {
+	msg := &MessageInfo{
+		Name: "testserver.Wrappers",
+		Fields: []FieldInfo{
+			{Name: "count", IsWellKnown: true, WellKnownType: "int64_value"},
+			{Name: "ratio", IsWellKnown: true, WellKnownType: "float_value"},
+			{Name: "label", IsWellKnown: true, WellKnownType: "string_value"},
+			{Name: "mask", IsWellKnown: true, WellKnownType: "field_mask", FieldMaskPaths: []string{"name", "address", "address.city"}},
+		},
+	}
+
+	data, err := GenerateJSONExample(msg)
+	if err != nil {
+		t.Fatalf("GenerateJSONExample failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if result["count"] != "0" {
+		t.Errorf("expected Int64Value as string, got %v", result["count"])
+	}
+	if result["ratio"] != float64(0) {
+		t.Errorf("expected FloatValue as number, got %v", result["ratio"])
+	}
+	if result["label"] != "" {
+		t.Errorf("expected StringValue as string, got %v", result["label"])
+	}
+	if result["mask"] != "name,address" {
+		t.Errorf("expected top-level FieldMask paths, got %v", result["mask"])
+	}
+}
+
+func TestSnakeToLowerCamel(t *testing.T) {
+	cases := map[string]string{
+		"name":          "name",
+		"signed_points": "signedPoints",
+		"address_map":   "addressMap",
+		"x_1":           "x1",
+	}
+
+	for in, expected := range cases {
+		if got := snakeToLowerCamel(in); got != expected {
+			t.Errorf("snakeToLowerCamel(%q) = %q, want %q", in, got, expected)
+		}
+	}
+}
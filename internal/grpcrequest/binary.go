@@ -0,0 +1,208 @@
+package grpcrequest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+
+	"grpc-gui/internal/grpcreflect"
+)
+
+const (
+	binarySuffix = "-bin"
+	hexPrefix    = "hex:"
+)
+
+// BinaryMetadataTypes - типы сообщений для известных бинарных ключей метаданных.
+var BinaryMetadataTypes = map[string]string{
+	"grpc-status-details-bin": "google.rpc.Status",
+}
+
+// BinaryMetadata - бинарное значение заголовка или трейлера в читаемом виде.
+type BinaryMetadata struct {
+	Trailer bool   `json:"trailer,omitempty"`
+	Key     string `json:"key"`
+	Base64  string `json:"base64"`
+	Hex     string `json:"hex"`
+	// Type и JSON заполнены, если значение разобралось как сообщение известного типа
+	Type string `json:"type,omitempty"`
+	JSON string `json:"json,omitempty"`
+}
+
+func isBinaryKey(key string) bool {
+	return strings.HasSuffix(strings.ToLower(key), binarySuffix)
+}
+
+// decodeBinaryValue разбирает значение -bin заголовка из ввода пользователя:
+// hex только с явным префиксом "hex:", иначе base64 (с паддингом или без, URL-safe).
+// Префикс "0x" не поддерживается: такая строка сама может быть корректным base64.
+func decodeBinaryValue(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+
+	if len(value) >= len(hexPrefix) && strings.EqualFold(value[:len(hexPrefix)], hexPrefix) {
+		data, err := hex.DecodeString(strings.ReplaceAll(value[len(hexPrefix):], " ", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex value: %w", err)
+		}
+		return data, nil
+	}
+
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := enc.DecodeString(value); err == nil {
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid base64 value, use the %q prefix for hex", hexPrefix)
+}
+
+// outgoingMetadata собирает метаданные вызова из заголовков и значений контекста.
+// Значения -bin ключей приходят текстом и переводятся в байты, base64 на проводе добавит сам grpc.
+func (rc *RequestContext) outgoingMetadata(requestHeaders map[string]string) (metadata.MD, error) {
+	md := metadata.MD{}
+
+	add := func(key, value string) error {
+		if strings.TrimSpace(key) == "" {
+			return nil
+		}
+		if isBinaryKey(key) {
+			data, err := decodeBinaryValue(value)
+			if err != nil {
+				return fmt.Errorf("metadata %s: %w", key, err)
+			}
+			value = string(data)
+		}
+		md.Append(key, value)
+		return nil
+	}
+
+	for _, key := range sortedKeys(requestHeaders) {
+		if err := add(key, requestHeaders[key]); err != nil {
+			return nil, err
+		}
+	}
+	if rc != nil {
+		for _, key := range sortedKeys(rc.Metadata) {
+			if err := add(key, rc.Metadata[key]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return md, nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MetadataForDisplay копирует метаданные, заменяя бинарные значения на base64.
+func MetadataForDisplay(md metadata.MD) map[string][]string {
+	if md == nil {
+		return nil
+	}
+
+	display := make(map[string][]string, len(md))
+	for key, values := range md {
+		if !isBinaryKey(key) {
+			display[key] = values
+			continue
+		}
+		encoded := make([]string, len(values))
+		for i, value := range values {
+			encoded[i] = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+		display[key] = encoded
+	}
+	return display
+}
+
+// decodeBinaryMetadata расшифровывает бинарные заголовки и трейлеры ответа.
+func decodeBinaryMetadata(headers, trailers metadata.MD, resolver grpcreflect.TypeResolver) []BinaryMetadata {
+	var items []BinaryMetadata
+
+	collect := func(md metadata.MD, trailer bool) {
+		keys := make([]string, 0, len(md))
+		for key := range md {
+			if isBinaryKey(key) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			for _, value := range md[key] {
+				item := BinaryMetadata{
+					Trailer: trailer,
+					Key:     key,
+					Base64:  base64.StdEncoding.EncodeToString([]byte(value)),
+					Hex:     hex.EncodeToString([]byte(value)),
+				}
+				if typeName, ok := BinaryMetadataTypes[key]; ok {
+					if decoded, err := decodeBinaryMessage([]byte(value), typeName, resolver); err == nil {
+						item.Type = typeName
+						item.JSON = decoded
+					}
+				}
+				items = append(items, item)
+			}
+		}
+	}
+
+	collect(headers, false)
+	collect(trailers, true)
+	return items
+}
+
+// decodeBinaryMessage разбирает значение как сообщение. google.rpc.Status раскладывается
+// вместе с деталями, чтобы одна неизвестная деталь не ломала весь вывод.
+func decodeBinaryMessage(data []byte, typeName string, resolver grpcreflect.TypeResolver) (string, error) {
+	if typeName == "google.rpc.Status" {
+		st := &spb.Status{}
+		if err := proto.Unmarshal(data, st); err != nil {
+			return "", err
+		}
+
+		decoded := map[string]any{"code": st.GetCode(), "message": st.GetMessage()}
+		if details := decodeStatusDetails(status.FromProto(st), resolver); details != "" {
+			decoded["details"] = json.RawMessage(details)
+		}
+		out, err := json.Marshal(decoded)
+		return string(out), err
+	}
+
+	mt, err := resolver.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		return "", err
+	}
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return "", err
+	}
+
+	out, err := (protojson.MarshalOptions{Resolver: resolver}).Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, out); err != nil {
+		return "", err
+	}
+	return compact.String(), nil
+}
@@ -0,0 +1,110 @@
+package grpcrequest
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"grpc-gui/internal/utils"
+)
+
+func TestDecodeBinaryValue(t *testing.T) {
+	want := "\x00\xffhi"
+	cases := []string{
+		base64.StdEncoding.EncodeToString([]byte(want)),
+		base64.RawStdEncoding.EncodeToString([]byte(want)),
+		base64.RawURLEncoding.EncodeToString([]byte(want)),
+		"hex:00ff6869",
+		"HEX:00FF6869",
+		" hex:00 ff 68 69 ",
+	}
+	for _, value := range cases {
+		got, err := decodeBinaryValue(value)
+		if err != nil || string(got) != want {
+			t.Errorf("%q: expected %q, got %q (%v)", value, want, got, err)
+		}
+	}
+
+	// Без префикса значение всегда base64, даже если похоже на hex
+	for value, want := range map[string]string{"deadbeef": "\x75\xe6\x9d\x6d\xe7\x9f", "0xAB": "\xd3\x10\x01"} {
+		if got, err := decodeBinaryValue(value); err != nil || string(got) != want {
+			t.Errorf("%q: expected base64 %x, got %x (%v)", value, want, got, err)
+		}
+	}
+
+	for _, value := range []string{"hex:zz", "not base64!"} {
+		if _, err := decodeBinaryValue(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestMetadataForDisplay(t *testing.T) {
+	md := metadata.Pairs("x-trace-bin", "\x01\x02", "x-plain", "text")
+	display := MetadataForDisplay(md)
+	if display["x-trace-bin"][0] != "AQI=" || display["x-plain"][0] != "text" {
+		t.Errorf("unexpected display metadata: %v", display)
+	}
+	if MetadataForDisplay(nil) != nil {
+		t.Error("expected nil for nil metadata")
+	}
+}
+
+// echoBinaryInterceptor возвращает полученный x-trace-bin заголовком ответа.
+func echoBinaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-trace-bin"); len(values) > 0 {
+		grpc.SetHeader(ctx, metadata.Pairs("x-trace-bin", values[0]))
+	}
+	return handler(ctx, req)
+}
+
+func TestDoGRPCRequest_BinaryMetadata(t *testing.T) {
+	addr, stop := startTestServer(t, grpc.UnaryInterceptor(echoBinaryInterceptor))
+	defer stop()
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	headers := map[string]string{"x-trace-bin": "hex:cafe00"}
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", `{}`, headers, nil, opts)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+
+	if got := reply.Headers.Get("x-trace-bin"); len(got) != 1 || got[0] != "\xca\xfe\x00" {
+		t.Errorf("expected raw bytes to reach server and come back, got %q", got)
+	}
+	if len(reply.BinaryMetadata) != 1 || reply.BinaryMetadata[0].Hex != "cafe00" || reply.BinaryMetadata[0].Trailer {
+		t.Errorf("unexpected binary metadata: %+v", reply.BinaryMetadata)
+	}
+
+	_, err = DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", `{}`, map[string]string{"x-trace-bin": "%%%"}, nil, opts)
+	if err == nil || !strings.Contains(err.Error(), "x-trace-bin") {
+		t.Errorf("expected invalid binary header error, got %v", err)
+	}
+}
+
+func TestDoGRPCRequest_StatusDetailsTrailer(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, _ := DoGRPCRequest(addr, "testserver.TestService", "ScheduleTask", `{}`, nil, nil, opts)
+
+	var found *BinaryMetadata
+	for i := range reply.BinaryMetadata {
+		if reply.BinaryMetadata[i].Key == "grpc-status-details-bin" {
+			found = &reply.BinaryMetadata[i]
+		}
+	}
+	if found == nil || !found.Trailer {
+		t.Fatalf("expected grpc-status-details-bin trailer, got %+v", reply.BinaryMetadata)
+	}
+	if found.Type != "google.rpc.Status" || !strings.Contains(found.JSON, `"message":"task is invalid"`) ||
+		!strings.Contains(found.JSON, "google.rpc.BadRequest") {
+		t.Errorf("expected decoded status, got %s %s", found.Type, found.JSON)
+	}
+}
@@ -0,0 +1,188 @@
+package grpcrequest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// DefaultTimeout - дедлайн вызова, если в контексте запроса он не задан.
+const DefaultTimeout = 30 * time.Second
+
+const (
+	CredentialsBearer = "bearer"
+	CredentialsBasic  = "basic"
+)
+
+// RequestContext - параметры вызова, которые реально уходят на сервер.
+type RequestContext struct {
+	// Timeout - дедлайн вызова в формате time.ParseDuration, например "500ms" или "5s"
+	Timeout string `json:"timeout,omitempty"`
+	// Compression - компрессор запроса, поддерживается gzip
+	Compression  string           `json:"compression,omitempty"`
+	WaitForReady bool             `json:"waitForReady,omitempty"`
+	Credentials  *CallCredentials `json:"credentials,omitempty"`
+	// Metadata - значения контекста, которые передаются серверу метаданными вызова
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// CallCredentials - учетные данные вызова, отправляются заголовком authorization.
+type CallCredentials struct {
+	Type     string `json:"type"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// IsZero - ничего не задано, вызов идет с настройками по умолчанию.
+func (rc *RequestContext) IsZero() bool {
+	return rc == nil || (rc.Timeout == "" && rc.Compression == "" && !rc.WaitForReady &&
+		rc.Credentials == nil && len(rc.Metadata) == 0)
+}
+
+// timeout разбирает дедлайн. Число без единиц считается миллисекундами.
+func (rc *RequestContext) timeout() (time.Duration, error) {
+	if rc == nil || strings.TrimSpace(rc.Timeout) == "" {
+		return DefaultTimeout, nil
+	}
+
+	value := strings.TrimSpace(rc.Timeout)
+	if ms, err := strconv.Atoi(value); err == nil {
+		value = fmt.Sprintf("%dms", ms)
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", rc.Timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout must be positive, got %q", rc.Timeout)
+	}
+	return d, nil
+}
+
+// callOptions собирает опции grpc для вызова и проверяет, что все значения корректны.
+func (rc *RequestContext) callOptions() ([]grpc.CallOption, error) {
+	if rc == nil {
+		return nil, nil
+	}
+
+	var opts []grpc.CallOption
+
+	if rc.Compression != "" {
+		if encoding.GetCompressor(rc.Compression) == nil {
+			return nil, fmt.Errorf("unsupported compression %q", rc.Compression)
+		}
+		opts = append(opts, grpc.UseCompressor(rc.Compression))
+	}
+
+	if rc.WaitForReady {
+		opts = append(opts, grpc.WaitForReady(true))
+	}
+
+	if rc.Credentials != nil {
+		creds, err := rc.Credentials.perRPC()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.PerRPCCredentials(creds))
+	}
+
+	return opts, nil
+}
+
+func (c *CallCredentials) perRPC() (credentials.PerRPCCredentials, error) {
+	switch strings.ToLower(c.Type) {
+	case CredentialsBearer:
+		if c.Token == "" {
+			return nil, fmt.Errorf("bearer credentials require a token")
+		}
+		return authorizationCredentials("Bearer " + c.Token), nil
+	case CredentialsBasic:
+		if c.Username == "" {
+			return nil, fmt.Errorf("basic credentials require a username")
+		}
+		token := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+		return authorizationCredentials("Basic " + token), nil
+	default:
+		return nil, fmt.Errorf("unsupported credentials type %q", c.Type)
+	}
+}
+
+// authorizationCredentials не требует TLS: GUI часто ходит в локальные серверы без шифрования.
+type authorizationCredentials string
+
+func (a authorizationCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": string(a)}, nil
+}
+
+func (a authorizationCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// RequestContextFromValues переводит старые произвольные значения контекста в типизированный вид.
+// Известные ключи (timeout, deadline, compression, wait-for-ready, authorization) становятся
+// параметрами вызова, остальные уходят в метаданные.
+func RequestContextFromValues(values map[string]string) *RequestContext {
+	rc := &RequestContext{}
+
+	for key, value := range values {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		switch strings.NewReplacer("_", "-").Replace(normalized) {
+		case "":
+			continue
+		case "timeout", "deadline":
+			rc.Timeout = strings.TrimSpace(value)
+		case "compression", "grpc-encoding":
+			rc.Compression = strings.ToLower(strings.TrimSpace(value))
+		case "wait-for-ready", "waitforready":
+			rc.WaitForReady, _ = strconv.ParseBool(strings.TrimSpace(value))
+		case "authorization":
+			if creds := parseAuthorization(value); creds != nil {
+				rc.Credentials = creds
+				continue
+			}
+			rc.setMetadata(normalized, value)
+		default:
+			rc.setMetadata(normalized, value)
+		}
+	}
+
+	return rc
+}
+
+func (rc *RequestContext) setMetadata(key, value string) {
+	if rc.Metadata == nil {
+		rc.Metadata = make(map[string]string)
+	}
+	rc.Metadata[key] = value
+}
+
+func parseAuthorization(value string) *CallCredentials {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(value), " ")
+	if !ok || strings.TrimSpace(token) == "" {
+		return nil
+	}
+	token = strings.TrimSpace(token)
+
+	switch strings.ToLower(scheme) {
+	case CredentialsBearer:
+		return &CallCredentials{Type: CredentialsBearer, Token: token}
+	case CredentialsBasic:
+		decoded, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return &CallCredentials{Type: CredentialsBasic, Username: username, Password: password}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package grpcrequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"grpc-gui/internal/utils"
+)
+
+type capturedCall struct {
+	md       metadata.MD
+	deadline time.Duration
+}
+
+func captureInterceptor(calls chan<- capturedCall) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		call := capturedCall{}
+		call.md, _ = metadata.FromIncomingContext(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			call.deadline = time.Until(deadline)
+		}
+		calls <- call
+		return handler(ctx, req)
+	}
+}
+
+func TestDoGRPCRequest_RequestContext(t *testing.T) {
+	calls := make(chan capturedCall, 1)
+	addr, stop := startTestServer(t, grpc.UnaryInterceptor(captureInterceptor(calls)))
+	defer stop()
+
+	reqContext := &RequestContext{
+		Timeout:      "2s",
+		Compression:  "gzip",
+		WaitForReady: true,
+		Credentials:  &CallCredentials{Type: CredentialsBearer, Token: "secret"},
+		Metadata:     map[string]string{"x-tenant": "acme"},
+	}
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", `{"message":"hi"}`,
+		map[string]string{"x-custom": "value"}, reqContext, opts)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if reply.Code != codes.OK {
+		t.Fatalf("expected OK, got %v", reply.Code)
+	}
+
+	call := <-calls
+	if got := call.md.Get("x-tenant"); len(got) != 1 || got[0] != "acme" {
+		t.Errorf("expected context metadata, got %v", call.md)
+	}
+	if got := call.md.Get("x-custom"); len(got) != 1 || got[0] != "value" {
+		t.Errorf("expected request headers, got %v", call.md)
+	}
+	if got := call.md.Get("authorization"); len(got) != 1 || got[0] != "Bearer secret" {
+		t.Errorf("expected call credentials, got %v", got)
+	}
+	if call.deadline <= 0 || call.deadline > 2*time.Second {
+		t.Errorf("expected deadline within 2s, got %v", call.deadline)
+	}
+}
+
+func TestDoGRPCRequest_InvalidRequestContext(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	cases := map[string]*RequestContext{
+		"timeout":     {Timeout: "soon"},
+		"compression": {Compression: "brotli"},
+		"credentials": {Credentials: &CallCredentials{Type: CredentialsBearer}},
+	}
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	for name, reqContext := range cases {
+		reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", `{}`, nil, reqContext, opts)
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+			continue
+		}
+		if reply.Code != codes.InvalidArgument || reply.ErrorKind != ErrorKindContext {
+			t.Errorf("%s: expected context error, got %v %q", name, reply.Code, reply.ErrorKind)
+		}
+	}
+}
+
+func TestRequestContextTimeout(t *testing.T) {
+	cases := map[string]time.Duration{
+		"":      DefaultTimeout,
+		"1500":  1500 * time.Millisecond,
+		"250ms": 250 * time.Millisecond,
+		" 5s ":  5 * time.Second,
+	}
+	for value, want := range cases {
+		got, err := (&RequestContext{Timeout: value}).timeout()
+		if err != nil || got != want {
+			t.Errorf("timeout %q: expected %v, got %v (%v)", value, want, got, err)
+		}
+	}
+
+	if _, err := (&RequestContext{Timeout: "-1s"}).timeout(); err == nil {
+		t.Error("expected error for negative timeout")
+	}
+}
+
+func TestRequestContextFromValues(t *testing.T) {
+	rc := RequestContextFromValues(map[string]string{
+		"Timeout":        "500",
+		"compression":    "GZIP",
+		"wait_for_ready": "true",
+		"authorization":  "Basic dXNlcjpwYXNz",
+		"User-ID":        "123",
+		"":               "ignored",
+	})
+
+	if rc.Timeout != "500" || rc.Compression != "gzip" || !rc.WaitForReady {
+		t.Errorf("unexpected typed values: %+v", rc)
+	}
+	if rc.Credentials == nil || rc.Credentials.Type != CredentialsBasic ||
+		rc.Credentials.Username != "user" || rc.Credentials.Password != "pass" {
+		t.Errorf("expected basic credentials, got %+v", rc.Credentials)
+	}
+	if len(rc.Metadata) != 1 || rc.Metadata["user-id"] != "123" {
+		t.Errorf("expected remaining values in metadata, got %v", rc.Metadata)
+	}
+
+	// Непонятная схема авторизации остается заголовком
+	rc = RequestContextFromValues(map[string]string{"authorization": "Token abc"})
+	if rc.Credentials != nil || rc.Metadata["authorization"] != "Token abc" {
+		t.Errorf("expected authorization in metadata, got %+v", rc)
+	}
+
+	if !RequestContextFromValues(nil).IsZero() {
+		t.Error("expected empty context for no values")
+	}
+}
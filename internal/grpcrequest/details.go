@@ -0,0 +1,44 @@
+package grpcrequest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+
+	// Регистрирует стандартные типы деталей: BadRequest, RetryInfo, ErrorInfo и другие
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"grpc-gui/internal/grpcreflect"
+)
+
+// decodeStatusDetails переводит google.rpc.Status.details в JSON массив.
+// Типы ищутся среди стандартных и через рефлексию сервера, неизвестные остаются base64.
+func decodeStatusDetails(st *status.Status, resolver grpcreflect.TypeResolver) string {
+	details := st.Proto().GetDetails()
+	if len(details) == 0 {
+		return ""
+	}
+
+	items := make([]json.RawMessage, 0, len(details))
+	for _, detail := range details {
+		data, err := (protojson.MarshalOptions{Resolver: resolver}).Marshal(detail)
+		if err == nil {
+			var compact bytes.Buffer
+			if json.Compact(&compact, data) == nil {
+				items = append(items, compact.Bytes())
+				continue
+			}
+		}
+
+		raw, _ := json.Marshal(map[string]string{
+			"@type": detail.GetTypeUrl(),
+			"value": base64.StdEncoding.EncodeToString(detail.GetValue()),
+		})
+		items = append(items, raw)
+	}
+
+	data, _ := json.Marshal(items)
+	return string(data)
+}
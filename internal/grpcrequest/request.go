@@ -1,7 +1,9 @@
 package grpcrequest
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -9,17 +11,18 @@ import (
 	"grpc-gui/internal/utils"
 
 	"github.com/jhump/protoreflect/desc"
-	"github.com/jhump/protoreflect/dynamic"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	reflectpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/reflect/protoregistry"
 	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func getMethodDescriptorLowLevel(ctx context.Context, conn *grpc.ClientConn, serviceName, methodName string) (*desc.MethodDescriptor, error) {
@@ -125,30 +128,80 @@ func getMethodDescriptorLowLevel(ctx context.Context, conn *grpc.ClientConn, ser
 	return nil, fmt.Errorf("method %s not found in service %s", methodName, serviceName)
 }
 
-func DoGRPCRequest(address, service, method, payload string, requestHeaders, contextValues map[string]string, opts *utils.GRPCConnectOptions) (string, codes.Code, map[string][]string, int32, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+// ErrorKind - на каком этапе сломался вызов.
+type ErrorKind string
+
+const (
+	// ErrorKindDial - не удалось подключиться к серверу
+	ErrorKindDial ErrorKind = "dial"
+	// ErrorKindContext - некорректные параметры вызова: таймаут, компрессия, учетные данные, значения -bin заголовков
+	ErrorKindContext ErrorKind = "context"
+	// ErrorKindReflection - не удалось найти метод через рефлексию
+	ErrorKindReflection ErrorKind = "reflection"
+	// ErrorKindPayload - тело запроса не разбирается как JSON сообщения
+	ErrorKindPayload ErrorKind = "payload"
+	// ErrorKindTransport - соединение оборвалось или сервер недоступен во время вызова
+	ErrorKindTransport ErrorKind = "transport"
+	// ErrorKindDeadline - вызов не уложился в таймаут
+	ErrorKindDeadline ErrorKind = "deadline"
+	// ErrorKindStatus - сервер ответил ошибкой со статусом
+	ErrorKindStatus ErrorKind = "status"
+	// ErrorKindResponse - не удалось разобрать ответ сервера
+	ErrorKindResponse ErrorKind = "response"
+	// ErrorKindTemplate - не удалось подставить переменные окружения, запрос не отправлялся
+	ErrorKindTemplate ErrorKind = "template"
+	// ErrorKindScript - pre-request скрипт завершился ошибкой, запрос не отправлялся
+	ErrorKindScript ErrorKind = "script"
+)
 
-	if len(contextValues) > 0 {
-		for k, v := range contextValues {
-			ctx = context.WithValue(ctx, k, v)
-		}
-	}
+// Result - ответ вызова вместе с метаданными. Код, время и причина ошибки заполняются и при неудаче.
+type Result struct {
+	Response      string
+	Code          codes.Code
+	Headers       metadata.MD
+	Trailers      metadata.MD
+	ExecutionTime int32
+	// StatusDetails - JSON массив google.rpc.Status.details, каждый элемент в JSON форме Any
+	StatusDetails string
+	// StatusMessage - сообщение статуса от сервера или текст ошибки до вызова
+	StatusMessage string
+	ErrorKind     ErrorKind
+	// BinaryMetadata - расшифровка -bin заголовков и трейлеров ответа
+	BinaryMetadata []BinaryMetadata
+}
+
+func failed(code codes.Code, kind ErrorKind, err error) (*Result, error) {
+	return &Result{Code: code, ErrorKind: kind, StatusMessage: err.Error()}, err
+}
 
-	if len(requestHeaders) > 0 {
-		md := metadata.New(requestHeaders)
-		ctx = metadata.NewOutgoingContext(ctx, md)
+func DoGRPCRequest(address, service, method, payload string, requestHeaders map[string]string, reqContext *RequestContext, opts *utils.GRPCConnectOptions) (*Result, error) {
+	timeout, err := reqContext.timeout()
+	if err != nil {
+		return failed(codes.InvalidArgument, ErrorKindContext, err)
+	}
+	callOpts, err := reqContext.callOptions()
+	if err != nil {
+		return failed(codes.InvalidArgument, ErrorKindContext, err)
+	}
+	md, err := reqContext.outgoingMetadata(requestHeaders)
+	if err != nil {
+		return failed(codes.InvalidArgument, ErrorKindContext, err)
 	}
 
+	// Рефлексия не должна съедать дедлайн вызова, поэтому у нее свой таймаут
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
 	conn, err := utils.CreateGRPCConnect(address, opts)
 	if err != nil {
-		return "", codes.Unavailable, nil, 0, fmt.Errorf("failed to dial: %w", err)
+		return failed(codes.Unavailable, ErrorKindDial, fmt.Errorf("failed to dial: %w", err))
 	}
 	defer conn.Close()
 
 	reflector, err := grpcreflect.NewReflector(ctx, address, opts)
 	if err != nil {
-		return "", codes.Unknown, nil, 0, fmt.Errorf("failed to create reflector: %w", err)
+		return failed(codes.Unavailable, ErrorKindDial, fmt.Errorf("failed to create reflector: %w", err))
 	}
 	defer reflector.Close()
 
@@ -158,48 +211,89 @@ func DoGRPCRequest(address, service, method, payload string, requestHeaders, con
 	if err != nil {
 		methodDesc, err = getMethodDescriptorLowLevel(ctx, conn, service, method)
 		if err != nil {
-			return "", codes.NotFound, nil, 0, fmt.Errorf("failed to resolve method: %w", err)
+			if utils.IsConnectionError(err) {
+				return failed(codes.Unavailable, ErrorKindDial, fmt.Errorf("failed to resolve method: %w", err))
+			}
+			return failed(codes.NotFound, ErrorKindReflection, fmt.Errorf("failed to resolve method: %w", err))
 		}
 	} else {
 		methodDesc = serviceDesc.FindMethodByName(method)
 		if methodDesc == nil {
-			return "", codes.NotFound, nil, 0, fmt.Errorf("method %s not found", method)
+			return failed(codes.NotFound, ErrorKindReflection, fmt.Errorf("method %s not found", method))
 		}
 	}
 
-	reqMsg := dynamic.NewMessage(methodDesc.GetInputType())
+	// Типы внутри google.protobuf.Any ищем через рефлексию сервера
+	resolver := reflector.TypeResolver()
+
+	reqMsg := dynamicpb.NewMessage(methodDesc.GetInputType().UnwrapMessage())
 
 	if payload != "" {
-		if err := reqMsg.UnmarshalJSON([]byte(payload)); err != nil {
-			return "", codes.InvalidArgument, nil, 0, fmt.Errorf("failed to parse payload: %w", err)
+		if err := (protojson.UnmarshalOptions{Resolver: resolver}).Unmarshal([]byte(payload), reqMsg); err != nil {
+			return failed(codes.InvalidArgument, ErrorKindPayload, fmt.Errorf("failed to parse payload: %w", err))
 		}
 	}
 
 	methodPath := fmt.Sprintf("/%s/%s", service, method)
-	respMsg := dynamic.NewMessage(methodDesc.GetOutputType())
+	respMsg := dynamicpb.NewMessage(methodDesc.GetOutputType().UnwrapMessage())
+
+	result := &Result{}
 
-	var responseHeaders metadata.MD
-	var trailer metadata.MD
+	callCtx, callCancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), md), timeout)
+	defer callCancel()
+	callOpts = append(callOpts, grpc.Header(&result.Headers), grpc.Trailer(&result.Trailers))
 
 	startTime := time.Now()
-	err = conn.Invoke(ctx, methodPath, reqMsg, respMsg, grpc.Header(&responseHeaders), grpc.Trailer(&trailer))
-	executionTime := int32(time.Since(startTime).Milliseconds())
+	err = conn.Invoke(callCtx, methodPath, reqMsg, respMsg, callOpts...)
+	result.ExecutionTime = int32(time.Since(startTime).Milliseconds())
+	result.BinaryMetadata = decodeBinaryMetadata(result.Headers, result.Trailers, resolver)
 
 	if err != nil {
 		st, ok := status.FromError(err)
 		if ok {
-			if len(trailer) > 0 {
-				return "", st.Code(), trailer, executionTime, err
-			}
-			return "", st.Code(), nil, executionTime, err
+			result.Code = st.Code()
+			result.StatusMessage = st.Message()
+			result.StatusDetails = decodeStatusDetails(st, resolver)
+			result.ErrorKind = statusErrorKind(st.Code())
+			return result, err
 		}
-		return "", codes.Unknown, nil, executionTime, fmt.Errorf("rpc call failed: %w", err)
+		result.Code = codes.Unknown
+		result.StatusMessage = err.Error()
+		result.ErrorKind = ErrorKindTransport
+		return result, fmt.Errorf("rpc call failed: %w", err)
 	}
 
-	respJSON, err := respMsg.MarshalJSON()
+	respJSON, err := (protojson.MarshalOptions{Resolver: resolver}).Marshal(respMsg)
 	if err != nil {
-		return "", codes.Internal, nil, executionTime, fmt.Errorf("failed to marshal response: %w", err)
+		result.Code = codes.Internal
+		result.ErrorKind = ErrorKindResponse
+		result.StatusMessage = err.Error()
+		return result, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return string(respJSON), codes.OK, responseHeaders, executionTime, nil
+	// protojson намеренно добавляет случайные пробелы, приводим к стабильному виду
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, respJSON); err != nil {
+		result.Code = codes.Internal
+		result.ErrorKind = ErrorKindResponse
+		result.StatusMessage = err.Error()
+		return result, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	result.Response = compact.String()
+	result.Code = codes.OK
+	return result, nil
+}
+
+// statusErrorKind отличает ошибки транспорта от ответа сервера. Unavailable и DeadlineExceeded
+// обычно выставляет сам клиент gRPC, когда сервер не ответил.
+func statusErrorKind(code codes.Code) ErrorKind {
+	switch code {
+	case codes.Unavailable, codes.Canceled:
+		return ErrorKindTransport
+	case codes.DeadlineExceeded:
+		return ErrorKindDeadline
+	default:
+		return ErrorKindStatus
+	}
 }
@@ -7,14 +7,21 @@ import (
 	"testing"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"grpc-gui/internal/utils"
 	"grpc-gui/testserver/proto"
 )
 
-func startTestServer(t *testing.T) (string, func()) {
+func startTestServer(t *testing.T, opts ...grpc.ServerOption) (string, func()) {
 	lis, err := net.Listen("tcp", ":0")
 	if err != nil {
 		t.Fatalf("failed to listen: %v", err)
@@ -22,9 +29,12 @@ func startTestServer(t *testing.T) (string, func()) {
 
 	addr := lis.Addr().String()
 
-	s := grpc.NewServer()
+	s := grpc.NewServer(opts...)
 	proto.RegisterTestServiceServer(s, &testServer{})
 	proto.RegisterAnotherServiceServer(s, &anotherServer{})
+	proto.RegisterEventServiceServer(s, &eventServer{})
+	proto.RegisterLegacyServiceServer(s, &legacyServer{})
+	proto.RegisterEditionsServiceServer(s, &editionsServer{})
 	reflection.Register(s)
 
 	go func() {
@@ -49,6 +59,59 @@ type anotherServer struct {
 	proto.UnimplementedAnotherServiceServer
 }
 
+type eventServer struct {
+	proto.UnimplementedEventServiceServer
+}
+
+type legacyServer struct {
+	proto.UnimplementedLegacyServiceServer
+}
+
+type editionsServer struct {
+	proto.UnimplementedEditionsServiceServer
+}
+
+func (s *legacyServer) Describe(ctx context.Context, req *proto.LegacyRequest) (*proto.LegacyResponse, error) {
+	return &proto.LegacyResponse{
+		Summary:     protobuf.String(req.GetName() + "/" + req.GetLocale()),
+		PageSize:    protobuf.Int32(req.GetPageSize()),
+		TraceTag:    protobuf.String(protobuf.GetExtension(req, proto.E_TraceTag).(string)),
+		RetryBudget: protobuf.Int32(protobuf.GetExtension(req, proto.E_RetryBudget).(int32)),
+	}, nil
+}
+
+func (s *editionsServer) Echo(ctx context.Context, req *proto.EditionsRequest) (*proto.EditionsResponse, error) {
+	return &proto.EditionsResponse{
+		Id:          protobuf.String(req.GetId()),
+		Count:       protobuf.Int32(req.GetCount()),
+		Label:       protobuf.String(req.GetLabel()),
+		NestedValue: protobuf.String(req.GetNested().GetValue()),
+		Limit:       protobuf.Int32(req.GetLimit()),
+		HasId:       protobuf.Bool(req.Id != nil),
+	}, nil
+}
+
+func (s *anotherServer) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.User, error) {
+	user := &proto.User{Id: 1, Name: "User1", Email: "user1@example.com"}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "name":
+			user.Name = req.GetUser().GetName()
+		case "email":
+			user.Email = req.GetUser().GetEmail()
+		}
+	}
+	return user, nil
+}
+
+func (s *eventServer) Publish(ctx context.Context, req *proto.Event) (*proto.Event, error) {
+	audit, err := anypb.New(&proto.AuditRecord{Actor: "testserver", Action: "publish"})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.Event{Id: req.Id, Payload: req.Payload, Details: append(req.Details, audit)}, nil
+}
+
 func (s *testServer) SimpleCall(ctx context.Context, req *proto.SimpleRequest) (*proto.SimpleResponse, error) {
 	return &proto.SimpleResponse{
 		Result:    "Echo: " + req.Message,
@@ -73,6 +136,27 @@ func (s *testServer) ComplexCall(ctx context.Context, req *proto.ComplexRequest)
 	}, nil
 }
 
+// ScheduleTask отвечает ошибкой с деталями и метаданными в заголовках и трейлерах.
+func (s *testServer) ScheduleTask(ctx context.Context, req *proto.ScheduleRequest) (*proto.ScheduleResponse, error) {
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "1", "x-request-id", "2"))
+	grpc.SetTrailer(ctx, metadata.Pairs("x-retry-reason", "quota"))
+
+	st := status.New(codes.InvalidArgument, "task is invalid")
+	st, err := st.WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "name", Description: "required"}}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(2 * time.Second)},
+		&errdetails.ErrorInfo{Reason: "QUOTA", Domain: "testserver"},
+		&proto.Address{City: "Moscow"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	withUnknown := st.Proto()
+	withUnknown.Details = append(withUnknown.Details, &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Detail", Value: []byte{1, 2}})
+	return nil, status.ErrorProto(withUnknown)
+}
+
 func (s *testServer) EmptyCall(ctx context.Context, req *proto.EmptyRequest) (*proto.EmptyResponse, error) {
 	return &proto.EmptyResponse{}, nil
 }
@@ -104,7 +188,8 @@ func TestDoGRPCRequest_SimpleCall(t *testing.T) {
 	payload := `{"message": "test", "value": 42}`
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	resp, code, _, _, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", payload, nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
@@ -130,7 +215,8 @@ func TestDoGRPCRequest_EmptyCall(t *testing.T) {
 	defer stop()
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	resp, code, _, _, err := DoGRPCRequest(addr, "testserver.TestService", "EmptyCall", "", nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "EmptyCall", "", nil, nil, opts)
+	resp, code := reply.Response, reply.Code
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
@@ -160,7 +246,8 @@ func TestDoGRPCRequest_ComplexCall(t *testing.T) {
 	}`
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	resp, code, _, _, err := DoGRPCRequest(addr, "testserver.TestService", "ComplexCall", payload, nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "ComplexCall", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
@@ -194,7 +281,8 @@ func TestDoGRPCRequest_GetUser(t *testing.T) {
 	payload := `{"message": "John Doe"}`
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	resp, code, _, _, err := DoGRPCRequest(addr, "testserver.AnotherService", "GetUser", payload, nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.AnotherService", "GetUser", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
@@ -226,7 +314,8 @@ func TestDoGRPCRequest_GetUsers(t *testing.T) {
 	defer stop()
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	resp, code, _, _, err := DoGRPCRequest(addr, "testserver.AnotherService", "GetUsers", "", nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.AnotherService", "GetUsers", "", nil, nil, opts)
+	resp, code := reply.Response, reply.Code
 	if err != nil {
 		t.Fatalf("DoGRPCRequest failed: %v", err)
 	}
@@ -261,7 +350,8 @@ func TestDoGRPCRequest_InvalidService(t *testing.T) {
 	defer stop()
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	_, code, _, _, err := DoGRPCRequest(addr, "testserver.InvalidService", "SimpleCall", "", nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.InvalidService", "SimpleCall", "", nil, nil, opts)
+	code := reply.Code
 	if err == nil {
 		t.Error("expected error for invalid service, got nil")
 	}
@@ -275,7 +365,8 @@ func TestDoGRPCRequest_InvalidMethod(t *testing.T) {
 	defer stop()
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	_, code, _, _, err := DoGRPCRequest(addr, "testserver.TestService", "InvalidMethod", "", nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "InvalidMethod", "", nil, nil, opts)
+	code := reply.Code
 	if err == nil {
 		t.Error("expected error for invalid method, got nil")
 	}
@@ -289,11 +380,252 @@ func TestDoGRPCRequest_InvalidPayload(t *testing.T) {
 	defer stop()
 
 	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
-	_, code, _, _, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", "invalid json", nil, nil, opts)
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", "invalid json", nil, nil, opts)
+	code := reply.Code
 	if err == nil {
 		t.Error("expected error for invalid payload, got nil")
 	}
 	if code == 0 {
 		t.Error("expected non-zero status code for invalid payload")
 	}
+	if reply.ErrorKind != ErrorKindPayload || reply.StatusMessage == "" {
+		t.Errorf("expected payload error with message, got %q %q", reply.ErrorKind, reply.StatusMessage)
+	}
+}
+
+func TestDoGRPCRequest_Unreachable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", `{}`, nil, nil, opts)
+	if err == nil {
+		t.Fatal("expected error for unreachable server")
+	}
+	if reply.Code != codes.Unavailable || reply.ErrorKind != ErrorKindDial {
+		t.Errorf("expected Unavailable dial error, got %v %q", reply.Code, reply.ErrorKind)
+	}
+	if reply.StatusMessage == "" {
+		t.Error("expected error message")
+	}
+}
+
+func TestDoGRPCRequest_AnyFields(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	payload := `{
+		"id": "evt-1",
+		"payload": {"@type": "type.googleapis.com/testserver.User", "name": "Alice", "age": 30},
+		"details": [{"@type": "type.googleapis.com/google.protobuf.Duration", "value": "1.5s"}]
+	}`
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.EventService", "Publish", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if code != 0 {
+		t.Errorf("expected code 0 (OK), got %d", code)
+	}
+
+	var result struct {
+		Payload map[string]interface{}   `json:"payload"`
+		Details []map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if result.Payload["@type"] != "type.googleapis.com/testserver.User" || result.Payload["name"] != "Alice" {
+		t.Errorf("expected expanded User payload, got %v", result.Payload)
+	}
+	if len(result.Details) != 2 {
+		t.Fatalf("expected 2 details, got %d: %s", len(result.Details), resp)
+	}
+	if result.Details[0]["value"] != "1.500s" {
+		t.Errorf("expected Duration detail, got %v", result.Details[0])
+	}
+	if result.Details[1]["actor"] != "testserver" {
+		t.Errorf("expected expanded AuditRecord detail, got %v", result.Details[1])
+	}
+}
+
+func TestDoGRPCRequest_WellKnownTypes(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	payload := `{
+		"user": {"name": "Alice", "email": "alice@example.com"},
+		"updateMask": "name",
+		"comment": "rename",
+		"version": "42",
+		"notify": true,
+		"attributes": {"source": "test"},
+		"nothing": null
+	}`
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.AnotherService", "UpdateUser", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if code != 0 {
+		t.Errorf("expected code 0 (OK), got %d", code)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if result["name"] != "Alice" {
+		t.Errorf("expected masked field name to be updated, got %v", result["name"])
+	}
+	if result["email"] != "user1@example.com" {
+		t.Errorf("expected email outside of mask to stay unchanged, got %v", result["email"])
+	}
+}
+
+func TestDoGRPCRequest_Proto2Extensions(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	payload := `{"name": "legacy", "filter": {}, "[testserver.trace_tag]": "trace-1"}`
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.LegacyService", "Describe", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if code != 0 {
+		t.Errorf("expected code 0 (OK), got %d", code)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if result["traceTag"] != "trace-1" {
+		t.Errorf("expected extension to reach the server, got %s", resp)
+	}
+	// Незаполненные поля proto2 читаются сервером со значениями по умолчанию
+	if result["summary"] != "legacy/en-US" || result["pageSize"] != float64(25) || result["retryBudget"] != float64(3) {
+		t.Errorf("expected proto2 defaults, got %s", resp)
+	}
+}
+
+func TestDoGRPCRequest_Editions(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	payload := `{"id": "", "label": "x", "nested": {"value": "v"}, "scope": {}}`
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.EditionsService", "Echo", payload, nil, nil, opts)
+	resp, code := reply.Response, reply.Code
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if code != 0 {
+		t.Errorf("expected code 0 (OK), got %d", code)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if result["hasId"] != true {
+		t.Errorf("expected explicit presence for empty id, got %s", resp)
+	}
+	if result["nestedValue"] != "v" || result["limit"] != float64(10) {
+		t.Errorf("unexpected response: %s", resp)
+	}
+
+	// LEGACY_REQUIRED поле без значения не проходит сериализацию
+	_, err = DoGRPCRequest(addr, "testserver.EditionsService", "Echo", `{"id": "1", "scope": {}}`, nil, nil, opts)
+	if err == nil {
+		t.Error("expected error for missing required field")
+	}
+}
+
+func TestDoGRPCRequest_StatusDetails(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "ScheduleTask", `{}`, nil, nil, opts)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if reply.Code != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", reply.Code)
+	}
+	if reply.ErrorKind != ErrorKindStatus || reply.StatusMessage != "task is invalid" {
+		t.Errorf("expected status error with message, got %q %q", reply.ErrorKind, reply.StatusMessage)
+	}
+
+	// Заголовки и трейлеры приходят раздельно и со всеми значениями
+	if got := reply.Headers.Get("x-request-id"); len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Errorf("expected both header values, got %v", got)
+	}
+	if got := reply.Trailers.Get("x-retry-reason"); len(got) != 1 || got[0] != "quota" {
+		t.Errorf("expected trailer, got %v", got)
+	}
+
+	var details []map[string]interface{}
+	if err := json.Unmarshal([]byte(reply.StatusDetails), &details); err != nil {
+		t.Fatalf("failed to unmarshal details %q: %v", reply.StatusDetails, err)
+	}
+	if len(details) != 5 {
+		t.Fatalf("expected 5 details, got %d: %s", len(details), reply.StatusDetails)
+	}
+
+	expected := []struct {
+		typeURL string
+		field   string
+	}{
+		{"type.googleapis.com/google.rpc.BadRequest", "fieldViolations"},
+		{"type.googleapis.com/google.rpc.RetryInfo", "retryDelay"},
+		{"type.googleapis.com/google.rpc.ErrorInfo", "reason"},
+		{"type.googleapis.com/testserver.Address", "city"},
+		{"type.googleapis.com/unknown.Detail", "value"},
+	}
+	for i, e := range expected {
+		if details[i]["@type"] != e.typeURL {
+			t.Errorf("detail %d: expected type %s, got %v", i, e.typeURL, details[i]["@type"])
+		}
+		if _, ok := details[i][e.field]; !ok {
+			t.Errorf("detail %d: expected field %s, got %v", i, e.field, details[i])
+		}
+	}
+	if details[1]["retryDelay"] != "2s" {
+		t.Errorf("expected retry delay 2s, got %v", details[1]["retryDelay"])
+	}
+}
+
+func TestDoGRPCRequest_HeadersOnSuccess(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+
+	opts := &utils.GRPCConnectOptions{UseTLS: false, Insecure: false}
+	reply, err := DoGRPCRequest(addr, "testserver.TestService", "SimpleCall", `{"message": "test"}`, nil, nil, opts)
+	if err != nil {
+		t.Fatalf("DoGRPCRequest failed: %v", err)
+	}
+	if reply.Headers.Get("content-type") == nil {
+		t.Errorf("expected response headers, got %v", reply.Headers)
+	}
+	if reply.StatusDetails != "" {
+		t.Errorf("expected no details on success, got %q", reply.StatusDetails)
+	}
 }
@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+type Status string
+
+const (
+	StatusServing        Status = "SERVING"
+	StatusNotServing     Status = "NOT_SERVING"
+	StatusServiceUnknown Status = "SERVICE_UNKNOWN"
+	StatusUnknown        Status = "UNKNOWN"
+	// StatusUnimplemented - сервер не поддерживает grpc.health.v1
+	StatusUnimplemented Status = "UNIMPLEMENTED"
+	// StatusUnreachable - до сервера не удалось достучаться
+	StatusUnreachable Status = "UNREACHABLE"
+)
+
+// ErrUnimplemented возвращается из Watch, если сервер не поддерживает подписку на статус.
+var ErrUnimplemented = errors.New("health watch is not implemented by server")
+
+type Result struct {
+	Status  Status
+	Latency time.Duration
+	Error   string
+}
+
+// Check опрашивает grpc.health.v1.Health/Check. Пустое имя сервиса - статус сервера целиком.
+func Check(ctx context.Context, conn grpc.ClientConnInterface, service string) Result {
+	started := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	latency := time.Since(started)
+
+	if err != nil {
+		return errorResult(err, latency)
+	}
+
+	return Result{Status: fromProto(resp.GetStatus()), Latency: latency}
+}
+
+// Watch подписывается на grpc.health.v1.Health/Watch и вызывает onResult на каждое изменение статуса.
+// Блокируется до отмены контекста или обрыва потока.
+func Watch(ctx context.Context, conn grpc.ClientConnInterface, service string, onResult func(Result)) error {
+	stream, err := healthpb.NewHealthClient(conn).Watch(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return watchError(err, onResult)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return watchError(err, onResult)
+		}
+
+		onResult(Result{Status: fromProto(resp.GetStatus())})
+	}
+}
+
+func watchError(err error, onResult func(Result)) error {
+	result := errorResult(err, 0)
+	onResult(result)
+	if result.Status == StatusUnimplemented {
+		return ErrUnimplemented
+	}
+	return err
+}
+
+func errorResult(err error, latency time.Duration) Result {
+	result := Result{Status: StatusUnknown, Latency: latency, Error: err.Error()}
+
+	switch status.Code(err) {
+	case codes.Unimplemented:
+		result.Status = StatusUnimplemented
+	case codes.NotFound:
+		result.Status = StatusServiceUnknown
+	case codes.Unavailable, codes.DeadlineExceeded:
+		result.Status = StatusUnreachable
+	}
+
+	return result
+}
+
+func fromProto(s healthpb.HealthCheckResponse_ServingStatus) Status {
+	switch s {
+	case healthpb.HealthCheckResponse_SERVING:
+		return StatusServing
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return StatusNotServing
+	case healthpb.HealthCheckResponse_SERVICE_UNKNOWN:
+		return StatusServiceUnknown
+	default:
+		return StatusUnknown
+	}
+}
@@ -0,0 +1,114 @@
+package health
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func startServer(t *testing.T, withHealth bool) (*grpc.ClientConn, *grpchealth.Server) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	var healthServer *grpchealth.Server
+	if withHealth {
+		healthServer = grpchealth.NewServer()
+		healthServer.SetServingStatus("testserver.TestService", healthpb.HealthCheckResponse_NOT_SERVING)
+		healthpb.RegisterHealthServer(s, healthServer)
+	}
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, healthServer
+}
+
+func TestCheck(t *testing.T) {
+	conn, _ := startServer(t, true)
+	ctx := context.Background()
+
+	if result := Check(ctx, conn, ""); result.Status != StatusServing || result.Error != "" {
+		t.Errorf("expected SERVING, got %+v", result)
+	}
+	if result := Check(ctx, conn, "testserver.TestService"); result.Status != StatusNotServing {
+		t.Errorf("expected NOT_SERVING, got %+v", result)
+	}
+	if result := Check(ctx, conn, "testserver.Missing"); result.Status != StatusServiceUnknown {
+		t.Errorf("expected SERVICE_UNKNOWN, got %+v", result)
+	}
+}
+
+func TestCheck_Unimplemented(t *testing.T) {
+	conn, _ := startServer(t, false)
+
+	result := Check(context.Background(), conn, "")
+	if result.Status != StatusUnimplemented || result.Error == "" {
+		t.Errorf("expected UNIMPLEMENTED, got %+v", result)
+	}
+}
+
+func TestCheck_Unreachable(t *testing.T) {
+	conn, err := grpc.NewClient("127.0.0.1:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if result := Check(ctx, conn, ""); result.Status != StatusUnreachable {
+		t.Errorf("expected UNREACHABLE, got %+v", result)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	conn, healthServer := startServer(t, true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := make(chan Result, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, conn, "testserver.TestService", func(r Result) { results <- r })
+	}()
+
+	if r := <-results; r.Status != StatusNotServing {
+		t.Fatalf("expected initial NOT_SERVING, got %+v", r)
+	}
+
+	healthServer.SetServingStatus("testserver.TestService", healthpb.HealthCheckResponse_SERVING)
+	if r := <-results; r.Status != StatusServing {
+		t.Fatalf("expected SERVING after change, got %+v", r)
+	}
+
+	cancel()
+	if err := <-done; err == nil {
+		t.Error("expected context error after cancel")
+	}
+}
+
+func TestWatch_Unimplemented(t *testing.T) {
+	conn, _ := startServer(t, false)
+
+	var last Result
+	err := Watch(context.Background(), conn, "", func(r Result) { last = r })
+	if err != ErrUnimplemented || last.Status != StatusUnimplemented {
+		t.Errorf("expected ErrUnimplemented, got %v, %+v", err, last)
+	}
+}
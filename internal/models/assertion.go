@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Assertion - проверка ответа метода, выполняется после каждого вызова.
+// Position задает порядок проверок.
+type Assertion struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	ServerID uint   `gorm:"index:idx_assertion_method" json:"serverId"`
+	Service  string `gorm:"index:idx_assertion_method" json:"service"`
+	Method   string `gorm:"index:idx_assertion_method" json:"method"`
+	Position int    `json:"position"`
+	Kind     string `json:"kind"`
+	Path     string `json:"path"`
+	Operator string `json:"operator"`
+	Expected string `json:"expected"`
+}
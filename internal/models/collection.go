@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Collection - библиотека сохраненных запросов.
+type Collection struct {
+	ID          uint      `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+// Folder - папка коллекции. ParentID = 0 - папка в корне коллекции.
+// Папки и запросы одного родителя упорядочены общим Position.
+type Folder struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	CollectionID uint      `gorm:"index" json:"collectionId"`
+	ParentID     uint      `gorm:"index" json:"parentId"`
+	Position     int       `json:"position"`
+	Name         string    `json:"name"`
+}
+
+// SavedRequest - сохраненный вызов. Address, Payload, Headers и Context - шаблоны с {{переменными}},
+// пустой Address - адрес сервера. FolderID = 0 - запрос в корне коллекции.
+type SavedRequest struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	CollectionID uint      `gorm:"index" json:"collectionId"`
+	FolderID     uint      `gorm:"index" json:"folderId"`
+	Position     int       `json:"position"`
+	Name         string    `json:"name"`
+
+	ServerID uint   `gorm:"index" json:"serverId"`
+	Service  string `json:"service"`
+	Method   string `json:"method"`
+	Address  string `json:"address"`
+	Payload  string `json:"payload"`
+
+	Headers    map[string]string    `gorm:"serializer:json" json:"headers"`
+	Context    *SavedRequestContext `gorm:"serializer:json" json:"context"`
+	Assertions []SavedAssertion     `gorm:"serializer:json" json:"assertions"`
+}
+
+// SavedRequestContext - параметры вызова сохраненного запроса, по JSON совпадает с grpcrequest.RequestContext.
+type SavedRequestContext struct {
+	Timeout      string            `json:"timeout,omitempty"`
+	Compression  string            `json:"compression,omitempty"`
+	WaitForReady bool              `json:"waitForReady,omitempty"`
+	Credentials  *SavedCredentials `json:"credentials,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// SavedCredentials - учетные данные вызова, по JSON совпадает с grpcrequest.CallCredentials.
+type SavedCredentials struct {
+	Type     string `json:"type"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// SavedAssertion - проверка сохраненного запроса, по JSON совпадает с assertion.Assertion.
+type SavedAssertion struct {
+	Kind     string `json:"kind"`
+	Path     string `json:"path,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Expected string `json:"expected,omitempty"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Environment - именованный набор переменных. Глобальные окружения (ServerID = 0) действуют
+// для всех серверов, серверные переопределяют их значения. В каждой области активно не больше одного.
+type Environment struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	Name      string            `json:"name"`
+	ServerID  uint              `gorm:"index" json:"serverId"`
+	Active    bool              `json:"active"`
+	Variables map[string]string `gorm:"serializer:json" json:"variables"`
+}
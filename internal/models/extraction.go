@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ExtractionRule - правило метода, которое после ответа записывает значение в переменную.
+// Position задает порядок выполнения правил.
+type ExtractionRule struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	ServerID   uint   `gorm:"index:idx_extraction_method" json:"serverId"`
+	Service    string `gorm:"index:idx_extraction_method" json:"service"`
+	Method     string `gorm:"index:idx_extraction_method" json:"method"`
+	Position   int    `json:"position"`
+	Variable   string `json:"variable"`
+	Source     string `json:"source"`
+	Expression string `json:"expression"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// HealthCheck - результат одной проверки grpc.health.v1, из них складывается история доступности.
+type HealthCheck struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"createdAt"`
+
+	ServerID  uint   `gorm:"index" json:"serverId"`
+	Service   string `json:"service,omitempty"`
+	Status    string `json:"status"`
+	LatencyMs int32  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
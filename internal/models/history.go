@@ -17,13 +17,38 @@ type History struct {
 
 	Request  string `json:"request"`
 	Response string `json:"response"`
+	// Address - адрес, по которому ушел запрос, после подстановки переменных
+	Address string `json:"address,omitempty"`
+	// RequestTemplate - JSON запроса до подстановки переменных, пустой, если переменных не было
+	RequestTemplate string `json:"requestTemplate,omitempty"`
 
 	Service       string `json:"service"`
 	Method        string `json:"method"`
 	StatusCode    int32  `json:"statusCode"`
 	ExecutionTime int32  `json:"executionTime"` // Время выполнения запроса в миллисекундах
 
-	RequestHeaders  string `json:"requestHeaders,omitempty"`
-	ResponseHeaders string `json:"responseHeaders,omitempty"`
-	ContextValues   string `json:"contextValues,omitempty"`
+	RequestHeaders string `json:"requestHeaders,omitempty"`
+	// ResponseHeaders и ResponseTrailers - JSON объекты со всеми значениями каждого ключа
+	ResponseHeaders  string `json:"responseHeaders,omitempty"`
+	ResponseTrailers string `json:"responseTrailers,omitempty"`
+	// RequestContext - JSON параметров вызова: таймаут, компрессия, wait-for-ready, учетные данные и метаданные
+	RequestContext string `json:"requestContext,omitempty"`
+	// ContextValues - старый формат значений контекста, при запуске переносится в RequestContext
+	ContextValues string `json:"-"`
+
+	// StatusDetails - JSON массив google.rpc.Status.details
+	StatusDetails string `json:"statusDetails,omitempty"`
+	// StatusMessage - сообщение статуса или текст ошибки, если до вызова не дошло
+	StatusMessage string `json:"statusMessage,omitempty"`
+	// ErrorKind - этап, на котором сломался вызов: dial, reflection, payload, transport, deadline, status, response
+	ErrorKind string `json:"errorKind,omitempty" gorm:"index"`
+	// Extracted - JSON массив значений, извлеченных правилами метода после ответа
+	Extracted string `json:"extracted,omitempty"`
+	// ScriptLogs - JSON массив строк console.log и ошибок скриптов метода
+	ScriptLogs string `json:"scriptLogs,omitempty"`
+	// Assertions - JSON массив результатов проверок, AssertionsPassed пустой, если проверок не было
+	Assertions       string `json:"assertions,omitempty"`
+	AssertionsPassed *bool  `json:"assertionsPassed,omitempty" gorm:"index"`
+	// SavedRequestID - сохраненный запрос, из которого отправлен вызов, 0 - обычный вызов
+	SavedRequestID uint `json:"savedRequestId,omitempty" gorm:"index"`
 }
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// MethodScript - скрипты метода: PreRequest выполняется перед отправкой, PostResponse после ответа.
+type MethodScript struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	ServerID     uint   `gorm:"uniqueIndex:idx_script_method" json:"serverId"`
+	Service      string `gorm:"uniqueIndex:idx_script_method" json:"service"`
+	Method       string `gorm:"uniqueIndex:idx_script_method" json:"method"`
+	PreRequest   string `json:"preRequest"`
+	PostResponse string `json:"postResponse"`
+}
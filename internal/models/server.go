@@ -19,8 +19,18 @@ type Server struct {
 	OptUseTLS   bool `json:"optUseTLS"`
 	OptInsecure bool `json:"optInsecure"`
 
-	ReflectionCache        string    `json:"-"`
-	ReflectionCachedAt     time.Time `json:"-"`
-	ReflectionAccessCount  int       `json:"-"`
-	ReflectionError        string    `json:"-"`
+	ReflectionCache       string    `json:"-"`
+	ReflectionCachedAt    time.Time `json:"-"`
+	ReflectionAccessCount int       `json:"-"`
+	ReflectionError       string    `json:"-"`
+
+	// Последняя удачная схема хранится отдельно от ошибки и используется офлайн
+	ReflectionSchemaAt    time.Time `json:"-"`
+	ReflectionDescriptors []byte    `json:"-"`
+
+	// HealthService - имя сервиса для grpc.health.v1, пустое - статус сервера целиком
+	HealthService   string    `json:"healthService"`
+	HealthStatus    string    `json:"healthStatus"`
+	HealthCheckedAt time.Time `json:"healthCheckedAt"`
+	HealthError     string    `json:"healthError,omitempty"`
 }
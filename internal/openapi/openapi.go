@@ -0,0 +1,404 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"grpc-gui/internal/grpcreflect"
+)
+
+const (
+	Version = "3.1.0"
+
+	statusSchemaName = "google.rpc.Status"
+	anySchemaName    = "google.protobuf.Any"
+)
+
+var pathParamRe = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+type generator struct {
+	schemas map[string]*grpcreflect.MessageInfo
+}
+
+// Generate строит OpenAPI документ по закэшированной рефлексии сервера.
+// Для методов без google.api.http используется маппинг grpc-gateway по умолчанию:
+// POST /package.Service/Method с телом запроса целиком.
+func Generate(title string, services *grpcreflect.ServicesInfo) ([]byte, error) {
+	if services == nil {
+		services = &grpcreflect.ServicesInfo{}
+	}
+
+	g := &generator{schemas: make(map[string]*grpcreflect.MessageInfo)}
+
+	paths := make(map[string]map[string]interface{})
+	tags := make([]map[string]interface{}, 0, len(services.Services))
+
+	for _, service := range services.Services {
+		tags = append(tags, map[string]interface{}{"name": service.Name})
+
+		for _, method := range service.Methods {
+			g.collect(method.Request)
+			g.collect(method.Response)
+
+			rules := method.HTTPRules
+			if len(rules) == 0 {
+				rules = []grpcreflect.HTTPRule{{
+					Method: "post",
+					Path:   fmt.Sprintf("/%s/%s", service.Name, method.Name),
+					Body:   "*",
+				}}
+			}
+
+			for i, rule := range rules {
+				path, params := convertPathTemplate(rule.Path)
+				operationID := operationID(service.Name, method.Name)
+				if i > 0 {
+					operationID = fmt.Sprintf("%s%d", operationID, i+1)
+				}
+
+				if paths[path] == nil {
+					paths[path] = make(map[string]interface{})
+				}
+				paths[path][strings.ToLower(rule.Method)] = g.operation(service.Name, operationID, method, rule, params)
+			}
+		}
+	}
+
+	schemas := make(map[string]interface{}, len(g.schemas)+2)
+	for name, msg := range g.schemas {
+		schemas[name] = g.messageSchema(msg)
+	}
+	schemas[anySchemaName] = anySchema()
+	schemas[statusSchemaName] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+			"message": map[string]interface{}{"type": "string"},
+			"details": map[string]interface{}{
+				"type":  "array",
+				"items": schemaRef(anySchemaName),
+			},
+		},
+	}
+
+	doc := map[string]interface{}{
+		"openapi": Version,
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": "1.0.0",
+		},
+		"tags":  tags,
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// Рекурсивные и повторно встречающиеся сообщения в MessageInfo приходят пустыми,
+// поэтому для схемы оставляем самое полное описание.
+func (g *generator) collect(msg *grpcreflect.MessageInfo) {
+	if msg == nil {
+		return
+	}
+
+	existing, ok := g.schemas[msg.Name]
+	if ok && len(existing.Fields) >= len(msg.Fields) {
+		return
+	}
+	g.schemas[msg.Name] = msg
+
+	for _, field := range msg.Fields {
+		g.collect(field.Message)
+	}
+}
+
+func (g *generator) operation(serviceName, operationID string, method grpcreflect.MethodInfo, rule grpcreflect.HTTPRule, params []string) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": operationID,
+		"tags":        []string{serviceName},
+	}
+
+	parameters := make([]map[string]interface{}, 0)
+	usedFields := make(map[string]bool)
+
+	for _, param := range params {
+		usedFields[strings.SplitN(param, ".", 2)[0]] = true
+		schema := map[string]interface{}{"type": "string"}
+		if field := g.lookupField(g.resolve(method.Request), param); field != nil {
+			schema = g.fieldSchema(*field)
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name":     param,
+			"in":       "path",
+			"required": true,
+			"schema":   schema,
+		})
+	}
+
+	request := g.resolve(method.Request)
+
+	switch rule.Body {
+	case "*":
+		op["requestBody"] = requestBody(schemaRef(strings.TrimPrefix(method.RequestType, ".")))
+	case "":
+		parameters = append(parameters, g.queryParameters(request, usedFields)...)
+	default:
+		usedFields[rule.Body] = true
+		bodySchema := map[string]interface{}{"type": "object"}
+		if field := g.lookupField(request, rule.Body); field != nil {
+			bodySchema = g.fieldSchema(*field)
+		}
+		op["requestBody"] = requestBody(bodySchema)
+		parameters = append(parameters, g.queryParameters(request, usedFields)...)
+	}
+
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+
+	responseSchema := schemaRef(strings.TrimPrefix(method.ResponseType, "."))
+	if rule.ResponseBody != "" {
+		if field := g.lookupField(g.resolve(method.Response), rule.ResponseBody); field != nil {
+			responseSchema = g.fieldSchema(*field)
+		}
+	}
+
+	op["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "A successful response.",
+			"content":     jsonContent(responseSchema),
+		},
+		"default": map[string]interface{}{
+			"description": "An unexpected error response.",
+			"content":     jsonContent(schemaRef(statusSchemaName)),
+		},
+	}
+
+	return op
+}
+
+func (g *generator) queryParameters(msg *grpcreflect.MessageInfo, usedFields map[string]bool) []map[string]interface{} {
+	var parameters []map[string]interface{}
+	if msg == nil {
+		return parameters
+	}
+
+	for _, field := range msg.Fields {
+		if usedFields[field.Name] || field.IsMap || (field.Message != nil && !field.IsWellKnown) {
+			continue
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name":   field.Name,
+			"in":     "query",
+			"schema": g.fieldSchema(field),
+		})
+	}
+
+	return parameters
+}
+
+func (g *generator) resolve(msg *grpcreflect.MessageInfo) *grpcreflect.MessageInfo {
+	if msg == nil {
+		return nil
+	}
+	if full, ok := g.schemas[msg.Name]; ok {
+		return full
+	}
+	return msg
+}
+
+func (g *generator) messageSchema(msg *grpcreflect.MessageInfo) map[string]interface{} {
+	properties := make(map[string]interface{}, len(msg.Fields))
+	var required []string
+
+	for _, field := range msg.Fields {
+		properties[field.Name] = g.fieldSchema(field)
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+func (g *generator) fieldSchema(field grpcreflect.FieldInfo) map[string]interface{} {
+	if field.IsMap {
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": g.valueSchema(field, field.MapValue),
+		}
+	}
+
+	if field.Repeated {
+		return map[string]interface{}{
+			"type":  "array",
+			"items": g.valueSchema(field, field.Type),
+		}
+	}
+
+	return g.valueSchema(field, field.Type)
+}
+
+func (g *generator) valueSchema(field grpcreflect.FieldInfo, typeName string) map[string]interface{} {
+	if field.IsWellKnown {
+		return wellKnownSchema(field.WellKnownType)
+	}
+
+	if field.Message != nil {
+		return schemaRef(field.Message.Name)
+	}
+
+	if len(field.EnumValues) > 0 {
+		values := make([]string, 0, len(field.EnumValues))
+		for _, v := range field.EnumValues {
+			values = append(values, v.Name)
+		}
+		return map[string]interface{}{"type": "string", "enum": values}
+	}
+
+	return scalarSchema(typeName)
+}
+
+func (g *generator) lookupField(msg *grpcreflect.MessageInfo, path string) *grpcreflect.FieldInfo {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		if msg == nil {
+			return nil
+		}
+
+		var found *grpcreflect.FieldInfo
+		for j := range msg.Fields {
+			if msg.Fields[j].Name == part {
+				found = &msg.Fields[j]
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		if i == len(parts)-1 {
+			return found
+		}
+		msg = g.resolve(found.Message)
+	}
+
+	return nil
+}
+
+func convertPathTemplate(template string) (string, []string) {
+	var params []string
+	path := pathParamRe.ReplaceAllStringFunc(template, func(match string) string {
+		name := pathParamRe.FindStringSubmatch(match)[1]
+		params = append(params, name)
+		return "{" + name + "}"
+	})
+
+	return path, params
+}
+
+func operationID(serviceName, methodName string) string {
+	shortName := serviceName
+	if idx := strings.LastIndex(serviceName, "."); idx >= 0 {
+		shortName = serviceName[idx+1:]
+	}
+	return shortName + "_" + methodName
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": schema,
+		},
+	}
+}
+
+func requestBody(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"required": true,
+		"content":  jsonContent(schema),
+	}
+}
+
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+func anySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"@type": map[string]interface{}{"type": "string"},
+		},
+		"additionalProperties": true,
+	}
+}
+
+func wellKnownSchema(wellKnownType string) map[string]interface{} {
+	// Обертки допускают null, в отличие от обычных скаляров
+	if scalar, ok := grpcreflect.WrapperScalarType(wellKnownType); ok {
+		schema := scalarSchema(scalar)
+		schema["type"] = []interface{}{schema["type"], "null"}
+		return schema
+	}
+
+	switch wellKnownType {
+	case "timestamp":
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case "duration":
+		return map[string]interface{}{"type": "string", "pattern": `^-?[0-9]+(\.[0-9]+)?s$`}
+	case "empty":
+		return map[string]interface{}{"type": "object"}
+	case "struct":
+		return map[string]interface{}{"type": "object", "additionalProperties": true}
+	case "value":
+		return map[string]interface{}{}
+	case "list_value":
+		return map[string]interface{}{"type": "array", "items": map[string]interface{}{}}
+	case "any":
+		return schemaRef(anySchemaName)
+	case "field_mask":
+		return map[string]interface{}{"type": "string", "format": "field-mask"}
+	case "null_value":
+		return map[string]interface{}{"type": "null"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// Маппинг скаляров соответствует каноническому proto3 JSON:
+// 64-битные целые передаются строками.
+func scalarSchema(typeName string) map[string]interface{} {
+	switch typeName {
+	case "bool":
+		return map[string]interface{}{"type": "boolean"}
+	case "int32", "sint32", "sfixed32":
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case "uint32", "fixed32":
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case "int64", "sint64", "sfixed64":
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case "uint64", "fixed64":
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case "float":
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case "double":
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case "bytes":
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
@@ -0,0 +1,259 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"grpc-gui/internal/grpcreflect"
+)
+
+func testServices() *grpcreflect.ServicesInfo {
+	address := &grpcreflect.MessageInfo{
+		Name: "testserver.Address",
+		Fields: []grpcreflect.FieldInfo{
+			{Name: "city", Type: "string"},
+			{Name: "zip_code", Type: "int32"},
+		},
+	}
+
+	user := &grpcreflect.MessageInfo{
+		Name: "testserver.User",
+		Fields: []grpcreflect.FieldInfo{
+			{Name: "id", Type: "int64"},
+			{Name: "name", Type: "string"},
+			{Name: "tags", Type: "string", Repeated: true},
+			{Name: "address", Type: "testserver.Address", Message: address},
+			{Name: "addresses", Type: "testserver.Address", Repeated: true, Message: &grpcreflect.MessageInfo{Name: "testserver.Address", Fields: []grpcreflect.FieldInfo{}}},
+			{Name: "metadata", Type: "map<string, string>", IsMap: true, MapKey: "string", MapValue: "string"},
+			{Name: "status", Type: "testserver.Status", IsEnum: true, EnumValues: []grpcreflect.EnumValueInfo{{Name: "UNKNOWN"}, {Name: "ACTIVE", Number: 2}}},
+			{Name: "created_at", Type: "google.protobuf.Timestamp", IsWellKnown: true, WellKnownType: "timestamp"},
+		},
+	}
+
+	simpleRequest := &grpcreflect.MessageInfo{
+		Name: "testserver.SimpleRequest",
+		Fields: []grpcreflect.FieldInfo{
+			{Name: "message", Type: "string"},
+			{Name: "value", Type: "int32"},
+		},
+	}
+
+	updateRequest := &grpcreflect.MessageInfo{
+		Name: "testserver.UpdateRequest",
+		Fields: []grpcreflect.FieldInfo{
+			{Name: "user", Type: "testserver.User", Message: user},
+			{Name: "status", Type: "testserver.Status", IsEnum: true, EnumValues: []grpcreflect.EnumValueInfo{{Name: "UNKNOWN"}}},
+		},
+	}
+
+	return &grpcreflect.ServicesInfo{
+		Services: []grpcreflect.ServiceInfo{
+			{
+				Name: "testserver.AnotherService",
+				Methods: []grpcreflect.MethodInfo{
+					{
+						Name:         "GetUser",
+						RequestType:  "testserver.SimpleRequest",
+						ResponseType: "testserver.User",
+						Request:      simpleRequest,
+						Response:     user,
+						HTTPRules:    []grpcreflect.HTTPRule{{Method: "get", Path: "/v1/users/{message}"}},
+					},
+					{
+						Name:         "UpdateStatus",
+						RequestType:  "testserver.UpdateRequest",
+						ResponseType: "testserver.SimpleRequest",
+						Request:      updateRequest,
+						Response:     simpleRequest,
+						HTTPRules: []grpcreflect.HTTPRule{
+							{Method: "post", Path: "/v1/users:updateStatus", Body: "*"},
+							{Method: "patch", Path: "/v1/users/{user.id=*}/status", Body: "status"},
+						},
+					},
+					{
+						Name:         "Echo",
+						RequestType:  "testserver.SimpleRequest",
+						ResponseType: "testserver.SimpleRequest",
+						Request:      simpleRequest,
+						Response:     simpleRequest,
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateDocument(t *testing.T) map[string]interface{} {
+	data, err := Generate("Test Server", testServices())
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to unmarshal document: %v", err)
+	}
+	return doc
+}
+
+func getOperation(t *testing.T, doc map[string]interface{}, path, method string) map[string]interface{} {
+	paths := doc["paths"].(map[string]interface{})
+	item, ok := paths[path].(map[string]interface{})
+	if !ok {
+		t.Fatalf("path %s not found", path)
+	}
+	op, ok := item[method].(map[string]interface{})
+	if !ok {
+		t.Fatalf("method %s not found for path %s", method, path)
+	}
+	return op
+}
+
+func TestGenerate_Document(t *testing.T) {
+	doc := generateDocument(t)
+
+	if doc["openapi"] != Version {
+		t.Errorf("expected openapi %s, got %v", Version, doc["openapi"])
+	}
+
+	info := doc["info"].(map[string]interface{})
+	if info["title"] != "Test Server" {
+		t.Errorf("expected title 'Test Server', got %v", info["title"])
+	}
+}
+
+func TestGenerate_HTTPRuleGet(t *testing.T) {
+	doc := generateDocument(t)
+	op := getOperation(t, doc, "/v1/users/{message}", "get")
+
+	if op["operationId"] != "AnotherService_GetUser" {
+		t.Errorf("unexpected operationId: %v", op["operationId"])
+	}
+	if _, ok := op["requestBody"]; ok {
+		t.Error("GET operation should not have requestBody")
+	}
+
+	params := op["parameters"].([]interface{})
+	if len(params) != 2 {
+		t.Fatalf("expected 2 parameters, got %d", len(params))
+	}
+
+	pathParam := params[0].(map[string]interface{})
+	if pathParam["name"] != "message" || pathParam["in"] != "path" || pathParam["required"] != true {
+		t.Errorf("unexpected path parameter: %v", pathParam)
+	}
+
+	queryParam := params[1].(map[string]interface{})
+	if queryParam["name"] != "value" || queryParam["in"] != "query" {
+		t.Errorf("unexpected query parameter: %v", queryParam)
+	}
+}
+
+func TestGenerate_AdditionalBindings(t *testing.T) {
+	doc := generateDocument(t)
+
+	post := getOperation(t, doc, "/v1/users:updateStatus", "post")
+	body := post["requestBody"].(map[string]interface{})
+	schema := body["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	if schema["$ref"] != "#/components/schemas/testserver.UpdateRequest" {
+		t.Errorf("unexpected request body schema: %v", schema)
+	}
+
+	patch := getOperation(t, doc, "/v1/users/{user.id}/status", "patch")
+	if patch["operationId"] != "AnotherService_UpdateStatus2" {
+		t.Errorf("unexpected operationId: %v", patch["operationId"])
+	}
+
+	params := patch["parameters"].([]interface{})
+	if len(params) != 1 {
+		t.Fatalf("expected 1 parameter, got %d", len(params))
+	}
+	pathParam := params[0].(map[string]interface{})
+	paramSchema := pathParam["schema"].(map[string]interface{})
+	if paramSchema["type"] != "string" || paramSchema["format"] != "int64" {
+		t.Errorf("expected int64 string schema for user.id, got %v", paramSchema)
+	}
+
+	patchBody := patch["requestBody"].(map[string]interface{})
+	patchSchema := patchBody["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+	if patchSchema["type"] != "string" || patchSchema["enum"] == nil {
+		t.Errorf("expected enum schema for status body, got %v", patchSchema)
+	}
+}
+
+func TestGenerate_DefaultMapping(t *testing.T) {
+	doc := generateDocument(t)
+	op := getOperation(t, doc, "/testserver.AnotherService/Echo", "post")
+
+	if _, ok := op["requestBody"]; !ok {
+		t.Error("default mapping should have requestBody")
+	}
+	if _, ok := op["parameters"]; ok {
+		t.Error("default mapping should not have parameters")
+	}
+}
+
+func TestGenerate_Schemas(t *testing.T) {
+	doc := generateDocument(t)
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+
+	for _, name := range []string{"testserver.User", "testserver.Address", "testserver.SimpleRequest", "google.rpc.Status"} {
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("schema %s not found", name)
+		}
+	}
+
+	address := schemas["testserver.Address"].(map[string]interface{})
+	if len(address["properties"].(map[string]interface{})) != 2 {
+		t.Errorf("expected full Address schema, got %v", address)
+	}
+
+	props := schemas["testserver.User"].(map[string]interface{})["properties"].(map[string]interface{})
+
+	tags := props["tags"].(map[string]interface{})
+	if tags["type"] != "array" {
+		t.Errorf("expected array for tags, got %v", tags)
+	}
+
+	metadata := props["metadata"].(map[string]interface{})
+	if metadata["type"] != "object" || metadata["additionalProperties"] == nil {
+		t.Errorf("expected object with additionalProperties for metadata, got %v", metadata)
+	}
+
+	createdAt := props["created_at"].(map[string]interface{})
+	if createdAt["format"] != "date-time" {
+		t.Errorf("expected date-time for created_at, got %v", createdAt)
+	}
+
+	addressRef := props["address"].(map[string]interface{})
+	if addressRef["$ref"] != "#/components/schemas/testserver.Address" {
+		t.Errorf("expected $ref for address, got %v", addressRef)
+	}
+}
+
+func TestGenerate_NilServices(t *testing.T) {
+	data, err := Generate("Empty", nil)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("failed to unmarshal document: %v", err)
+	}
+	if len(doc["paths"].(map[string]interface{})) != 0 {
+		t.Error("expected no paths")
+	}
+}
+
+func TestWellKnownSchema_Wrappers(t *testing.T) {
+	schema := wellKnownSchema("int64_value")
+	types, ok := schema["type"].([]interface{})
+	if !ok || len(types) != 2 || types[0] != "string" || types[1] != "null" {
+		t.Errorf("expected nullable string for Int64Value, got %v", schema)
+	}
+
+	if schema := wellKnownSchema("field_mask"); schema["type"] != "string" {
+		t.Errorf("expected string for FieldMask, got %v", schema)
+	}
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"grpc-gui/internal/extract"
+	"grpc-gui/internal/templating"
+)
+
+// ParseData разбирает файл данных для итераций. Формат выбирается по расширению name:
+// .csv - первая строка с именами переменных, .json - массив объектов. Значения не строки
+// (числа, объекты) превращаются в JSON текст.
+func ParseData(name string, data []byte) ([]map[string]string, error) {
+	var rows []map[string]string
+	var err error
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		rows, err = parseCSV(data)
+	case ".json":
+		rows, err = parseJSON(data)
+	default:
+		return nil, fmt.Errorf("unsupported data file %q: expected .csv or .json", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("data file %s: %w", name, err)
+	}
+
+	for i, row := range rows {
+		for key := range row {
+			if !templating.ValidName(key) {
+				return nil, fmt.Errorf("data file %s: row %d: invalid variable name %q", name, i+1, key)
+			}
+		}
+	}
+	return rows, nil
+}
+
+func parseCSV(data []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, key := range header {
+			row[key] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseJSON(data []byte) ([]map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var objects []map[string]any
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("expected an array of objects: %w", err)
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for key, value := range object {
+			text, err := extract.Stringify(value)
+			if err != nil {
+				return nil, err
+			}
+			row[key] = text
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
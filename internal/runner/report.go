@@ -0,0 +1,241 @@
+package runner
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"grpc-gui/internal/assertion"
+)
+
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+)
+
+// Report - итог прогона коллекции. Время везде в миллисекундах.
+type Report struct {
+	Collection string      `json:"collection"`
+	StartedAt  time.Time   `json:"startedAt"`
+	Duration   int64       `json:"duration"`
+	Summary    Summary     `json:"summary"`
+	Iterations []Iteration `json:"iterations"`
+	// Stopped - прогон прерван: упал запрос при StopOnFailure или отменен
+	Stopped bool `json:"stopped"`
+}
+
+// Passed - все запросы прогона прошли.
+func (r *Report) Passed() bool {
+	return r.Summary.Failed == 0 && r.Summary.Skipped == 0
+}
+
+type Summary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+func (s *Summary) add(result Result) {
+	s.Total++
+	switch result.Status {
+	case StatusPassed:
+		s.Passed++
+	case StatusFailed:
+		s.Failed++
+	case StatusSkipped:
+		s.Skipped++
+	}
+}
+
+// Iteration - один проход коллекции. Data - строка файла данных.
+type Iteration struct {
+	Index   int               `json:"index"`
+	Data    map[string]string `json:"data,omitempty"`
+	Results []Result          `json:"results"`
+}
+
+// Result - итог одного запроса прогона.
+type Result struct {
+	RequestID     uint               `json:"requestId"`
+	Name          string             `json:"name"`
+	Path          string             `json:"path"`
+	Service       string             `json:"service"`
+	Method        string             `json:"method"`
+	Status        Status             `json:"status"`
+	Code          int32              `json:"code"`
+	CodeName      string             `json:"codeName,omitempty"`
+	StatusMessage string             `json:"statusMessage,omitempty"`
+	ErrorKind     string             `json:"errorKind,omitempty"`
+	Error         string             `json:"error,omitempty"`
+	Response      string             `json:"response,omitempty"`
+	Duration      int64              `json:"duration"`
+	Assertions    []assertion.Result `json:"assertions,omitempty"`
+}
+
+// failure - текст причины падения для отчетов.
+func (r Result) failure() string {
+	var lines []string
+	if r.Error != "" {
+		lines = append(lines, r.Error)
+	} else if r.Code != 0 {
+		lines = append(lines, strings.TrimSpace(r.CodeName+" "+r.StatusMessage))
+	}
+	for _, result := range r.Assertions {
+		if result.Passed {
+			continue
+		}
+		line := result.Assertion.String()
+		if result.Message != "" {
+			line += ": " + result.Message
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Format - формат отчета.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatJUnit Format = "junit"
+)
+
+// Write пишет отчет в формате format.
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatJUnit:
+		return r.WriteJUnit(w)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Props     *junitPropsList `xml:"properties,omitempty"`
+	Cases     []junitCase     `xml:"testcase"`
+}
+
+type junitPropsList struct {
+	Props []junitProp `xml:"property"`
+}
+
+type junitProp struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit пишет отчет в формате JUnit XML: итерация - testsuite, запрос - testcase.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suites := junitSuites{
+		Name:     r.Collection,
+		Tests:    r.Summary.Total,
+		Failures: r.Summary.Failed,
+		Skipped:  r.Summary.Skipped,
+		Time:     seconds(r.Duration),
+	}
+
+	for _, iteration := range r.Iterations {
+		suite := junitSuite{Name: r.Collection, Timestamp: r.StartedAt.Format("2006-01-02T15:04:05")}
+		if len(r.Iterations) > 1 {
+			suite.Name = fmt.Sprintf("%s [%d]", r.Collection, iteration.Index)
+		}
+		if len(iteration.Data) > 0 {
+			suite.Props = &junitPropsList{}
+			for _, key := range sortedKeys(iteration.Data) {
+				suite.Props.Props = append(suite.Props.Props, junitProp{Name: key, Value: iteration.Data[key]})
+			}
+		}
+
+		var total int64
+		for _, result := range iteration.Results {
+			total += result.Duration
+			testCase := junitCase{
+				Name:      result.Path,
+				ClassName: result.Service + "/" + result.Method,
+				Time:      seconds(result.Duration),
+			}
+			switch result.Status {
+			case StatusFailed:
+				suite.Failures++
+				failure := result.failure()
+				testCase.Failure = &junitMessage{
+					Message: strings.SplitN(failure, "\n", 2)[0],
+					Type:    result.ErrorKind,
+					Text:    failure,
+				}
+			case StatusSkipped:
+				suite.Skipped++
+				testCase.Skipped = &junitMessage{Message: "not run"}
+			}
+			if result.Response != "" {
+				testCase.SystemOut = result.Response
+			}
+			suite.Tests++
+			suite.Cases = append(suite.Cases, testCase)
+		}
+		suite.Time = seconds(total)
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
@@ -0,0 +1,198 @@
+// Package runner прогоняет запросы коллекции по порядку: итерации по файлу данных,
+// передача переменных между запросами, остановка на первой ошибке и отчеты JUnit и JSON.
+// Сам вызов выполняет Executor, поэтому один и тот же прогон работает и в GUI, и в консоли.
+package runner
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/models"
+)
+
+// Executor выполняет один сохраненный запрос со всеми шагами приложения:
+// подстановка переменных, скрипты, извлечение значений и проверки.
+type Executor interface {
+	// Execute отправляет запрос. vars - переменные прогона, они перекрывают переменные окружения.
+	Execute(request *models.SavedRequest, vars map[string]string) (*Outcome, error)
+}
+
+// Outcome - итог одного вызова.
+type Outcome struct {
+	Code          codes.Code
+	StatusMessage string
+	ErrorKind     string
+	Error         string
+	Response      string
+	Duration      time.Duration
+	Assertions    []assertion.Result
+	// Vars - значения, извлеченные из ответа и заданные скриптами, они видны следующим запросам
+	Vars map[string]string
+}
+
+// Item - запрос коллекции с путем папок от корня.
+type Item struct {
+	Request models.SavedRequest
+	Folders []string
+}
+
+// Path - путь запроса внутри коллекции: "Папка / Подпапка / Запрос".
+func (i Item) Path() string {
+	return strings.Join(append(append([]string{}, i.Folders...), i.Request.Name), " / ")
+}
+
+// Plan раскладывает дерево коллекции в порядок прогона: обход в глубину, внутри папки
+// в том же порядке, что и в дереве - по позиции, при равенстве папки раньше запросов.
+func Plan(folders []models.Folder, requests []models.SavedRequest) []Item {
+	type node struct {
+		folder   *models.Folder
+		request  *models.SavedRequest
+		position int
+		id       uint
+	}
+
+	children := make(map[uint][]node)
+	for i := range folders {
+		f := &folders[i]
+		children[f.ParentID] = append(children[f.ParentID], node{folder: f, position: f.Position, id: f.ID})
+	}
+	for i := range requests {
+		r := &requests[i]
+		children[r.FolderID] = append(children[r.FolderID], node{request: r, position: r.Position, id: r.ID})
+	}
+	for _, nodes := range children {
+		sort.SliceStable(nodes, func(i, j int) bool {
+			a, b := nodes[i], nodes[j]
+			if a.position != b.position {
+				return a.position < b.position
+			}
+			if (a.folder != nil) != (b.folder != nil) {
+				return a.folder != nil
+			}
+			return a.id < b.id
+		})
+	}
+
+	var items []Item
+	var walk func(parentID uint, path []string)
+	walk = func(parentID uint, path []string) {
+		for _, n := range children[parentID] {
+			if n.folder != nil {
+				walk(n.folder.ID, append(append([]string{}, path...), n.folder.Name))
+				continue
+			}
+			items = append(items, Item{Request: *n.request, Folders: path})
+		}
+	}
+	walk(0, nil)
+	return items
+}
+
+// Options - настройки прогона.
+type Options struct {
+	// Data - строки файла данных, каждая строка - отдельная итерация со своими переменными.
+	// Без данных коллекция проходит один раз
+	Data []map[string]string
+	// StopOnFailure - после первого упавшего запроса остальные пропускаются
+	StopOnFailure bool
+	// OnResult вызывается после каждого запроса, например для прогресса в GUI
+	OnResult func(iteration int, result Result)
+}
+
+// Run прогоняет запросы по порядку. Переменные, извлеченные из ответа, видны следующим запросам
+// той же итерации, каждая итерация начинается только со своей строки данных.
+// Отмена ctx пропускает оставшиеся запросы.
+func Run(ctx context.Context, collection string, items []Item, executor Executor, opts Options) *Report {
+	report := &Report{Collection: collection, StartedAt: time.Now()}
+
+	data := opts.Data
+	if len(data) == 0 {
+		data = []map[string]string{nil}
+	}
+
+	for index, row := range data {
+		if report.Stopped {
+			break
+		}
+
+		iteration := Iteration{Index: index + 1, Data: row}
+		vars := make(map[string]string, len(row))
+		for key, value := range row {
+			vars[key] = value
+		}
+
+		for _, item := range items {
+			var result Result
+			if report.Stopped || ctx.Err() != nil {
+				report.Stopped = true
+				result = newResult(item)
+				result.Status = StatusSkipped
+			} else {
+				result = execute(executor, item, vars)
+				report.Stopped = opts.StopOnFailure && result.Status == StatusFailed
+			}
+
+			iteration.Results = append(iteration.Results, result)
+			report.Summary.add(result)
+			if opts.OnResult != nil {
+				opts.OnResult(iteration.Index, result)
+			}
+		}
+		report.Iterations = append(report.Iterations, iteration)
+	}
+
+	report.Duration = time.Since(report.StartedAt).Milliseconds()
+	return report
+}
+
+func execute(executor Executor, item Item, vars map[string]string) Result {
+	result := newResult(item)
+	request := item.Request
+
+	outcome, err := executor.Execute(&request, vars)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	for key, value := range outcome.Vars {
+		vars[key] = value
+	}
+
+	result.Code = int32(outcome.Code)
+	result.CodeName = outcome.Code.String()
+	result.StatusMessage = outcome.StatusMessage
+	result.ErrorKind = outcome.ErrorKind
+	result.Error = outcome.Error
+	result.Response = outcome.Response
+	result.Duration = outcome.Duration.Milliseconds()
+	result.Assertions = outcome.Assertions
+
+	// С проверками исход решают они, так ожидаемый NOT_FOUND не считается ошибкой.
+	// Без проверок запрос должен пройти без ошибки
+	passed := outcome.Code == codes.OK && outcome.Error == ""
+	if len(outcome.Assertions) > 0 {
+		passed = assertion.Passed(outcome.Assertions)
+	}
+	result.Status = StatusFailed
+	if passed {
+		result.Status = StatusPassed
+	}
+	return result
+}
+
+func newResult(item Item) Result {
+	return Result{
+		RequestID: item.Request.ID,
+		Name:      item.Request.Name,
+		Path:      item.Path(),
+		Service:   item.Request.Service,
+		Method:    item.Request.Method,
+	}
+}
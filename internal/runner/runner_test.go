@@ -0,0 +1,200 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"grpc-gui/internal/assertion"
+	"grpc-gui/internal/models"
+)
+
+// fakeExecutor отвечает по имени запроса: "fail" падает проверкой, "notfound" возвращает NOT_FOUND,
+// "expected-notfound" - NOT_FOUND с пройденной проверкой, "error" - ошибку исполнителя.
+// Payload запоминается с подставленными переменными.
+type fakeExecutor struct {
+	calls []string
+}
+
+func (e *fakeExecutor) Execute(request *models.SavedRequest, vars map[string]string) (*Outcome, error) {
+	payload := request.Payload
+	for key, value := range vars {
+		payload = strings.ReplaceAll(payload, "{{"+key+"}}", value)
+	}
+	e.calls = append(e.calls, request.Name+":"+payload)
+
+	outcome := &Outcome{Duration: 15 * time.Millisecond, Vars: map[string]string{"last": request.Name}}
+	switch request.Name {
+	case "fail":
+		outcome.Assertions = []assertion.Result{{
+			Assertion: assertion.Assertion{Kind: assertion.KindBody, Path: "$.id", Operator: assertion.OpEquals, Expected: "1"},
+			Actual:    "2",
+			Message:   "expected 1",
+		}}
+	case "notfound":
+		outcome.Code = codes.NotFound
+		outcome.StatusMessage = "no user"
+		outcome.ErrorKind = "status"
+	case "expected-notfound":
+		outcome.Code = codes.NotFound
+		outcome.Assertions = []assertion.Result{{Assertion: assertion.Assertion{Kind: assertion.KindStatus}, Passed: true}}
+	case "error":
+		return nil, fmt.Errorf("server not found")
+	}
+	return outcome, nil
+}
+
+func TestPlan(t *testing.T) {
+	folders := []models.Folder{
+		{ID: 1, ParentID: 0, Position: 1, Name: "users"},
+		{ID: 2, ParentID: 1, Position: 0, Name: "admin"},
+		{ID: 3, ParentID: 0, Position: 0, Name: "auth"},
+	}
+	requests := []models.SavedRequest{
+		{ID: 1, FolderID: 0, Position: 2, Name: "cleanup"},
+		{ID: 2, FolderID: 1, Position: 1, Name: "list"},
+		{ID: 3, FolderID: 2, Position: 0, Name: "ban"},
+		{ID: 4, FolderID: 3, Position: 0, Name: "login"},
+		{ID: 5, FolderID: 1, Position: 0, Name: "create"},
+	}
+
+	var paths []string
+	for _, item := range Plan(folders, requests) {
+		paths = append(paths, item.Path())
+	}
+	want := "auth / login,users / admin / ban,users / create,users / list,cleanup"
+	if got := strings.Join(paths, ","); got != want {
+		t.Errorf("Plan() = %s, want %s", got, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	items := []Item{
+		{Request: models.SavedRequest{Name: "first", Payload: "{{user}} {{last}}"}},
+		{Request: models.SavedRequest{Name: "expected-notfound"}},
+		{Request: models.SavedRequest{Name: "second", Payload: "{{user}} {{last}}"}},
+	}
+	executor := &fakeExecutor{}
+	var progress []string
+	report := Run(context.Background(), "api", items, executor, Options{
+		Data: []map[string]string{{"user": "alice"}, {"user": "bob"}},
+		OnResult: func(iteration int, result Result) {
+			progress = append(progress, fmt.Sprintf("%d:%s", iteration, result.Name))
+		},
+	})
+
+	// Переменные передаются между запросами итерации, новая итерация начинается только со своей строки
+	want := "first:alice {{last}},expected-notfound:,second:alice expected-notfound," +
+		"first:bob {{last}},expected-notfound:,second:bob expected-notfound"
+	if got := strings.Join(executor.calls, ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+	if report.Summary != (Summary{Total: 6, Passed: 6}) || !report.Passed() || len(progress) != 6 || progress[3] != "2:first" {
+		t.Errorf("unexpected report: %+v progress=%v", report.Summary, progress)
+	}
+	if report.Iterations[1].Results[1].CodeName != "NotFound" || report.Iterations[1].Results[0].Duration != 15 {
+		t.Errorf("unexpected result: %+v", report.Iterations[1].Results)
+	}
+}
+
+func TestRun_Failures(t *testing.T) {
+	items := []Item{
+		{Request: models.SavedRequest{Name: "notfound"}},
+		{Request: models.SavedRequest{Name: "error"}},
+		{Request: models.SavedRequest{Name: "fail"}},
+		{Request: models.SavedRequest{Name: "ok"}},
+	}
+
+	report := Run(context.Background(), "api", items, &fakeExecutor{}, Options{})
+	if report.Summary != (Summary{Total: 4, Passed: 1, Failed: 3}) || report.Stopped || report.Passed() {
+		t.Errorf("expected failures without stop, got %+v", report.Summary)
+	}
+	if results := report.Iterations[0].Results; results[1].Error != "server not found" {
+		t.Errorf("expected executor error in result, got %+v", results[1])
+	}
+
+	executor := &fakeExecutor{}
+	report = Run(context.Background(), "api", items[2:], executor, Options{
+		StopOnFailure: true,
+		Data:          []map[string]string{{"n": "1"}, {"n": "2"}},
+	})
+	if report.Summary != (Summary{Total: 2, Failed: 1, Skipped: 1}) || !report.Stopped || len(report.Iterations) != 1 || len(executor.calls) != 1 {
+		t.Errorf("expected stop on first failure, got %+v %v", report.Summary, executor.calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report = Run(ctx, "api", items, &fakeExecutor{}, Options{})
+	if report.Summary.Skipped != 4 || !report.Stopped {
+		t.Errorf("expected cancelled run to skip everything, got %+v", report.Summary)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	items := []Item{
+		{Request: models.SavedRequest{ID: 7, Name: "fail", Service: "users.Users", Method: "Get"}, Folders: []string{"users"}},
+		{Request: models.SavedRequest{Name: "notfound", Service: "users.Users", Method: "Get"}},
+		{Request: models.SavedRequest{Name: "ok", Service: "users.Users", Method: "List"}},
+	}
+	report := Run(context.Background(), "api & co", items, &fakeExecutor{}, Options{
+		StopOnFailure: true,
+		Data:          []map[string]string{{"user": "alice"}},
+	})
+
+	var junit bytes.Buffer
+	if err := report.Write(&junit, FormatJUnit); err != nil {
+		t.Fatalf("Write(junit) failed: %v", err)
+	}
+	for _, want := range []string{
+		`<testsuites name="api &amp; co" tests="3" failures="1" skipped="2" time=`,
+		`<property name="user" value="alice"></property>`,
+		`<testcase name="users / fail" classname="users.Users/Get" time="0.015">`,
+		`<failure message="body $.id eq 1: expected 1">body $.id eq 1: expected 1</failure>`,
+		`<skipped message="not run"></skipped>`,
+	} {
+		if !strings.Contains(junit.String(), want) {
+			t.Errorf("junit report misses %s:\n%s", want, junit.String())
+		}
+	}
+
+	var data bytes.Buffer
+	if err := report.Write(&data, FormatJSON); err != nil {
+		t.Fatalf("Write(json) failed: %v", err)
+	}
+	if !strings.Contains(data.String(), `"requestId": 7`) || !strings.Contains(data.String(), `"status": "skipped"`) {
+		t.Errorf("unexpected json report: %s", data.String())
+	}
+
+	if err := report.Write(&data, "yaml"); err == nil {
+		t.Error("expected unknown format error")
+	}
+}
+
+func TestParseData(t *testing.T) {
+	rows, err := ParseData("users.csv", []byte("\ufeffname, id\nalice,1\n\"bob, jr\",2\n"))
+	if err != nil || len(rows) != 2 || rows[1]["name"] != "bob, jr" || rows[0]["id"] != "1" {
+		t.Errorf("ParseData(csv) = %v, %v", rows, err)
+	}
+
+	rows, err = ParseData("users.JSON", []byte(`[{"name": "alice", "id": 12345678901234567890, "tags": ["a"]}, {"name": null}]`))
+	if err != nil || len(rows) != 2 || rows[0]["id"] != "12345678901234567890" || rows[0]["tags"] != `["a"]` || rows[1]["name"] != "null" {
+		t.Errorf("ParseData(json) = %v, %v", rows, err)
+	}
+
+	for name, data := range map[string]string{
+		"users.txt":  "name\nalice",
+		"bad.csv":    "name,id\nalice",
+		"bad.json":   `{"name": "alice"}`,
+		"names.csv":  "user name\nalice",
+		"names.json": `[{"1st": "alice"}]`,
+	} {
+		if _, err := ParseData(name, []byte(data)); err == nil {
+			t.Errorf("ParseData(%s) expected error", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package scripting
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/google/uuid"
+)
+
+// Вспомогательные функции скриптов:
+//
+//	crypto.hash("sha256", data)            хеш, по умолчанию в hex
+//	crypto.hmac("sha256", key, data, "base64")
+//	crypto.uuid()
+//	encoding.base64Encode(s), encoding.base64Decode(s), encoding.hexEncode(s), encoding.hexDecode(s)
+//
+// Алгоритмы: md5, sha1, sha256, sha512. Выходной формат: hex или base64.
+
+func hashFunc(name string) (func() hash.Hash, error) {
+	switch strings.ToLower(strings.ReplaceAll(name, "-", "")) {
+	case "md5":
+		return md5.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	}
+	return nil, fmt.Errorf("unsupported hash algorithm %q", name)
+}
+
+func encodeDigest(sum []byte, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", "hex":
+		return hex.EncodeToString(sum), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(sum), nil
+	}
+	return "", fmt.Errorf("unsupported output encoding %q", format)
+}
+
+func newCrypto(vm *goja.Runtime) *goja.Object {
+	// throw превращает ошибку Go в исключение JS, которое скрипт может поймать
+	throw := func(err error) {
+		panic(vm.NewGoError(err))
+	}
+
+	obj := vm.NewObject()
+	_ = obj.Set("hash", func(algorithm, data string, format string) string {
+		newHash, err := hashFunc(algorithm)
+		if err != nil {
+			throw(err)
+		}
+		h := newHash()
+		h.Write([]byte(data))
+		out, err := encodeDigest(h.Sum(nil), format)
+		if err != nil {
+			throw(err)
+		}
+		return out
+	})
+	_ = obj.Set("hmac", func(algorithm, key, data string, format string) string {
+		newHash, err := hashFunc(algorithm)
+		if err != nil {
+			throw(err)
+		}
+		mac := hmac.New(newHash, []byte(key))
+		mac.Write([]byte(data))
+		out, err := encodeDigest(mac.Sum(nil), format)
+		if err != nil {
+			throw(err)
+		}
+		return out
+	})
+	_ = obj.Set("uuid", uuid.NewString)
+	return obj
+}
+
+func newEncoding(vm *goja.Runtime) *goja.Object {
+	obj := vm.NewObject()
+	_ = obj.Set("base64Encode", func(data string) string {
+		return base64.StdEncoding.EncodeToString([]byte(data))
+	})
+	_ = obj.Set("base64Decode", func(data string) string {
+		out, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("invalid base64: %w", err)))
+		}
+		return string(out)
+	})
+	_ = obj.Set("hexEncode", func(data string) string {
+		return hex.EncodeToString([]byte(data))
+	})
+	_ = obj.Set("hexDecode", func(data string) string {
+		out, err := hex.DecodeString(data)
+		if err != nil {
+			panic(vm.NewGoError(fmt.Errorf("invalid hex: %w", err)))
+		}
+		return string(out)
+	})
+	return obj
+}
@@ -0,0 +1,289 @@
+package scripting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// DefaultTimeout - сколько может выполняться один скрипт, после этого он прерывается.
+const DefaultTimeout = 5 * time.Second
+
+type Phase string
+
+const (
+	PhasePreRequest   Phase = "pre-request"
+	PhasePostResponse Phase = "post-response"
+)
+
+// LogEntry - строка console.log и ошибки скрипта.
+type LogEntry struct {
+	Phase   Phase  `json:"phase"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// Request - запрос, который pre-request скрипт может изменить: тело и метаданные.
+type Request struct {
+	Service  string
+	Method   string
+	Address  string
+	Payload  string
+	Metadata map[string]string
+}
+
+// Response - ответ сервера, доступен только post-response скрипту.
+type Response struct {
+	Code          int32
+	Status        string
+	Message       string
+	Body          string
+	Headers       map[string][]string
+	Trailers      map[string][]string
+	ExecutionTime int32
+}
+
+// Env - то, что видит скрипт. Vars только читается, изменения переменных возвращаются в Outcome.
+type Env struct {
+	Request  *Request
+	Response *Response
+	Vars     map[string]string
+}
+
+// Outcome - результат выполнения: логи и переменные, которые скрипт задал через vars.set.
+type Outcome struct {
+	Logs []LogEntry
+	Vars map[string]string
+}
+
+// Check проверяет синтаксис скрипта без выполнения.
+func Check(source string) error {
+	if strings.TrimSpace(source) == "" {
+		return nil
+	}
+	_, err := goja.Compile("script", source, false)
+	return err
+}
+
+// Run выполняет скрипт в отдельной песочнице: без доступа к файлам, сети и процессу.
+// Для PhasePreRequest изменения тела и метаданных записываются обратно в env.Request.
+// Логи возвращаются и при ошибке.
+func Run(phase Phase, source string, env *Env, timeout time.Duration) (*Outcome, error) {
+	outcome := &Outcome{Vars: make(map[string]string)}
+	if strings.TrimSpace(source) == "" {
+		return outcome, nil
+	}
+
+	program, err := goja.Compile(string(phase), source, false)
+	if err != nil {
+		return outcome, err
+	}
+
+	vm := goja.New()
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	timer := time.AfterFunc(timeout, func() {
+		vm.Interrupt(fmt.Sprintf("script timed out after %s", timeout))
+	})
+	defer timer.Stop()
+
+	log := func(level string) func(goja.FunctionCall) goja.Value {
+		return func(call goja.FunctionCall) goja.Value {
+			parts := make([]string, len(call.Arguments))
+			for i, arg := range call.Arguments {
+				parts[i] = format(arg)
+			}
+			outcome.Logs = append(outcome.Logs, LogEntry{Phase: phase, Level: level, Message: strings.Join(parts, " ")})
+			return goja.Undefined()
+		}
+	}
+
+	console := vm.NewObject()
+	for _, level := range []string{"log", "info", "warn", "error"} {
+		_ = console.Set(level, log(level))
+	}
+	_ = vm.Set("console", console)
+	_ = vm.Set("vars", newVars(vm, env.Vars, outcome.Vars))
+	_ = vm.Set("crypto", newCrypto(vm))
+	_ = vm.Set("encoding", newEncoding(vm))
+
+	var request *goja.Object
+	if env.Request != nil {
+		request = newRequest(vm, env.Request)
+		_ = vm.Set("request", request)
+	}
+	if env.Response != nil {
+		_ = vm.Set("response", newResponse(vm, env.Response))
+	}
+
+	if _, err := vm.RunProgram(program); err != nil {
+		return outcome, scriptError(err)
+	}
+
+	if phase == PhasePreRequest && request != nil {
+		if err := readRequest(request, env.Request); err != nil {
+			return outcome, err
+		}
+	}
+	return outcome, nil
+}
+
+func scriptError(err error) error {
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		return fmt.Errorf("%v", interrupted.Value())
+	}
+	var exception *goja.Exception
+	if errors.As(err, &exception) {
+		return errors.New(exception.String())
+	}
+	return err
+}
+
+func newRequest(vm *goja.Runtime, req *Request) *goja.Object {
+	obj := vm.NewObject()
+	_ = obj.Set("service", req.Service)
+	_ = obj.Set("method", req.Method)
+	_ = obj.Set("address", req.Address)
+	_ = obj.Set("payload", req.Payload)
+
+	metadata := vm.NewObject()
+	for key, value := range req.Metadata {
+		_ = metadata.Set(key, value)
+	}
+	_ = obj.Set("metadata", metadata)
+
+	// json() разбирает текущее тело, setJSON(obj) записывает объект обратно
+	_ = obj.Set("json", func() goja.Value {
+		return parseJSON(vm, obj.Get("payload").String())
+	})
+	_ = obj.Set("setJSON", func(value goja.Value) {
+		_ = obj.Set("payload", stringifyJSON(vm, value))
+	})
+	return obj
+}
+
+func readRequest(obj *goja.Object, req *Request) error {
+	req.Payload = obj.Get("payload").String()
+
+	metadata := obj.Get("metadata")
+	if metadata == nil || goja.IsUndefined(metadata) || goja.IsNull(metadata) {
+		req.Metadata = nil
+		return nil
+	}
+	values, ok := metadata.Export().(map[string]any)
+	if !ok {
+		return fmt.Errorf("request.metadata must be an object")
+	}
+
+	req.Metadata = make(map[string]string, len(values))
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		req.Metadata[key] = fmt.Sprint(value)
+	}
+	return nil
+}
+
+func newResponse(vm *goja.Runtime, resp *Response) *goja.Object {
+	obj := vm.NewObject()
+	_ = obj.Set("code", resp.Code)
+	_ = obj.Set("status", resp.Status)
+	_ = obj.Set("message", resp.Message)
+	_ = obj.Set("body", resp.Body)
+	_ = obj.Set("time", resp.ExecutionTime)
+	_ = obj.Set("headers", metadataObject(vm, resp.Headers))
+	_ = obj.Set("trailers", metadataObject(vm, resp.Trailers))
+	_ = obj.Set("json", func() goja.Value {
+		return parseJSON(vm, resp.Body)
+	})
+	return obj
+}
+
+// metadataObject отдает метаданные ответа объектом "ключ -> значения через запятую".
+func metadataObject(vm *goja.Runtime, md map[string][]string) *goja.Object {
+	obj := vm.NewObject()
+	for key, values := range md {
+		_ = obj.Set(key, strings.Join(values, ","))
+	}
+	return obj
+}
+
+func newVars(vm *goja.Runtime, vars, changed map[string]string) *goja.Object {
+	lookup := func(name string) (string, bool) {
+		if value, ok := changed[name]; ok {
+			return value, true
+		}
+		value, ok := vars[name]
+		return value, ok
+	}
+
+	obj := vm.NewObject()
+	_ = obj.Set("get", func(name string) goja.Value {
+		if value, ok := lookup(name); ok {
+			return vm.ToValue(value)
+		}
+		return goja.Undefined()
+	})
+	_ = obj.Set("has", func(name string) bool {
+		_, ok := lookup(name)
+		return ok
+	})
+	_ = obj.Set("set", func(name string, value goja.Value) {
+		changed[name] = format(value)
+	})
+	_ = obj.Set("all", func() map[string]string {
+		all := make(map[string]string, len(vars)+len(changed))
+		for key, value := range vars {
+			all[key] = value
+		}
+		for key, value := range changed {
+			all[key] = value
+		}
+		return all
+	})
+	return obj
+}
+
+func parseJSON(vm *goja.Runtime, text string) goja.Value {
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		panic(vm.NewGoError(fmt.Errorf("invalid JSON: %w", err)))
+	}
+	return vm.ToValue(value)
+}
+
+func stringifyJSON(vm *goja.Runtime, value goja.Value) string {
+	data, err := json.Marshal(value.Export())
+	if err != nil {
+		panic(vm.NewGoError(err))
+	}
+	return string(data)
+}
+
+// format печатает значение как console.log: строки как есть, объекты JSON.
+func format(value goja.Value) string {
+	if value == nil || goja.IsUndefined(value) {
+		return "undefined"
+	}
+	if goja.IsNull(value) {
+		return "null"
+	}
+
+	switch exported := value.Export().(type) {
+	case string:
+		return exported
+	case map[string]any, []any:
+		data, err := json.Marshal(exported)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return value.String()
+}
@@ -0,0 +1,150 @@
+package scripting
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_PreRequest(t *testing.T) {
+	env := &Env{
+		Request: &Request{
+			Service:  "svc.Users",
+			Method:   "Get",
+			Payload:  `{"id": 1}`,
+			Metadata: map[string]string{"x-old": "1"},
+		},
+		Vars: map[string]string{"secret": "key"},
+	}
+
+	source := `
+		const body = request.json();
+		body.id += 1;
+		request.setJSON(body);
+		request.metadata["x-signature"] = crypto.hmac("sha256", vars.get("secret"), request.payload);
+		delete request.metadata["x-old"];
+		vars.set("last_id", body.id);
+		console.log("signed", request.method, {id: body.id});
+	`
+	outcome, err := Run(PhasePreRequest, source, env, 0)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if env.Request.Payload != `{"id":2}` {
+		t.Errorf("expected modified payload, got %s", env.Request.Payload)
+	}
+	// echo -n '{"id":2}' | openssl dgst -sha256 -hmac key
+	if sig := env.Request.Metadata["x-signature"]; sig != "93a9ddd5bebc61febf43ffd787508794b1b64cef841178cf48d718f2cd32e19f" {
+		t.Errorf("unexpected signature %q", sig)
+	}
+	if _, ok := env.Request.Metadata["x-old"]; ok {
+		t.Error("expected deleted metadata to be removed")
+	}
+	if outcome.Vars["last_id"] != "2" {
+		t.Errorf("expected variable to be set, got %v", outcome.Vars)
+	}
+	if len(outcome.Logs) != 1 || outcome.Logs[0].Message != `signed Get {"id":2}` || outcome.Logs[0].Phase != PhasePreRequest {
+		t.Errorf("unexpected logs: %+v", outcome.Logs)
+	}
+}
+
+func TestRun_PostResponse(t *testing.T) {
+	env := &Env{
+		Response: &Response{
+			Code:     5,
+			Status:   "NotFound",
+			Message:  "user not found",
+			Body:     "",
+			Trailers: map[string][]string{"x-retry": {"a", "b"}},
+		},
+		Vars: map[string]string{},
+	}
+
+	source := `
+		if (response.code !== 0) {
+			console.warn(response.status, response.message, response.trailers["x-retry"]);
+			vars.set("failed", true);
+		}
+	`
+	outcome, err := Run(PhasePostResponse, source, env, 0)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(outcome.Logs) != 1 || outcome.Logs[0].Level != "warn" || outcome.Logs[0].Message != "NotFound user not found a,b" {
+		t.Errorf("unexpected logs: %+v", outcome.Logs)
+	}
+	if outcome.Vars["failed"] != "true" {
+		t.Errorf("expected variable to be set, got %v", outcome.Vars)
+	}
+}
+
+func TestRun_Helpers(t *testing.T) {
+	source := `
+		console.log(crypto.hash("sha256", "abc"));
+		console.log(crypto.hmac("sha256", "key", "The quick brown fox jumps over the lazy dog"));
+		console.log(crypto.hash("md5", "", "base64"));
+		console.log(encoding.base64Encode("hi"), encoding.base64Decode("aGk="), encoding.hexEncode("hi"));
+		console.log(crypto.uuid().length);
+	`
+	outcome, err := Run(PhasePreRequest, source, &Env{}, 0)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := []string{
+		"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		"1B2M2Y8AsgTpgAmY7PhCfg==",
+		"aGk= hi 6869",
+		"36",
+	}
+	if len(outcome.Logs) != len(want) {
+		t.Fatalf("expected %d logs, got %+v", len(want), outcome.Logs)
+	}
+	for i, message := range want {
+		if outcome.Logs[i].Message != message {
+			t.Errorf("log %d: expected %q, got %q", i, message, outcome.Logs[i].Message)
+		}
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{"syntax", "let = ;", "SyntaxError"},
+		{"throw", `console.log("before"); throw new Error("boom");`, "boom"},
+		{"helper", `crypto.hash("sha3", "x")`, "unsupported hash algorithm"},
+		{"invalid json", `request.json()`, "invalid JSON"},
+		{"sandbox", `require("fs")`, "require is not defined"},
+		{"timeout", `while (true) {}`, "timed out"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &Env{Request: &Request{Payload: "not json"}}
+			outcome, err := Run(PhasePreRequest, tt.source, env, 100*time.Millisecond)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if tt.name == "throw" && len(outcome.Logs) != 1 {
+				t.Errorf("expected logs before the error, got %+v", outcome.Logs)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(""); err != nil {
+		t.Errorf("expected empty script to be valid, got %v", err)
+	}
+	if err := Check("vars.set('a', 1)"); err != nil {
+		t.Errorf("expected valid script, got %v", err)
+	}
+	if err := Check("function ("); err == nil {
+		t.Error("expected syntax error")
+	}
+}
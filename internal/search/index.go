@@ -0,0 +1,318 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"grpc-gui/internal/grpcreflect"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type Kind string
+
+const (
+	KindService   Kind = "service"
+	KindMethod    Kind = "method"
+	KindMessage   Kind = "message"
+	KindField     Kind = "field"
+	KindEnum      Kind = "enum"
+	KindEnumValue Kind = "enum_value"
+)
+
+// Entry - найденный символ и координаты, по которым его можно открыть.
+// Для сообщений, полей и перечислений Service/Method указывают на первый метод, который их использует.
+type Entry struct {
+	Kind       Kind   `json:"kind"`
+	Name       string `json:"name"`
+	FullName   string `json:"fullName"`
+	Comment    string `json:"comment,omitempty"`
+	ServerID   uint   `json:"serverId"`
+	ServerName string `json:"serverName"`
+	Service    string `json:"service,omitempty"`
+	Method     string `json:"method,omitempty"`
+	// Message - сообщение или перечисление, которому принадлежит поле или значение
+	Message string `json:"message,omitempty"`
+}
+
+type Hit struct {
+	Entry Entry `json:"entry"`
+	Score int   `json:"score"`
+}
+
+// Index - индекс символов по закэшированной рефлексии всех серверов.
+type Index struct {
+	entries []Entry
+}
+
+func NewIndex() *Index {
+	return &Index{}
+}
+
+func (idx *Index) Len() int {
+	return len(idx.entries)
+}
+
+// AddServer добавляет символы сервера. Если сохранены дескрипторы, в индекс попадают
+// сообщения, поля, перечисления и комментарии, иначе только то, что есть в списке сервисов.
+func (idx *Index) AddServer(serverID uint, serverName string, services *grpcreflect.ServicesInfo, descriptors []byte) {
+	if services == nil {
+		return
+	}
+
+	base := Entry{ServerID: serverID, ServerName: serverName}
+
+	files, err := grpcreflect.FilesFromDescriptorSet(descriptors)
+	if err != nil {
+		idx.addServicesInfo(base, services)
+		return
+	}
+
+	seen := make(map[protoreflect.FullName]bool)
+	for _, serviceInfo := range services.Services {
+		d, err := files.FindDescriptorByName(protoreflect.FullName(serviceInfo.Name))
+		service, ok := d.(protoreflect.ServiceDescriptor)
+		if err != nil || !ok {
+			idx.addServicesInfo(base, &grpcreflect.ServicesInfo{Services: []grpcreflect.ServiceInfo{serviceInfo}})
+			continue
+		}
+
+		idx.add(base, KindService, service, "", "", "")
+
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+			serviceName, methodName := string(service.FullName()), string(method.Name())
+
+			idx.add(base, KindMethod, method, serviceName, methodName, "")
+			idx.addMessage(base, method.Input(), serviceName, methodName, seen)
+			idx.addMessage(base, method.Output(), serviceName, methodName, seen)
+		}
+	}
+}
+
+func (idx *Index) addServicesInfo(base Entry, services *grpcreflect.ServicesInfo) {
+	seen := make(map[string]bool)
+	addMessage := func(fullName, service, method string) {
+		if fullName == "" || seen[fullName] {
+			return
+		}
+		seen[fullName] = true
+
+		entry := base
+		entry.Kind = KindMessage
+		entry.Name = shortName(fullName)
+		entry.FullName = fullName
+		entry.Service = service
+		entry.Method = method
+		idx.entries = append(idx.entries, entry)
+	}
+
+	for _, service := range services.Services {
+		entry := base
+		entry.Kind = KindService
+		entry.Name = shortName(service.Name)
+		entry.FullName = service.Name
+		idx.entries = append(idx.entries, entry)
+
+		for _, method := range service.Methods {
+			entry := base
+			entry.Kind = KindMethod
+			entry.Name = method.Name
+			entry.FullName = service.Name + "." + method.Name
+			entry.Service = service.Name
+			entry.Method = method.Name
+			idx.entries = append(idx.entries, entry)
+
+			addMessage(method.RequestType, service.Name, method.Name)
+			addMessage(method.ResponseType, service.Name, method.Name)
+		}
+	}
+}
+
+// addMessage добавляет сообщение и все достижимые из него типы.
+func (idx *Index) addMessage(base Entry, msg protoreflect.MessageDescriptor, service, method string, seen map[protoreflect.FullName]bool) {
+	if msg.IsMapEntry() {
+		// Служебные сообщения map не показываем, но типы значений индексируем
+		idx.addFieldTypes(base, msg.Fields(), service, method, seen)
+		return
+	}
+	if seen[msg.FullName()] {
+		return
+	}
+	seen[msg.FullName()] = true
+
+	// Well-known типы есть почти у каждого сервера и только засоряют выдачу
+	if strings.HasPrefix(string(msg.FullName()), "google.protobuf.") {
+		return
+	}
+
+	idx.add(base, KindMessage, msg, service, method, "")
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		idx.add(base, KindField, fields.Get(i), service, method, string(msg.FullName()))
+	}
+	idx.addFieldTypes(base, fields, service, method, seen)
+}
+
+func (idx *Index) addFieldTypes(base Entry, fields protoreflect.FieldDescriptors, service, method string, seen map[protoreflect.FullName]bool) {
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Message() != nil {
+			idx.addMessage(base, field.Message(), service, method, seen)
+		}
+		if enum := field.Enum(); enum != nil && !seen[enum.FullName()] {
+			seen[enum.FullName()] = true
+			if strings.HasPrefix(string(enum.FullName()), "google.protobuf.") {
+				continue
+			}
+
+			idx.add(base, KindEnum, enum, service, method, "")
+			values := enum.Values()
+			for j := 0; j < values.Len(); j++ {
+				idx.add(base, KindEnumValue, values.Get(j), service, method, string(enum.FullName()))
+			}
+		}
+	}
+}
+
+func (idx *Index) add(base Entry, kind Kind, d protoreflect.Descriptor, service, method, parent string) {
+	entry := base
+	entry.Kind = kind
+	entry.Name = string(d.Name())
+	entry.FullName = string(d.FullName())
+	entry.Comment = descriptorComment(d)
+	entry.Service = service
+	entry.Method = method
+	entry.Message = parent
+	idx.entries = append(idx.entries, entry)
+}
+
+func descriptorComment(d protoreflect.Descriptor) string {
+	location := d.ParentFile().SourceLocations().ByDescriptor(d)
+	comment := location.LeadingComments
+	if comment == "" {
+		comment = location.TrailingComments
+	}
+	return strings.TrimSpace(comment)
+}
+
+func shortName(fullName string) string {
+	if i := strings.LastIndex(fullName, "."); i >= 0 {
+		return fullName[i+1:]
+	}
+	return fullName
+}
+
+// Search ищет символы по нечеткому совпадению с именем, полным именем и комментарием.
+// Результаты отсортированы по убыванию релевантности.
+func (idx *Index) Search(query string, limit int) []Hit {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []Hit{}
+	}
+
+	hits := []Hit{}
+	for _, entry := range idx.entries {
+		score := matchScore(query, entry.Name)
+		if full := matchScore(query, entry.FullName) - 50; full > score {
+			score = full
+		}
+		if score <= 0 && entry.Comment != "" && strings.Contains(strings.ToLower(entry.Comment), query) {
+			score = 50
+		}
+		if score <= 0 {
+			continue
+		}
+
+		hits = append(hits, Hit{Entry: entry, Score: score + kindWeight[entry.Kind]})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Entry.ServerName != hits[j].Entry.ServerName {
+			return hits[i].Entry.ServerName < hits[j].Entry.ServerName
+		}
+		return hits[i].Entry.FullName < hits[j].Entry.FullName
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// При равном совпадении сервисы и методы важнее полей
+var kindWeight = map[Kind]int{
+	KindService:   30,
+	KindMethod:    25,
+	KindMessage:   20,
+	KindEnum:      15,
+	KindField:     10,
+	KindEnumValue: 5,
+}
+
+// matchScore оценивает совпадение запроса (уже в нижнем регистре) с именем.
+// Точное совпадение > префикс > подстрока > подпоследовательность, 0 - не совпадает.
+func matchScore(query, name string) int {
+	lower := strings.ToLower(name)
+
+	switch {
+	case lower == query:
+		return 1000
+	case strings.HasPrefix(lower, query):
+		return 800 - (len(lower) - len(query))
+	}
+
+	if pos := strings.Index(lower, query); pos >= 0 {
+		score := 600 - pos - (len(lower) - len(query))
+		if isBoundary(name, pos) {
+			score += 50
+		}
+		return score
+	}
+
+	// Подпоследовательность: "gu" находит GetUser, бонус за начала слов и подряд идущие символы
+	score := 300
+	qi := 0
+	last := -1
+	for i := 0; i < len(lower) && qi < len(query); i++ {
+		if lower[i] != query[qi] {
+			continue
+		}
+		if isBoundary(name, i) {
+			score += 15
+		}
+		if last >= 0 && i == last+1 {
+			score += 10
+		} else if last >= 0 {
+			score -= i - last
+		}
+		last = i
+		qi++
+	}
+	if qi < len(query) {
+		return 0
+	}
+	score -= len(lower) - len(query)
+	if score < 0 {
+		// Символы запроса разбросаны по длинному имени - это уже не совпадение
+		return 0
+	}
+	return score
+}
+
+// isBoundary - начинается ли с позиции i новое слово: после точки, подчеркивания или в CamelCase.
+func isBoundary(name string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := name[i-1], name[i]
+	if prev == '.' || prev == '_' || prev == '/' {
+		return true
+	}
+	return cur >= 'A' && cur <= 'Z' && prev >= 'a' && prev <= 'z'
+}
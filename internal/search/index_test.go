@@ -0,0 +1,200 @@
+package search
+
+import (
+	"testing"
+
+	"grpc-gui/internal/grpcreflect"
+	testproto "grpc-gui/testserver/proto"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func descriptorSet(t *testing.T, files ...protoreflect.FileDescriptor) []byte {
+	t.Helper()
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, fd := range files {
+		add(fd)
+	}
+
+	data, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("failed to marshal descriptor set: %v", err)
+	}
+	return data
+}
+
+func testServicesInfo() *grpcreflect.ServicesInfo {
+	return &grpcreflect.ServicesInfo{Services: []grpcreflect.ServiceInfo{
+		{Name: "testserver.AnotherService", Methods: []grpcreflect.MethodInfo{
+			{Name: "GetUser", RequestType: "testserver.SimpleRequest", ResponseType: "testserver.User"},
+			{Name: "GetUsers", RequestType: "testserver.EmptyRequest", ResponseType: "testserver.ComplexResponse"},
+		}},
+		{Name: "testserver.LegacyService", Methods: []grpcreflect.MethodInfo{
+			{Name: "Describe", RequestType: "testserver.LegacyRequest", ResponseType: "testserver.LegacyResponse"},
+		}},
+	}}
+}
+
+func findHit(hits []Hit, kind Kind, fullName string) (int, *Hit) {
+	for i := range hits {
+		if hits[i].Entry.Kind == kind && hits[i].Entry.FullName == fullName {
+			return i, &hits[i]
+		}
+	}
+	return -1, nil
+}
+
+func TestIndex_AddServerWithDescriptors(t *testing.T) {
+	idx := NewIndex()
+	idx.AddServer(1, "users", testServicesInfo(), descriptorSet(t, testproto.File_proto_test_proto, testproto.File_proto_legacy_proto))
+
+	hits := idx.Search("GetUser", 0)
+	if len(hits) == 0 || hits[0].Entry.Kind != KindMethod || hits[0].Entry.Name != "GetUser" {
+		t.Fatalf("expected exact method match first, got %+v", hits)
+	}
+	if hits[0].Entry.ServerID != 1 || hits[0].Entry.ServerName != "users" ||
+		hits[0].Entry.Service != "testserver.AnotherService" || hits[0].Entry.Method != "GetUser" {
+		t.Errorf("unexpected coordinates: %+v", hits[0].Entry)
+	}
+
+	// Сообщение, поле и перечисление находятся вместе с методом, который их использует
+	_, user := findHit(idx.Search("User", 0), KindMessage, "testserver.User")
+	if user == nil || user.Entry.Service != "testserver.AnotherService" || user.Entry.Method != "GetUser" {
+		t.Errorf("expected User message with method coordinates, got %+v", user)
+	}
+
+	_, field := findHit(idx.Search("email", 0), KindField, "testserver.User.email")
+	if field == nil || field.Entry.Message != "testserver.User" {
+		t.Errorf("expected User.email field, got %+v", field)
+	}
+
+	if _, enum := findHit(idx.Search("LegacyLevel", 0), KindEnum, "testserver.LegacyLevel"); enum == nil {
+		t.Error("expected LegacyLevel enum")
+	}
+
+	// Well-known типы не индексируются
+	if _, ts := findHit(idx.Search("Timestamp", 0), KindMessage, "google.protobuf.Timestamp"); ts != nil {
+		t.Error("expected well-known types to be skipped")
+	}
+}
+
+func TestIndex_AddServerWithoutDescriptors(t *testing.T) {
+	idx := NewIndex()
+	idx.AddServer(2, "legacy", testServicesInfo(), nil)
+
+	if _, hit := findHit(idx.Search("describe", 0), KindMethod, "testserver.LegacyService.Describe"); hit == nil {
+		t.Error("expected method from services list")
+	}
+	if _, hit := findHit(idx.Search("LegacyRequest", 0), KindMessage, "testserver.LegacyRequest"); hit == nil {
+		t.Error("expected request type from services list")
+	}
+	if _, hit := findHit(idx.Search("email", 0), KindField, "testserver.User.email"); hit != nil {
+		t.Error("expected no fields without descriptors")
+	}
+}
+
+func TestIndex_Comments(t *testing.T) {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("billing.proto"),
+		Package: proto.String("billing"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{Name: proto.String("Invoice")},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("BillingService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Charge"),
+				InputType:  proto.String(".billing.Invoice"),
+				OutputType: proto.String(".billing.Invoice"),
+			}},
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{Location: []*descriptorpb.SourceCodeInfo_Location{
+			{Path: []int32{6, 0, 2, 0}, Span: []int32{1, 0, 10}, LeadingComments: proto.String(" Списывает деньги с карты клиента\n")},
+		}},
+	}
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}})
+	if err != nil {
+		t.Fatalf("failed to marshal descriptor set: %v", err)
+	}
+
+	services := &grpcreflect.ServicesInfo{Services: []grpcreflect.ServiceInfo{
+		{Name: "billing.BillingService", Methods: []grpcreflect.MethodInfo{{Name: "Charge"}}},
+	}}
+
+	idx := NewIndex()
+	idx.AddServer(3, "billing", services, data)
+
+	hits := idx.Search("карты", 0)
+	if len(hits) != 1 || hits[0].Entry.FullName != "billing.BillingService.Charge" {
+		t.Fatalf("expected comment match, got %+v", hits)
+	}
+	if hits[0].Entry.Comment != "Списывает деньги с карты клиента" {
+		t.Errorf("unexpected comment: %q", hits[0].Entry.Comment)
+	}
+}
+
+func TestIndex_SearchRanking(t *testing.T) {
+	idx := NewIndex()
+	idx.AddServer(1, "users", testServicesInfo(), descriptorSet(t, testproto.File_proto_test_proto, testproto.File_proto_legacy_proto))
+
+	hits := idx.Search("getuser", 0)
+	exact, _ := findHit(hits, KindMethod, "testserver.AnotherService.GetUser")
+	prefix, _ := findHit(hits, KindMethod, "testserver.AnotherService.GetUsers")
+	if exact < 0 || prefix < 0 || exact > prefix {
+		t.Errorf("expected exact match before prefix match, got %d and %d", exact, prefix)
+	}
+
+	// Нечеткий поиск по начальным буквам слов
+	if _, hit := findHit(idx.Search("gus", 0), KindMethod, "testserver.AnotherService.GetUsers"); hit == nil {
+		t.Error("expected fuzzy match for GetUsers")
+	}
+
+	if hits := idx.Search("User", 2); len(hits) != 2 {
+		t.Errorf("expected limit to be applied, got %d hits", len(hits))
+	}
+	if hits := idx.Search("  ", 0); len(hits) != 0 {
+		t.Errorf("expected no hits for empty query, got %d", len(hits))
+	}
+	if hits := idx.Search("zzqx", 0); len(hits) != 0 {
+		t.Errorf("expected no hits, got %+v", hits)
+	}
+}
+
+func TestMatchScore(t *testing.T) {
+	cases := []struct {
+		query, name string
+		worse       string
+	}{
+		{"user", "User", "UserProfile"},
+		{"user", "UserProfile", "GetUser"},
+		{"user", "GetUser", "SuperUsualEntry"},
+	}
+	for _, c := range cases {
+		if matchScore(c.query, c.name) <= matchScore(c.query, c.worse) {
+			t.Errorf("expected %q to rank above %q for %q", c.name, c.worse, c.query)
+		}
+	}
+
+	if matchScore("xyz", "GetUser") != 0 {
+		t.Error("expected no match")
+	}
+}
@@ -0,0 +1,414 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"grpc-gui/internal/models"
+
+	"gorm.io/gorm"
+)
+
+func (s *SQLiteStorage) CreateCollection(collection *models.Collection) error {
+	return s.db.Create(collection).Error
+}
+
+func (s *SQLiteStorage) GetCollection(id uint) (*models.Collection, error) {
+	var collection models.Collection
+	if err := s.db.First(&collection, id).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (s *SQLiteStorage) GetCollections() ([]models.Collection, error) {
+	var collections []models.Collection
+	if err := s.db.Order("name ASC, id ASC").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// GetCollectionByName нужен CLI, где коллекцию указывают по имени.
+func (s *SQLiteStorage) GetCollectionByName(name string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := s.db.Where("name = ?", name).First(&collection).Error; err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (s *SQLiteStorage) UpdateCollection(collection *models.Collection) error {
+	return s.db.Save(collection).Error
+}
+
+// DeleteCollection удаляет коллекцию вместе с папками и запросами.
+func (s *SQLiteStorage) DeleteCollection(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&models.SavedRequest{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", id).Delete(&models.Folder{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Collection{}, id).Error
+	})
+}
+
+// DuplicateCollection копирует коллекцию со всей структурой папок и запросами.
+func (s *SQLiteStorage) DuplicateCollection(id uint, name string) (*models.Collection, error) {
+	var duplicate models.Collection
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var source models.Collection
+		if err := tx.First(&source, id).Error; err != nil {
+			return err
+		}
+		duplicate = models.Collection{Name: name, Description: source.Description}
+		if err := tx.Create(&duplicate).Error; err != nil {
+			return err
+		}
+
+		var folders []models.Folder
+		if err := tx.Where("collection_id = ?", id).Order("id ASC").Find(&folders).Error; err != nil {
+			return err
+		}
+
+		// Родитель создается раньше детей, поэтому идем от корня вглубь
+		folderIDs := map[uint]uint{0: 0}
+		for len(folders) > 0 {
+			var pending []models.Folder
+			for _, folder := range folders {
+				parentID, ok := folderIDs[folder.ParentID]
+				if !ok {
+					pending = append(pending, folder)
+					continue
+				}
+				oldID := folder.ID
+				folder.ID = 0
+				folder.CollectionID = duplicate.ID
+				folder.ParentID = parentID
+				if err := tx.Create(&folder).Error; err != nil {
+					return err
+				}
+				folderIDs[oldID] = folder.ID
+			}
+			if len(pending) == len(folders) {
+				return fmt.Errorf("collection %d has folders with missing parents", id)
+			}
+			folders = pending
+		}
+
+		var requests []models.SavedRequest
+		if err := tx.Where("collection_id = ?", id).Find(&requests).Error; err != nil {
+			return err
+		}
+		for _, request := range requests {
+			request.ID = 0
+			request.CollectionID = duplicate.ID
+			request.FolderID = folderIDs[request.FolderID]
+			if err := tx.Create(&request).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+func (s *SQLiteStorage) GetFolders(collectionID uint) ([]models.Folder, error) {
+	var folders []models.Folder
+	err := s.db.Where("collection_id = ?", collectionID).Order("position ASC, id ASC").Find(&folders).Error
+	if err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+func (s *SQLiteStorage) GetFolder(id uint) (*models.Folder, error) {
+	var folder models.Folder
+	if err := s.db.First(&folder, id).Error; err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// CreateFolder добавляет папку в конец родителя.
+func (s *SQLiteStorage) CreateFolder(folder *models.Folder) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		position, err := nextPosition(tx, folder.CollectionID, folder.ParentID)
+		if err != nil {
+			return err
+		}
+		folder.Position = position
+		return tx.Create(folder).Error
+	})
+}
+
+func (s *SQLiteStorage) UpdateFolder(folder *models.Folder) error {
+	return s.db.Save(folder).Error
+}
+
+// DeleteFolder удаляет папку со всеми вложенными папками и запросами.
+func (s *SQLiteStorage) DeleteFolder(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var folder models.Folder
+		if err := tx.First(&folder, id).Error; err != nil {
+			return err
+		}
+
+		ids, err := folderSubtree(tx, folder)
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("folder_id IN ?", ids).Delete(&models.SavedRequest{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", ids).Delete(&models.Folder{}).Error
+	})
+}
+
+// MoveFolder переносит папку к новому родителю на позицию position среди его папок и запросов.
+func (s *SQLiteStorage) MoveFolder(id, parentID uint, position int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var folder models.Folder
+		if err := tx.First(&folder, id).Error; err != nil {
+			return err
+		}
+
+		if parentID != 0 {
+			ids, err := folderSubtree(tx, folder)
+			if err != nil {
+				return err
+			}
+			for _, subID := range ids {
+				if subID == parentID {
+					return fmt.Errorf("cannot move folder into itself")
+				}
+			}
+			if err := checkFolder(tx, folder.CollectionID, parentID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&folder).Update("parent_id", parentID).Error; err != nil {
+			return err
+		}
+		return reorder(tx, folder.CollectionID, parentID, item{folder: true, id: id}, position)
+	})
+}
+
+func (s *SQLiteStorage) GetSavedRequests(collectionID uint) ([]models.SavedRequest, error) {
+	var requests []models.SavedRequest
+	err := s.db.Where("collection_id = ?", collectionID).Order("position ASC, id ASC").Find(&requests).Error
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (s *SQLiteStorage) GetSavedRequest(id uint) (*models.SavedRequest, error) {
+	var request models.SavedRequest
+	if err := s.db.First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// CreateSavedRequest добавляет запрос в конец папки.
+func (s *SQLiteStorage) CreateSavedRequest(request *models.SavedRequest) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkFolder(tx, request.CollectionID, request.FolderID); err != nil {
+			return err
+		}
+		position, err := nextPosition(tx, request.CollectionID, request.FolderID)
+		if err != nil {
+			return err
+		}
+		request.Position = position
+		return tx.Create(request).Error
+	})
+}
+
+// UpdateSavedRequest сохраняет содержимое запроса, место в коллекции меняет MoveSavedRequest.
+func (s *SQLiteStorage) UpdateSavedRequest(request *models.SavedRequest) error {
+	return s.db.Model(request).
+		Select("Name", "ServerID", "Service", "Method", "Address", "Payload", "Headers", "Context", "Assertions").
+		Updates(request).Error
+}
+
+func (s *SQLiteStorage) DeleteSavedRequest(id uint) error {
+	return s.db.Delete(&models.SavedRequest{}, id).Error
+}
+
+// DuplicateSavedRequest копирует запрос под именем name и ставит копию сразу после оригинала.
+func (s *SQLiteStorage) DuplicateSavedRequest(id uint, name string) (*models.SavedRequest, error) {
+	var duplicate models.SavedRequest
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&duplicate, id).Error; err != nil {
+			return err
+		}
+		items, err := siblings(tx, duplicate.CollectionID, duplicate.FolderID)
+		if err != nil {
+			return err
+		}
+		position := len(items)
+		for i, it := range items {
+			if !it.folder && it.id == id {
+				position = i + 1
+				break
+			}
+		}
+
+		duplicate.ID = 0
+		duplicate.Name = name
+		if err := tx.Create(&duplicate).Error; err != nil {
+			return err
+		}
+		duplicate.Position = position
+		return reorder(tx, duplicate.CollectionID, duplicate.FolderID, item{id: duplicate.ID}, position)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+// MoveSavedRequest переносит запрос в папку folderID на позицию position среди ее папок и запросов.
+func (s *SQLiteStorage) MoveSavedRequest(id, folderID uint, position int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var request models.SavedRequest
+		if err := tx.First(&request, id).Error; err != nil {
+			return err
+		}
+		if err := checkFolder(tx, request.CollectionID, folderID); err != nil {
+			return err
+		}
+		if err := tx.Model(&request).Update("folder_id", folderID).Error; err != nil {
+			return err
+		}
+		return reorder(tx, request.CollectionID, folderID, item{id: id}, position)
+	})
+}
+
+// checkFolder - папка существует и принадлежит коллекции. 0 - корень, подходит всегда.
+func checkFolder(tx *gorm.DB, collectionID, folderID uint) error {
+	if folderID == 0 {
+		return nil
+	}
+	var folder models.Folder
+	if err := tx.First(&folder, folderID).Error; err != nil {
+		return err
+	}
+	if folder.CollectionID != collectionID {
+		return fmt.Errorf("folder %d belongs to another collection", folderID)
+	}
+	return nil
+}
+
+// folderSubtree - ID папки и всех вложенных в нее папок.
+func folderSubtree(tx *gorm.DB, root models.Folder) ([]uint, error) {
+	var folders []models.Folder
+	if err := tx.Where("collection_id = ?", root.CollectionID).Find(&folders).Error; err != nil {
+		return nil, err
+	}
+
+	children := make(map[uint][]uint)
+	for _, folder := range folders {
+		children[folder.ParentID] = append(children[folder.ParentID], folder.ID)
+	}
+
+	ids := []uint{root.ID}
+	for i := 0; i < len(ids); i++ {
+		ids = append(ids, children[ids[i]]...)
+	}
+	return ids, nil
+}
+
+// item - элемент родителя: папка или запрос.
+type item struct {
+	folder   bool
+	id       uint
+	position int
+}
+
+func siblings(tx *gorm.DB, collectionID, parentID uint) ([]item, error) {
+	var folders []models.Folder
+	if err := tx.Where("collection_id = ? AND parent_id = ?", collectionID, parentID).Find(&folders).Error; err != nil {
+		return nil, err
+	}
+	var requests []models.SavedRequest
+	if err := tx.Where("collection_id = ? AND folder_id = ?", collectionID, parentID).Find(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]item, 0, len(folders)+len(requests))
+	for _, folder := range folders {
+		items = append(items, item{folder: true, id: folder.ID, position: folder.Position})
+	}
+	for _, request := range requests {
+		items = append(items, item{id: request.ID, position: request.Position})
+	}
+	sortItems(items)
+	return items, nil
+}
+
+// sortItems упорядочивает элементы родителя: по позиции, при равенстве папки раньше запросов, затем по ID.
+func sortItems(items []item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.position != b.position {
+			return a.position < b.position
+		}
+		if a.folder != b.folder {
+			return a.folder
+		}
+		return a.id < b.id
+	})
+}
+
+func nextPosition(tx *gorm.DB, collectionID, parentID uint) (int, error) {
+	items, err := siblings(tx, collectionID, parentID)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+	return items[len(items)-1].position + 1, nil
+}
+
+// reorder ставит элемент на позицию position среди соседей и перенумеровывает их с нуля.
+func reorder(tx *gorm.DB, collectionID, parentID uint, moved item, position int) error {
+	items, err := siblings(tx, collectionID, parentID)
+	if err != nil {
+		return err
+	}
+
+	ordered := make([]item, 0, len(items))
+	for _, it := range items {
+		if it.folder != moved.folder || it.id != moved.id {
+			ordered = append(ordered, it)
+		}
+	}
+	position = max(0, min(position, len(ordered)))
+	ordered = append(ordered[:position], append([]item{moved}, ordered[position:]...)...)
+
+	for i, it := range ordered {
+		if it.position == i && (it.folder != moved.folder || it.id != moved.id) {
+			continue
+		}
+		var model any = &models.SavedRequest{}
+		if it.folder {
+			model = &models.Folder{}
+		}
+		if err := tx.Model(model).Where("id = ?", it.id).Update("position", i).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
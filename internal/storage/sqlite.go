@@ -3,10 +3,13 @@ package storage
 import (
 	"fmt"
 	"grpc-gui/internal/models"
+	"log"
+	"os"
 	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 type SQLiteStorage struct {
@@ -16,10 +19,27 @@ type SQLiteStorage struct {
 }
 
 func NewSQLiteStorage(path string) (*SQLiteStorage, error) {
-	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	// Предупреждения gorm идут в stderr: stdout консольных команд занят JSON.
+	// Ненайденные записи - обычный ответ поиска по имени, а не ошибка
+	dbLogger := logger.New(log.New(os.Stderr, "\r\n", log.LstdFlags), logger.Config{
+		SlowThreshold:             200 * time.Millisecond,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+		Colorful:                  true,
+	})
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: dbLogger})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+
+	// Рефлексия серверов обновляется параллельно, а sqlite не любит конкурентную запись
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
 	return &SQLiteStorage{db: db}, nil
 }
 
@@ -62,9 +82,26 @@ func (s *SQLiteStorage) ToggleFavorite(serverID uint) error {
 	return s.db.Save(&server).Error
 }
 
-func (s *SQLiteStorage) UpdateReflectionCache(serverID uint, reflectionJSON string, reflectionError string) error {
+// UpdateReflectionCache сохраняет удачно полученную схему и сбрасывает ошибку.
+// Без descriptors сохраненные дескрипторы остаются прежними.
+func (s *SQLiteStorage) UpdateReflectionCache(serverID uint, reflectionJSON string, descriptors []byte) error {
+	now := time.Now()
 	updates := map[string]interface{}{
 		"reflection_cache":        reflectionJSON,
+		"reflection_cached_at":    now,
+		"reflection_schema_at":    now,
+		"reflection_access_count": 0,
+		"reflection_error":        "",
+	}
+	if descriptors != nil {
+		updates["reflection_descriptors"] = descriptors
+	}
+	return s.db.Model(&models.Server{}).Where("id = ?", serverID).Updates(updates).Error
+}
+
+// UpdateReflectionError запоминает ошибку обновления, не трогая последнюю удачную схему.
+func (s *SQLiteStorage) UpdateReflectionError(serverID uint, reflectionError string) error {
+	updates := map[string]interface{}{
 		"reflection_cached_at":    time.Now(),
 		"reflection_access_count": 0,
 		"reflection_error":        reflectionError,
@@ -77,6 +114,55 @@ func (s *SQLiteStorage) IncrementReflectionAccessCount(serverID uint) error {
 		UpdateColumn("reflection_access_count", gorm.Expr("reflection_access_count + 1")).Error
 }
 
+// RecordHealthCheck сохраняет результат проверки, обновляет текущий статус сервера
+// и оставляет только последние maxRecords проверок этого сервера.
+func (s *SQLiteStorage) RecordHealthCheck(check *models.HealthCheck, maxRecords int) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(check).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"health_status":     check.Status,
+			"health_checked_at": check.CreatedAt,
+			"health_error":      check.Error,
+		}
+		if err := tx.Model(&models.Server{}).Where("id = ?", check.ServerID).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		var keep []uint
+		err := tx.Model(&models.HealthCheck{}).
+			Where("server_id = ?", check.ServerID).
+			Order("created_at DESC, id DESC").
+			Limit(maxRecords).
+			Pluck("id", &keep).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("server_id = ? AND id NOT IN ?", check.ServerID, keep).Delete(&models.HealthCheck{}).Error
+	})
+}
+
+func (s *SQLiteStorage) GetHealthChecks(serverID uint, limit int) ([]models.HealthCheck, error) {
+	var checks []models.HealthCheck
+	query := s.db.Where("server_id = ?", serverID).Order("created_at DESC, id DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+func (s *SQLiteStorage) UpdateHealthService(serverID uint, service string) error {
+	return s.db.Model(&models.Server{}).Where("id = ?", serverID).Update("health_service", service).Error
+}
+
 func (s *SQLiteStorage) CreateHistory(history *models.History) error {
 	return s.db.Create(history).Error
 }
@@ -140,6 +226,186 @@ func (s *SQLiteStorage) CleanupOldHistory(maxRecords int) error {
 	return nil
 }
 
+func (s *SQLiteStorage) CreateEnvironment(env *models.Environment) error {
+	return s.db.Create(env).Error
+}
+
+func (s *SQLiteStorage) GetEnvironment(id uint) (*models.Environment, error) {
+	var env models.Environment
+	if err := s.db.First(&env, id).Error; err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// GetEnvironments возвращает глобальные окружения и окружения всех серверов.
+func (s *SQLiteStorage) GetEnvironments() ([]models.Environment, error) {
+	var envs []models.Environment
+	if err := s.db.Order("server_id ASC, name ASC").Find(&envs).Error; err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
+
+func (s *SQLiteStorage) UpdateEnvironment(env *models.Environment) error {
+	return s.db.Save(env).Error
+}
+
+func (s *SQLiteStorage) DeleteEnvironment(id uint) error {
+	return s.db.Delete(&models.Environment{}, id).Error
+}
+
+// SetActiveEnvironment делает окружение активным и снимает активность с остальных в его области.
+func (s *SQLiteStorage) SetActiveEnvironment(id uint, active bool) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var env models.Environment
+		if err := tx.First(&env, id).Error; err != nil {
+			return err
+		}
+
+		if active {
+			err := tx.Model(&models.Environment{}).
+				Where("server_id = ? AND id <> ?", env.ServerID, id).
+				Update("active", false).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.Environment{}).Where("id = ?", id).Update("active", active).Error
+	})
+}
+
+// GetActiveEnvironments возвращает активное глобальное окружение и активное окружение сервера, если они есть.
+func (s *SQLiteStorage) GetActiveEnvironments(serverID uint) ([]models.Environment, error) {
+	var envs []models.Environment
+	err := s.db.Where("active = ? AND server_id IN ?", true, []uint{0, serverID}).
+		Order("server_id ASC").
+		Find(&envs).Error
+	if err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
+
+// GetExtractionRules возвращает правила метода в порядке выполнения.
+func (s *SQLiteStorage) GetExtractionRules(serverID uint, service, method string) ([]models.ExtractionRule, error) {
+	var rules []models.ExtractionRule
+	err := s.db.Where("server_id = ? AND service = ? AND method = ?", serverID, service, method).
+		Order("position ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// ReplaceExtractionRules заменяет все правила метода.
+func (s *SQLiteStorage) ReplaceExtractionRules(serverID uint, service, method string, rules []models.ExtractionRule) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("server_id = ? AND service = ? AND method = ?", serverID, service, method).
+			Delete(&models.ExtractionRule{}).Error
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		return tx.Create(&rules).Error
+	})
+}
+
+// GetAssertions возвращает проверки метода в порядке выполнения.
+func (s *SQLiteStorage) GetAssertions(serverID uint, service, method string) ([]models.Assertion, error) {
+	var assertions []models.Assertion
+	err := s.db.Where("server_id = ? AND service = ? AND method = ?", serverID, service, method).
+		Order("position ASC").
+		Find(&assertions).Error
+	if err != nil {
+		return nil, err
+	}
+	return assertions, nil
+}
+
+// ReplaceAssertions заменяет все проверки метода.
+func (s *SQLiteStorage) ReplaceAssertions(serverID uint, service, method string, assertions []models.Assertion) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("server_id = ? AND service = ? AND method = ?", serverID, service, method).
+			Delete(&models.Assertion{}).Error
+		if err != nil {
+			return err
+		}
+		if len(assertions) == 0 {
+			return nil
+		}
+		return tx.Create(&assertions).Error
+	})
+}
+
+// GetMethodScript возвращает скрипты метода. Если скриптов нет, возвращается пустая запись с ID = 0.
+func (s *SQLiteStorage) GetMethodScript(serverID uint, service, method string) (*models.MethodScript, error) {
+	var script models.MethodScript
+	err := s.db.Where("server_id = ? AND service = ? AND method = ?", serverID, service, method).
+		Limit(1).
+		Find(&script).Error
+	if err != nil {
+		return nil, err
+	}
+	return &script, nil
+}
+
+// SaveMethodScript сохраняет скрипты метода, пустые скрипты удаляют запись.
+func (s *SQLiteStorage) SaveMethodScript(script *models.MethodScript) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("server_id = ? AND service = ? AND method = ?", script.ServerID, script.Service, script.Method).
+			Delete(&models.MethodScript{}).Error
+		if err != nil {
+			return err
+		}
+		if script.PreRequest == "" && script.PostResponse == "" {
+			return nil
+		}
+		return tx.Create(script).Error
+	})
+}
+
+// MigrateHistoryContextValues переносит значения контекста старого формата в RequestContext.
+// convert получает JSON старых значений и возвращает JSON нового формата. Возвращает число перенесенных записей.
+func (s *SQLiteStorage) MigrateHistoryContextValues(convert func(string) (string, error)) (int, error) {
+	var legacy []models.History
+	err := s.db.Unscoped().
+		Select("id", "context_values").
+		Where("context_values IS NOT NULL AND context_values <> ''").
+		Find(&legacy).Error
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range legacy {
+			converted, err := convert(item.ContextValues)
+			if err != nil {
+				// Битые значения только очищаем: на сервер они все равно никогда не уходили
+				converted = ""
+			}
+
+			updates := map[string]interface{}{"context_values": ""}
+			if converted != "" {
+				updates["request_context"] = converted
+			}
+			if err := tx.Unscoped().Model(&models.History{}).Where("id = ?", item.ID).UpdateColumns(updates).Error; err != nil {
+				return err
+			}
+			migrated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return migrated, nil
+}
+
 func (s *SQLiteStorage) AutoMigrate(models ...interface{}) error {
 	return s.db.AutoMigrate(models...)
 }
@@ -0,0 +1,322 @@
+package templating
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Подстановка - это значение и цепочка форматтеров через "|":
+//
+//	{{token}}                   переменная окружения
+//	{{uuid}}                    случайный UUID v4
+//	{{now}}, {{now+1h}}         время в RFC 3339 (UTC), сдвиг в формате time.ParseDuration или в днях: now-2d
+//	{{randomInt 1 100}}         случайное целое, границы включаются
+//	{{base64 file:"a.png"}}     base64 от файла, строки в кавычках или переменной
+//	{{now+1h | unix}}           форматтеры: rfc3339, rfc3339nano, unix, unixms, date, upper, lower, base64
+//
+// Переменная с тем же именем, что и функция, имеет приоритет.
+
+type token struct {
+	text   string
+	quoted bool
+	file   bool
+}
+
+func (r *Renderer) eval(expr string) (string, error) {
+	segments, err := splitPipeline(expr)
+	if err != nil {
+		return "", err
+	}
+
+	tokens, err := tokenize(segments[0])
+	if err != nil {
+		return "", err
+	}
+	if len(tokens) == 0 {
+		return "", fmt.Errorf("empty placeholder")
+	}
+
+	value, err := r.call(tokens)
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range segments[1:] {
+		value, err = format(value, strings.TrimSpace(name))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return stringify(value), nil
+}
+
+func (r *Renderer) call(tokens []token) (any, error) {
+	head := tokens[0]
+	args := tokens[1:]
+
+	if head.quoted || head.file {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("unexpected arguments after literal")
+		}
+		return r.literal(head)
+	}
+
+	if value, ok := r.vars[head.text]; ok && len(args) == 0 {
+		return value, nil
+	}
+
+	if head.text == "now" || strings.HasPrefix(head.text, "now+") || strings.HasPrefix(head.text, "now-") {
+		if err := expectArgs("now", args, 0); err != nil {
+			return nil, err
+		}
+		offset, err := parseOffset(strings.TrimPrefix(head.text, "now"))
+		if err != nil {
+			return nil, err
+		}
+		return r.now.Add(offset), nil
+	}
+
+	switch head.text {
+	case "uuid":
+		if err := expectArgs("uuid", args, 0); err != nil {
+			return nil, err
+		}
+		return uuid.NewString(), nil
+
+	case "randomInt":
+		if err := expectArgs("randomInt", args, 2); err != nil {
+			return nil, err
+		}
+		return randomInt(args[0].text, args[1].text)
+
+	case "base64":
+		if err := expectArgs("base64", args, 1); err != nil {
+			return nil, err
+		}
+		value, err := r.argument(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString([]byte(value)), nil
+	}
+
+	if len(args) == 0 && ValidName(head.text) {
+		return nil, &UnresolvedError{Names: []string{head.text}}
+	}
+	return nil, fmt.Errorf("unknown function %q", head.text)
+}
+
+func (r *Renderer) literal(t token) (string, error) {
+	if !t.file {
+		return t.text, nil
+	}
+
+	path := t.text
+	if !filepath.IsAbs(path) && r.BaseDir != "" {
+		path = filepath.Join(r.BaseDir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	return string(data), nil
+}
+
+// argument - значение аргумента функции: литерал, файл или переменная.
+func (r *Renderer) argument(t token) (string, error) {
+	if t.quoted || t.file {
+		return r.literal(t)
+	}
+	if value, ok := r.vars[t.text]; ok {
+		return value, nil
+	}
+	return "", &UnresolvedError{Names: []string{t.text}}
+}
+
+func expectArgs(name string, args []token, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("%s expects %d arguments, got %d", name, n, len(args))
+	}
+	return nil
+}
+
+// parseOffset разбирает сдвиг времени: "+1h30m", "-15m", "+2d".
+func parseOffset(offset string) (time.Duration, error) {
+	if offset == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(offset, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time offset %q", offset)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(offset)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time offset %q", offset)
+	}
+	return d, nil
+}
+
+func randomInt(minText, maxText string) (int64, error) {
+	lo, err := strconv.ParseInt(minText, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("randomInt: invalid min %q", minText)
+	}
+	hi, err := strconv.ParseInt(maxText, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("randomInt: invalid max %q", maxText)
+	}
+	if lo > hi {
+		return 0, fmt.Errorf("randomInt: min %d is greater than max %d", lo, hi)
+	}
+
+	// Границы во весь диапазон int64 не помещаются в uint64, поэтому считаем в big.Int
+	bound := new(big.Int).Sub(big.NewInt(hi), big.NewInt(lo))
+	bound.Add(bound, big.NewInt(1))
+	n, err := rand.Int(rand.Reader, bound)
+	if err != nil {
+		return 0, err
+	}
+	return n.Add(n, big.NewInt(lo)).Int64(), nil
+}
+
+func format(value any, name string) (any, error) {
+	if t, ok := value.(time.Time); ok {
+		switch name {
+		case "rfc3339":
+			return t.UTC().Format(time.RFC3339), nil
+		case "rfc3339nano":
+			return t.UTC().Format(time.RFC3339Nano), nil
+		case "unix":
+			return t.Unix(), nil
+		case "unixms":
+			return t.UnixMilli(), nil
+		case "date":
+			return t.UTC().Format(time.DateOnly), nil
+		}
+	}
+
+	switch name {
+	case "upper":
+		return strings.ToUpper(stringify(value)), nil
+	case "lower":
+		return strings.ToLower(stringify(value)), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString([]byte(stringify(value))), nil
+	case "rfc3339", "rfc3339nano", "unix", "unixms", "date":
+		return nil, fmt.Errorf("%s expects a time value", name)
+	}
+	return nil, fmt.Errorf("unknown formatter %q", name)
+}
+
+func stringify(value any) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// splitPipeline делит выражение по "|" вне кавычек.
+func splitPipeline(expr string) ([]string, error) {
+	var segments []string
+	var current strings.Builder
+	inQuotes := false
+
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c == '\\' && inQuotes && i+1 < len(expr):
+			current.WriteByte(c)
+			i++
+			current.WriteByte(expr[i])
+			continue
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == '|' && !inQuotes:
+			segments = append(segments, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated string")
+	}
+
+	segments = append(segments, current.String())
+	for _, segment := range segments[1:] {
+		if strings.TrimSpace(segment) == "" {
+			return nil, fmt.Errorf("empty formatter")
+		}
+	}
+	return segments, nil
+}
+
+// tokenize делит вызов на имя и аргументы: слова, строки в кавычках и file:"путь".
+func tokenize(segment string) ([]token, error) {
+	var tokens []token
+	rest := strings.TrimSpace(segment)
+
+	for rest != "" {
+		t := token{}
+		if strings.HasPrefix(rest, `file:"`) {
+			t.file = true
+			rest = rest[len("file:"):]
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			end := closingQuote(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			text, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", rest[:end+1])
+			}
+			t.text, t.quoted = text, !t.file
+			rest = rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			t.text = rest[:end]
+			rest = rest[end:]
+		}
+
+		tokens = append(tokens, t)
+		rest = strings.TrimLeft(rest, " \t")
+	}
+
+	return tokens, nil
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
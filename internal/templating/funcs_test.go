@@ -0,0 +1,128 @@
+package templating
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixedRenderer(vars map[string]string) *Renderer {
+	r := NewRenderer(vars)
+	r.now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return r
+}
+
+func TestRenderer_Functions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "avatar.png"), []byte{0x89, 'P', 'N', 'G'}, 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	r := fixedRenderer(map[string]string{"token": "abc"})
+	r.BaseDir = dir
+
+	cases := map[string]string{
+		`{{now+1h | rfc3339}}`:           "2025-03-01T13:00:00Z",
+		`{{ now-2d|date }}`:              "2025-02-27",
+		`{{now+90m | unix}}`:             strconv.FormatInt(r.now.Add(90*time.Minute).Unix(), 10),
+		`{{now | unixms}}`:               strconv.FormatInt(r.now.UnixMilli(), 10),
+		`{{base64 file:"./avatar.png"}}`: base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'}),
+		`{{base64 "a|b"}}`:               base64.StdEncoding.EncodeToString([]byte("a|b")),
+		`{{base64 token}}`:               "YWJj",
+		`{{token | upper}}`:              "ABC",
+		`{{"Hello" | lower | base64}}`:   "aGVsbG8=",
+	}
+	for placeholder, want := range cases {
+		if got := r.Render(placeholder); got != want {
+			t.Errorf("%s: expected %q, got %q", placeholder, want, got)
+		}
+	}
+
+	if err := r.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Переменная перекрывает функцию с тем же именем
+	if got := fixedRenderer(map[string]string{"uuid": "fixed"}).Render("{{uuid}}"); got != "fixed" {
+		t.Errorf("expected variable to win, got %q", got)
+	}
+
+	r = fixedRenderer(nil)
+	if got := r.Render("{{now}}"); got != "2025-03-01T12:00:00Z" {
+		t.Errorf("expected RFC 3339 time by default, got %q", got)
+	}
+
+	uuidRe := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first, second := r.Render("{{uuid}}"), r.Render("{{uuid}}")
+	if !uuidRe.MatchString(first) || first == second {
+		t.Errorf("expected distinct v4 UUIDs, got %q and %q", first, second)
+	}
+
+	for i := 0; i < 50; i++ {
+		n, err := strconv.Atoi(r.Render("{{randomInt 1 3}}"))
+		if err != nil || n < 1 || n > 3 {
+			t.Fatalf("expected value in [1, 3], got %d (%v)", n, err)
+		}
+	}
+	if r.Render("{{randomInt -5 -5}}") != "-5" {
+		t.Error("expected single-value range")
+	}
+	if _, err := strconv.ParseInt(r.Render("{{randomInt -9223372036854775808 9223372036854775807}}"), 10, 64); err != nil {
+		t.Errorf("expected value in full int64 range: %v", err)
+	}
+}
+
+func TestRenderer_FunctionErrors(t *testing.T) {
+	payload := "{\n  \"id\": \"{{uuid}}\",\n  \"n\": {{randomInt 1}},\n  \"at\": \"{{now | shout}}\"\n}"
+
+	r := fixedRenderer(nil)
+	rendered := r.Render(payload)
+	if !strings.Contains(rendered, "{{randomInt 1}}") {
+		t.Errorf("expected failed placeholder to stay in text, got %s", rendered)
+	}
+
+	err := r.Err()
+	var placeholderErr *PlaceholderError
+	if !errors.As(err, &placeholderErr) {
+		t.Fatalf("expected placeholder error, got %v", err)
+	}
+	if placeholderErr.Placeholder != "{{randomInt 1}}" || placeholderErr.Line != 3 || placeholderErr.Column != 8 {
+		t.Errorf("unexpected error position: %+v", placeholderErr)
+	}
+	if !strings.Contains(err.Error(), "randomInt expects 2 arguments") ||
+		!strings.Contains(err.Error(), `unknown formatter "shout"`) {
+		t.Errorf("expected both errors, got %v", err)
+	}
+
+	for _, placeholder := range []string{
+		`{{randomInt 5 1}}`,
+		`{{randomInt a 1}}`,
+		`{{now+soon}}`,
+		`{{uuid | unix}}`,
+		`{{base64 file:"missing.bin"}}`,
+		`{{base64 "open}}`,
+		`{{frobnicate 1}}`,
+		`{{token |}}`,
+	} {
+		r := fixedRenderer(map[string]string{"token": "x"})
+		r.BaseDir = t.TempDir()
+		r.Render(placeholder)
+		if !errors.As(r.Err(), &placeholderErr) {
+			t.Errorf("%s: expected placeholder error, got %v", placeholder, r.Err())
+		}
+	}
+
+	// Неизвестная переменная в аргументе - это неразрешенная переменная, а не ошибка функции
+	r = fixedRenderer(nil)
+	r.Render("{{base64 secret}}")
+	var unresolved *UnresolvedError
+	if !errors.As(r.Err(), &unresolved) || unresolved.Names[0] != "secret" {
+		t.Errorf("expected unresolved variable, got %v", r.Err())
+	}
+}
@@ -0,0 +1,149 @@
+package templating
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	placeholderRe = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+	nameRe        = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+)
+
+// ValidName - годится ли имя для переменной: буква или подчеркивание, затем буквы, цифры, "_", "." и "-".
+func ValidName(name string) bool {
+	return nameRe.MatchString(name)
+}
+
+// UnresolvedError - в тексте остались переменные, которых нет в окружении.
+type UnresolvedError struct {
+	Names []string
+}
+
+func (e *UnresolvedError) Error() string {
+	return fmt.Sprintf("unresolved variables: %s", strings.Join(e.Names, ", "))
+}
+
+// PlaceholderError - подстановку не удалось вычислить. Line и Column считаются с 1.
+type PlaceholderError struct {
+	Placeholder string
+	Line        int
+	Column      int
+	Err         error
+}
+
+func (e *PlaceholderError) Error() string {
+	return fmt.Sprintf("%s at line %d, column %d: %v", e.Placeholder, e.Line, e.Column, e.Err)
+}
+
+func (e *PlaceholderError) Unwrap() error {
+	return e.Err
+}
+
+// HasPlaceholders - есть ли в тексте хотя бы одна подстановка {{...}}.
+func HasPlaceholders(text string) bool {
+	return placeholderRe.MatchString(text)
+}
+
+// Render подставляет переменные и функции в один текст.
+func Render(text string, vars map[string]string) (string, error) {
+	r := NewRenderer(vars)
+	rendered := r.Render(text)
+	if err := r.Err(); err != nil {
+		return "", err
+	}
+	return rendered, nil
+}
+
+// Renderer накапливает ошибки по нескольким полям запроса,
+// чтобы сообщить обо всех сразу, а не по одной за попытку.
+type Renderer struct {
+	// BaseDir - каталог для относительных путей в file:"...", пустой - текущий каталог
+	BaseDir string
+
+	vars    map[string]string
+	now     time.Time
+	missing map[string]bool
+	errs    []error
+	used    bool
+}
+
+// NewRenderer фиксирует текущее время: все {{now}} одного запроса получают одно значение.
+func NewRenderer(vars map[string]string) *Renderer {
+	return &Renderer{vars: vars, now: time.Now(), missing: make(map[string]bool)}
+}
+
+// Render подставляет значения. Подстановки с ошибкой остаются в тексте как есть, ошибки отдает Err.
+func (r *Renderer) Render(text string) string {
+	matches := placeholderRe.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	r.used = true
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		expr := text[m[2]:m[3]]
+		b.WriteString(text[last:start])
+		last = end
+
+		value, err := r.eval(expr)
+		var unresolved *UnresolvedError
+		switch {
+		case errors.As(err, &unresolved):
+			for _, name := range unresolved.Names {
+				r.missing[name] = true
+			}
+			b.WriteString(text[start:end])
+		case err != nil:
+			line, column := position(text, start)
+			r.errs = append(r.errs, &PlaceholderError{Placeholder: text[start:end], Line: line, Column: column, Err: err})
+			b.WriteString(text[start:end])
+		default:
+			b.WriteString(value)
+		}
+	}
+	b.WriteString(text[last:])
+
+	return b.String()
+}
+
+func position(text string, offset int) (int, int) {
+	before := text[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := len([]rune(before[strings.LastIndex(before, "\n")+1:])) + 1
+	return line, column
+}
+
+// Used - встретилась ли хотя бы одна подстановка.
+func (r *Renderer) Used() bool {
+	return r.used
+}
+
+func (r *Renderer) Err() error {
+	errs := append([]error{}, r.errs...)
+
+	if len(r.missing) > 0 {
+		names := make([]string, 0, len(r.missing))
+		for name := range r.missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		errs = append(errs, &UnresolvedError{Names: names})
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,63 @@
+package templating
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	vars := map[string]string{"token": "abc", "tenant.id": "42"}
+
+	got, err := Render(`{"tenant": "{{tenant.id}}", "auth": "{{ token }}"}`, vars)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if got != `{"tenant": "42", "auth": "abc"}` {
+		t.Errorf("unexpected result: %s", got)
+	}
+
+	_, err = Render("{{host}}:{{port}} {{token}} {{host}}", vars)
+	var unresolved *UnresolvedError
+	if !errors.As(err, &unresolved) || !reflect.DeepEqual(unresolved.Names, []string{"host", "port"}) {
+		t.Errorf("expected unresolved host and port, got %v", err)
+	}
+
+	if got, err := Render("no placeholders", nil); err != nil || got != "no placeholders" {
+		t.Errorf("expected text unchanged, got %q %v", got, err)
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	r := NewRenderer(map[string]string{"a": "1"})
+
+	if r.Render("plain") != "plain" || r.Used() {
+		t.Error("expected plain text to be untouched")
+	}
+	if r.Render("{{a}}") != "1" || !r.Used() {
+		t.Error("expected substitution")
+	}
+	if r.Err() != nil {
+		t.Errorf("unexpected error: %v", r.Err())
+	}
+
+	r.Render("{{b}}")
+	r.Render("{{c}} {{b}}")
+	var unresolved *UnresolvedError
+	if !errors.As(r.Err(), &unresolved) || !reflect.DeepEqual(unresolved.Names, []string{"b", "c"}) {
+		t.Errorf("expected all missing names across fields, got %v", r.Err())
+	}
+}
+
+func TestValidName(t *testing.T) {
+	for _, name := range []string{"token", "tenant_id", "api.host", "x-1", "_x"} {
+		if !ValidName(name) {
+			t.Errorf("expected %q to be valid", name)
+		}
+	}
+	for _, name := range []string{"", "1abc", "has space", "{{x}}"} {
+		if ValidName(name) {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
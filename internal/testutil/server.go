@@ -7,8 +7,18 @@ import (
 	"testing"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"grpc-gui/testserver/proto"
 )
@@ -24,6 +34,9 @@ func StartTestServer(t *testing.T) (string, func()) {
 	s := grpc.NewServer()
 	proto.RegisterTestServiceServer(s, &TestServer{})
 	proto.RegisterAnotherServiceServer(s, &AnotherServer{})
+	proto.RegisterEventServiceServer(s, &EventServer{})
+	proto.RegisterLegacyServiceServer(s, &LegacyServer{})
+	proto.RegisterEditionsServiceServer(s, &EditionsServer{})
 	reflection.Register(s)
 
 	go func() {
@@ -40,6 +53,32 @@ func StartTestServer(t *testing.T) (string, func()) {
 	}
 }
 
+// StartHealthTestServer поднимает тестовый сервер с grpc.health.v1.
+// Через возвращенный health.Server тест меняет статус сервисов.
+func StartHealthTestServer(t *testing.T) (string, *health.Server, func()) {
+	lis, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := grpc.NewServer()
+	proto.RegisterTestServiceServer(s, &TestServer{})
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
+
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("server error: %v", err)
+		}
+	}()
+
+	return lis.Addr().String(), healthServer, func() {
+		s.Stop()
+		lis.Close()
+	}
+}
+
 type TestServer struct {
 	proto.UnimplementedTestServiceServer
 }
@@ -48,6 +87,18 @@ type AnotherServer struct {
 	proto.UnimplementedAnotherServiceServer
 }
 
+type LegacyServer struct {
+	proto.UnimplementedLegacyServiceServer
+}
+
+type EditionsServer struct {
+	proto.UnimplementedEditionsServiceServer
+}
+
+type EventServer struct {
+	proto.UnimplementedEventServiceServer
+}
+
 func (s *TestServer) SimpleCall(ctx context.Context, req *proto.SimpleRequest) (*proto.SimpleResponse, error) {
 	return &proto.SimpleResponse{
 		Result:    fmt.Sprintf("Echo: %s", req.Message),
@@ -76,6 +127,20 @@ func (s *TestServer) EmptyCall(ctx context.Context, req *proto.EmptyRequest) (*p
 	return &proto.EmptyResponse{}, nil
 }
 
+// ScheduleTask всегда отвечает ошибкой с деталями статуса и трейлером.
+func (s *TestServer) ScheduleTask(ctx context.Context, req *proto.ScheduleRequest) (*proto.ScheduleResponse, error) {
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "1", "x-request-id", "2"))
+	grpc.SetTrailer(ctx, metadata.Pairs("x-retry-reason", "quota"))
+
+	st, err := status.New(codes.InvalidArgument, "task is invalid").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "name", Description: "required"}}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return nil, st.Err()
+}
+
 func (s *TestServer) ServerStream(req *proto.SimpleRequest, stream proto.TestService_ServerStreamServer) error {
 	for i := 0; i < 5; i++ {
 		if err := stream.Send(&proto.StreamResponse{
@@ -144,9 +209,71 @@ func (s *AnotherServer) GetUsers(ctx context.Context, req *proto.EmptyRequest) (
 	}, nil
 }
 
+func (s *AnotherServer) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.User, error) {
+	user := &proto.User{Id: 1, Name: "User1", Email: "user1@example.com", Status: proto.Status_ACTIVE}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return req.User, nil
+	}
+
+	src := req.GetUser().ProtoReflect()
+	dst := user.ProtoReflect()
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path))
+		if fd == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown field %q in update_mask", path)
+		}
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+	}
+
+	return user, nil
+}
+
 func (s *AnotherServer) UpdateStatus(ctx context.Context, req *proto.ComplexRequest) (*proto.SimpleResponse, error) {
 	return &proto.SimpleResponse{
 		Result:    "Status updated",
 		Processed: 1,
 	}, nil
 }
+
+func (s *LegacyServer) Describe(ctx context.Context, req *proto.LegacyRequest) (*proto.LegacyResponse, error) {
+	return &proto.LegacyResponse{
+		Summary:     protobuf.String(fmt.Sprintf("%s (%s, %s)", req.GetName(), req.GetLocale(), req.GetLevel())),
+		PageSize:    protobuf.Int32(req.GetPageSize()),
+		TraceTag:    protobuf.String(protobuf.GetExtension(req, proto.E_TraceTag).(string)),
+		RetryBudget: protobuf.Int32(protobuf.GetExtension(req, proto.E_RetryBudget).(int32)),
+	}, nil
+}
+
+func (s *EditionsServer) Echo(ctx context.Context, req *proto.EditionsRequest) (*proto.EditionsResponse, error) {
+	return &proto.EditionsResponse{
+		Id:          protobuf.String(req.GetId()),
+		Count:       protobuf.Int32(req.GetCount()),
+		Label:       protobuf.String(req.GetLabel()),
+		NestedValue: protobuf.String(req.GetNested().GetValue()),
+		Limit:       protobuf.Int32(req.GetLimit()),
+		HasId:       protobuf.Bool(req.Id != nil),
+	}, nil
+}
+
+// Publish возвращает событие обратно и добавляет в details запись аудита,
+// тип которой не встречается ни в одном методе.
+func (s *EventServer) Publish(ctx context.Context, req *proto.Event) (*proto.Event, error) {
+	audit, err := anypb.New(&proto.AuditRecord{
+		Actor:  "testserver",
+		Action: "publish",
+		At:     timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Event{
+		Id:      req.Id,
+		Payload: req.Payload,
+		Details: append(req.Details, audit),
+	}, nil
+}
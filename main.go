@@ -4,8 +4,10 @@ import (
 	"embed"
 	_ "embed"
 	"grpc-gui/internal/consts"
+	"grpc-gui/internal/models"
 	"grpc-gui/internal/utils"
 	"log"
+	"os"
 	"path/filepath"
 
 	"github.com/wailsapp/wails/v3/pkg/application"
@@ -19,6 +21,11 @@ func init() {
 	// This is not required, but the binding generator will pick up registered events
 	// and provide a strongly typed JS/TS API for them.
 	application.RegisterEvent[string]("time")
+
+	application.RegisterEvent[ServerWithReflection](consts.EventServerReflection)
+	application.RegisterEvent[SchemaChange](consts.EventSchemaChanged)
+	application.RegisterEvent[models.HealthCheck](consts.EventServerHealth)
+	application.RegisterEvent[RunProgress](consts.EventCollectionRun)
 }
 
 func main() {
@@ -31,6 +38,11 @@ func main() {
 	dbPath := filepath.Join(appConfigDir, consts.AppDbName)
 	appService := NewApp(dbPath)
 
+	// Консольные команды работают с той же базой и не открывают окно
+	if isCLI(os.Args[1:]) {
+		os.Exit(runCLI(appService, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	app := application.New(application.Options{
 		Name:        "grpc-gui",
 		Description: "gRPC GUI",
@@ -45,6 +57,10 @@ func main() {
 		},
 	})
 
+	appService.emit = func(name string, data any) {
+		app.Event.Emit(name, data)
+	}
+
 	app.Window.NewWithOptions(application.WebviewWindowOptions{
 		Title: "grpc-gui",
 		Mac: application.MacWindow{
@@ -59,6 +75,12 @@ func main() {
 		Height:           900,
 	})
 
+	stopScheduler := appService.startReflectionScheduler(consts.ReflectionBackgroundInterval)
+	defer stopScheduler()
+
+	stopHealthScheduler := appService.startHealthScheduler(consts.HealthCheckInterval)
+	defer stopHealthScheduler()
+
 	err = app.Run()
 	if err != nil {
 		log.Fatal(err)
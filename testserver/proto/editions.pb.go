@@ -0,0 +1,318 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.5
+// source: proto/editions.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EditionsRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            *string                 `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Count         int32                   `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Label         *string                 `protobuf:"bytes,3,req,name=label" json:"label,omitempty"`
+	Nested        *EditionsRequest_Nested `protobuf:"group,4,opt,name=Nested,json=nested" json:"nested,omitempty"`
+	Limit         *int32                  `protobuf:"varint,5,opt,name=limit,def=10" json:"limit,omitempty"`
+	Scope         *EditionsRequest_Nested `protobuf:"bytes,6,req,name=scope" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for EditionsRequest fields.
+const (
+	Default_EditionsRequest_Limit = int32(10)
+)
+
+func (x *EditionsRequest) Reset() {
+	*x = EditionsRequest{}
+	mi := &file_proto_editions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditionsRequest) ProtoMessage() {}
+
+func (x *EditionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_editions_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditionsRequest.ProtoReflect.Descriptor instead.
+func (*EditionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_editions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EditionsRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *EditionsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *EditionsRequest) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+func (x *EditionsRequest) GetNested() *EditionsRequest_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *EditionsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return Default_EditionsRequest_Limit
+}
+
+func (x *EditionsRequest) GetScope() *EditionsRequest_Nested {
+	if x != nil {
+		return x.Scope
+	}
+	return nil
+}
+
+type EditionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Count         *int32                 `protobuf:"varint,2,opt,name=count" json:"count,omitempty"`
+	Label         *string                `protobuf:"bytes,3,opt,name=label" json:"label,omitempty"`
+	NestedValue   *string                `protobuf:"bytes,4,opt,name=nested_value,json=nestedValue" json:"nested_value,omitempty"`
+	Limit         *int32                 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+	HasId         *bool                  `protobuf:"varint,6,opt,name=has_id,json=hasId" json:"has_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditionsResponse) Reset() {
+	*x = EditionsResponse{}
+	mi := &file_proto_editions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditionsResponse) ProtoMessage() {}
+
+func (x *EditionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_editions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditionsResponse.ProtoReflect.Descriptor instead.
+func (*EditionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_editions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EditionsResponse) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *EditionsResponse) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *EditionsResponse) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return ""
+}
+
+func (x *EditionsResponse) GetNestedValue() string {
+	if x != nil && x.NestedValue != nil {
+		return *x.NestedValue
+	}
+	return ""
+}
+
+func (x *EditionsResponse) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *EditionsResponse) GetHasId() bool {
+	if x != nil && x.HasId != nil {
+		return *x.HasId
+	}
+	return false
+}
+
+type EditionsRequest_Nested struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         *string                `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditionsRequest_Nested) Reset() {
+	*x = EditionsRequest_Nested{}
+	mi := &file_proto_editions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditionsRequest_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditionsRequest_Nested) ProtoMessage() {}
+
+func (x *EditionsRequest_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_editions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditionsRequest_Nested.ProtoReflect.Descriptor instead.
+func (*EditionsRequest_Nested) Descriptor() ([]byte, []int) {
+	return file_proto_editions_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *EditionsRequest_Nested) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+var File_proto_editions_proto protoreflect.FileDescriptor
+
+const file_proto_editions_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/editions.proto\x12\n" +
+	"testserver\"\x99\x02\n" +
+	"\x0fEditionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\x05count\x18\x02 \x01(\x05B\x05\xaa\x01\x02\b\x02R\x05count\x12\x1b\n" +
+	"\x05label\x18\x03 \x01(\tB\x05\xaa\x01\x02\b\x03R\x05label\x12A\n" +
+	"\x06nested\x18\x04 \x01(\v2\".testserver.EditionsRequest.NestedB\x05\xaa\x01\x02(\x02R\x06nested\x12\x18\n" +
+	"\x05limit\x18\x05 \x01(\x05:\x0210R\x05limit\x12?\n" +
+	"\x05scope\x18\x06 \x01(\v2\".testserver.EditionsRequest.NestedB\x05\xaa\x01\x02\b\x03R\x05scope\x1a\x1e\n" +
+	"\x06Nested\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\"\x9e\x01\n" +
+	"\x10EditionsResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12!\n" +
+	"\fnested_value\x18\x04 \x01(\tR\vnestedValue\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x15\n" +
+	"\x06has_id\x18\x06 \x01(\bR\x05hasId2T\n" +
+	"\x0fEditionsService\x12A\n" +
+	"\x04Echo\x12\x1b.testserver.EditionsRequest\x1a\x1c.testserver.EditionsResponseB\x1bZ\x19grpc-gui/testserver/protob\beditionsp\xe8\a"
+
+var (
+	file_proto_editions_proto_rawDescOnce sync.Once
+	file_proto_editions_proto_rawDescData []byte
+)
+
+func file_proto_editions_proto_rawDescGZIP() []byte {
+	file_proto_editions_proto_rawDescOnce.Do(func() {
+		file_proto_editions_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_editions_proto_rawDesc), len(file_proto_editions_proto_rawDesc)))
+	})
+	return file_proto_editions_proto_rawDescData
+}
+
+var file_proto_editions_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_editions_proto_goTypes = []any{
+	(*EditionsRequest)(nil),        // 0: testserver.EditionsRequest
+	(*EditionsResponse)(nil),       // 1: testserver.EditionsResponse
+	(*EditionsRequest_Nested)(nil), // 2: testserver.EditionsRequest.Nested
+}
+var file_proto_editions_proto_depIdxs = []int32{
+	2, // 0: testserver.EditionsRequest.nested:type_name -> testserver.EditionsRequest.Nested
+	2, // 1: testserver.EditionsRequest.scope:type_name -> testserver.EditionsRequest.Nested
+	0, // 2: testserver.EditionsService.Echo:input_type -> testserver.EditionsRequest
+	1, // 3: testserver.EditionsService.Echo:output_type -> testserver.EditionsResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_editions_proto_init() }
+func file_proto_editions_proto_init() {
+	if File_proto_editions_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_editions_proto_rawDesc), len(file_proto_editions_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_editions_proto_goTypes,
+		DependencyIndexes: file_proto_editions_proto_depIdxs,
+		MessageInfos:      file_proto_editions_proto_msgTypes,
+	}.Build()
+	File_proto_editions_proto = out.File
+	file_proto_editions_proto_goTypes = nil
+	file_proto_editions_proto_depIdxs = nil
+}
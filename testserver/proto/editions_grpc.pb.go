@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v6.33.5
+// source: proto/editions.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EditionsService_Echo_FullMethodName = "/testserver.EditionsService/Echo"
+)
+
+// EditionsServiceClient is the client API for EditionsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EditionsServiceClient interface {
+	Echo(ctx context.Context, in *EditionsRequest, opts ...grpc.CallOption) (*EditionsResponse, error)
+}
+
+type editionsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEditionsServiceClient(cc grpc.ClientConnInterface) EditionsServiceClient {
+	return &editionsServiceClient{cc}
+}
+
+func (c *editionsServiceClient) Echo(ctx context.Context, in *EditionsRequest, opts ...grpc.CallOption) (*EditionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditionsResponse)
+	err := c.cc.Invoke(ctx, EditionsService_Echo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EditionsServiceServer is the server API for EditionsService service.
+// All implementations must embed UnimplementedEditionsServiceServer
+// for forward compatibility.
+type EditionsServiceServer interface {
+	Echo(context.Context, *EditionsRequest) (*EditionsResponse, error)
+	mustEmbedUnimplementedEditionsServiceServer()
+}
+
+// UnimplementedEditionsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEditionsServiceServer struct{}
+
+func (UnimplementedEditionsServiceServer) Echo(context.Context, *EditionsRequest) (*EditionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Echo not implemented")
+}
+func (UnimplementedEditionsServiceServer) mustEmbedUnimplementedEditionsServiceServer() {}
+func (UnimplementedEditionsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeEditionsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EditionsServiceServer will
+// result in compilation errors.
+type UnsafeEditionsServiceServer interface {
+	mustEmbedUnimplementedEditionsServiceServer()
+}
+
+func RegisterEditionsServiceServer(s grpc.ServiceRegistrar, srv EditionsServiceServer) {
+	// If the following call panics, it indicates UnimplementedEditionsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EditionsService_ServiceDesc, srv)
+}
+
+func _EditionsService_Echo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EditionsServiceServer).Echo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EditionsService_Echo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EditionsServiceServer).Echo(ctx, req.(*EditionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EditionsService_ServiceDesc is the grpc.ServiceDesc for EditionsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EditionsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "testserver.EditionsService",
+	HandlerType: (*EditionsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Echo",
+			Handler:    _EditionsService_Echo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/editions.proto",
+}
@@ -0,0 +1,477 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.5
+// source: proto/legacy.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LegacyLevel int32
+
+const (
+	LegacyLevel_LEVEL_UNSPECIFIED LegacyLevel = 0
+	LegacyLevel_LEVEL_BASIC       LegacyLevel = 1
+	LegacyLevel_LEVEL_FULL        LegacyLevel = 2
+)
+
+// Enum value maps for LegacyLevel.
+var (
+	LegacyLevel_name = map[int32]string{
+		0: "LEVEL_UNSPECIFIED",
+		1: "LEVEL_BASIC",
+		2: "LEVEL_FULL",
+	}
+	LegacyLevel_value = map[string]int32{
+		"LEVEL_UNSPECIFIED": 0,
+		"LEVEL_BASIC":       1,
+		"LEVEL_FULL":        2,
+	}
+)
+
+func (x LegacyLevel) Enum() *LegacyLevel {
+	p := new(LegacyLevel)
+	*p = x
+	return p
+}
+
+func (x LegacyLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LegacyLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_legacy_proto_enumTypes[0].Descriptor()
+}
+
+func (LegacyLevel) Type() protoreflect.EnumType {
+	return &file_proto_legacy_proto_enumTypes[0]
+}
+
+func (x LegacyLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *LegacyLevel) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = LegacyLevel(num)
+	return nil
+}
+
+// Deprecated: Use LegacyLevel.Descriptor instead.
+func (LegacyLevel) EnumDescriptor() ([]byte, []int) {
+	return file_proto_legacy_proto_rawDescGZIP(), []int{0}
+}
+
+type LegacyFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         *string                `protobuf:"bytes,1,opt,name=query" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegacyFilter) Reset() {
+	*x = LegacyFilter{}
+	mi := &file_proto_legacy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegacyFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyFilter) ProtoMessage() {}
+
+func (x *LegacyFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_legacy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyFilter.ProtoReflect.Descriptor instead.
+func (*LegacyFilter) Descriptor() ([]byte, []int) {
+	return file_proto_legacy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LegacyFilter) GetQuery() string {
+	if x != nil && x.Query != nil {
+		return *x.Query
+	}
+	return ""
+}
+
+type LegacyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            *string                `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	PageSize        *int32                 `protobuf:"varint,2,opt,name=page_size,json=pageSize,def=25" json:"page_size,omitempty"`
+	Locale          *string                `protobuf:"bytes,3,opt,name=locale,def=en-US" json:"locale,omitempty"`
+	Verbose         *bool                  `protobuf:"varint,4,opt,name=verbose,def=1" json:"verbose,omitempty"`
+	Level           *LegacyLevel           `protobuf:"varint,5,opt,name=level,enum=testserver.LegacyLevel,def=2" json:"level,omitempty"`
+	Ratio           *float64               `protobuf:"fixed64,6,opt,name=ratio,def=0.5" json:"ratio,omitempty"`
+	Options         *LegacyRequest_Options `protobuf:"group,7,opt,name=Options,json=options" json:"options,omitempty"`
+	Filter          *LegacyFilter          `protobuf:"bytes,8,req,name=filter" json:"filter,omitempty"`
+	extensionFields protoimpl.ExtensionFields
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+// Default values for LegacyRequest fields.
+const (
+	Default_LegacyRequest_PageSize = int32(25)
+	Default_LegacyRequest_Locale   = string("en-US")
+	Default_LegacyRequest_Verbose  = bool(true)
+	Default_LegacyRequest_Level    = LegacyLevel_LEVEL_FULL
+	Default_LegacyRequest_Ratio    = float64(0.5)
+)
+
+func (x *LegacyRequest) Reset() {
+	*x = LegacyRequest{}
+	mi := &file_proto_legacy_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegacyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyRequest) ProtoMessage() {}
+
+func (x *LegacyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_legacy_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyRequest.ProtoReflect.Descriptor instead.
+func (*LegacyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_legacy_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LegacyRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *LegacyRequest) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return Default_LegacyRequest_PageSize
+}
+
+func (x *LegacyRequest) GetLocale() string {
+	if x != nil && x.Locale != nil {
+		return *x.Locale
+	}
+	return Default_LegacyRequest_Locale
+}
+
+func (x *LegacyRequest) GetVerbose() bool {
+	if x != nil && x.Verbose != nil {
+		return *x.Verbose
+	}
+	return Default_LegacyRequest_Verbose
+}
+
+func (x *LegacyRequest) GetLevel() LegacyLevel {
+	if x != nil && x.Level != nil {
+		return *x.Level
+	}
+	return Default_LegacyRequest_Level
+}
+
+func (x *LegacyRequest) GetRatio() float64 {
+	if x != nil && x.Ratio != nil {
+		return *x.Ratio
+	}
+	return Default_LegacyRequest_Ratio
+}
+
+func (x *LegacyRequest) GetOptions() *LegacyRequest_Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *LegacyRequest) GetFilter() *LegacyFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type LegacyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Summary       *string                `protobuf:"bytes,1,opt,name=summary" json:"summary,omitempty"`
+	PageSize      *int32                 `protobuf:"varint,2,opt,name=page_size,json=pageSize" json:"page_size,omitempty"`
+	TraceTag      *string                `protobuf:"bytes,3,opt,name=trace_tag,json=traceTag" json:"trace_tag,omitempty"`
+	RetryBudget   *int32                 `protobuf:"varint,4,opt,name=retry_budget,json=retryBudget" json:"retry_budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LegacyResponse) Reset() {
+	*x = LegacyResponse{}
+	mi := &file_proto_legacy_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegacyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyResponse) ProtoMessage() {}
+
+func (x *LegacyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_legacy_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyResponse.ProtoReflect.Descriptor instead.
+func (*LegacyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_legacy_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LegacyResponse) GetSummary() string {
+	if x != nil && x.Summary != nil {
+		return *x.Summary
+	}
+	return ""
+}
+
+func (x *LegacyResponse) GetPageSize() int32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *LegacyResponse) GetTraceTag() string {
+	if x != nil && x.TraceTag != nil {
+		return *x.TraceTag
+	}
+	return ""
+}
+
+func (x *LegacyResponse) GetRetryBudget() int32 {
+	if x != nil && x.RetryBudget != nil {
+		return *x.RetryBudget
+	}
+	return 0
+}
+
+type LegacyRequest_Options struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        *bool                  `protobuf:"varint,1,opt,name=dry_run,json=dryRun,def=0" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+// Default values for LegacyRequest_Options fields.
+const (
+	Default_LegacyRequest_Options_DryRun = bool(false)
+)
+
+func (x *LegacyRequest_Options) Reset() {
+	*x = LegacyRequest_Options{}
+	mi := &file_proto_legacy_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LegacyRequest_Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyRequest_Options) ProtoMessage() {}
+
+func (x *LegacyRequest_Options) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_legacy_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyRequest_Options.ProtoReflect.Descriptor instead.
+func (*LegacyRequest_Options) Descriptor() ([]byte, []int) {
+	return file_proto_legacy_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *LegacyRequest_Options) GetDryRun() bool {
+	if x != nil && x.DryRun != nil {
+		return *x.DryRun
+	}
+	return Default_LegacyRequest_Options_DryRun
+}
+
+var file_proto_legacy_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*LegacyRequest)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         100,
+		Name:          "testserver.trace_tag",
+		Tag:           "bytes,100,opt,name=trace_tag",
+		Filename:      "proto/legacy.proto",
+	},
+	{
+		ExtendedType:  (*LegacyRequest)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         101,
+		Name:          "testserver.retry_budget",
+		Tag:           "varint,101,opt,name=retry_budget,def=3",
+		Filename:      "proto/legacy.proto",
+	},
+}
+
+// Extension fields to LegacyRequest.
+var (
+	// optional string trace_tag = 100;
+	E_TraceTag = &file_proto_legacy_proto_extTypes[0]
+	// optional int32 retry_budget = 101;
+	E_RetryBudget = &file_proto_legacy_proto_extTypes[1]
+)
+
+var File_proto_legacy_proto protoreflect.FileDescriptor
+
+const file_proto_legacy_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/legacy.proto\x12\n" +
+	"testserver\"$\n" +
+	"\fLegacyFilter\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\"\xfa\x02\n" +
+	"\rLegacyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x02(\tR\x04name\x12\x1f\n" +
+	"\tpage_size\x18\x02 \x01(\x05:\x0225R\bpageSize\x12\x1d\n" +
+	"\x06locale\x18\x03 \x01(\t:\x05en-USR\x06locale\x12\x1e\n" +
+	"\averbose\x18\x04 \x01(\b:\x04trueR\averbose\x129\n" +
+	"\x05level\x18\x05 \x01(\x0e2\x17.testserver.LegacyLevel:\n" +
+	"LEVEL_FULLR\x05level\x12\x19\n" +
+	"\x05ratio\x18\x06 \x01(\x01:\x030.5R\x05ratio\x12;\n" +
+	"\aoptions\x18\a \x01(\n" +
+	"2!.testserver.LegacyRequest.OptionsR\aoptions\x120\n" +
+	"\x06filter\x18\b \x02(\v2\x18.testserver.LegacyFilterR\x06filter\x1a)\n" +
+	"\aOptions\x12\x1e\n" +
+	"\adry_run\x18\x01 \x01(\b:\x05falseR\x06dryRun*\x05\bd\x10\xc8\x01\"\x87\x01\n" +
+	"\x0eLegacyResponse\x12\x18\n" +
+	"\asummary\x18\x01 \x01(\tR\asummary\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1b\n" +
+	"\ttrace_tag\x18\x03 \x01(\tR\btraceTag\x12!\n" +
+	"\fretry_budget\x18\x04 \x01(\x05R\vretryBudget*E\n" +
+	"\vLegacyLevel\x12\x15\n" +
+	"\x11LEVEL_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vLEVEL_BASIC\x10\x01\x12\x0e\n" +
+	"\n" +
+	"LEVEL_FULL\x10\x022R\n" +
+	"\rLegacyService\x12A\n" +
+	"\bDescribe\x12\x19.testserver.LegacyRequest\x1a\x1a.testserver.LegacyResponse:6\n" +
+	"\ttrace_tag\x12\x19.testserver.LegacyRequest\x18d \x01(\tR\btraceTag:?\n" +
+	"\fretry_budget\x12\x19.testserver.LegacyRequest\x18e \x01(\x05:\x013R\vretryBudgetB\x1bZ\x19grpc-gui/testserver/proto"
+
+var (
+	file_proto_legacy_proto_rawDescOnce sync.Once
+	file_proto_legacy_proto_rawDescData []byte
+)
+
+func file_proto_legacy_proto_rawDescGZIP() []byte {
+	file_proto_legacy_proto_rawDescOnce.Do(func() {
+		file_proto_legacy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_legacy_proto_rawDesc), len(file_proto_legacy_proto_rawDesc)))
+	})
+	return file_proto_legacy_proto_rawDescData
+}
+
+var file_proto_legacy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_legacy_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_legacy_proto_goTypes = []any{
+	(LegacyLevel)(0),              // 0: testserver.LegacyLevel
+	(*LegacyFilter)(nil),          // 1: testserver.LegacyFilter
+	(*LegacyRequest)(nil),         // 2: testserver.LegacyRequest
+	(*LegacyResponse)(nil),        // 3: testserver.LegacyResponse
+	(*LegacyRequest_Options)(nil), // 4: testserver.LegacyRequest.Options
+}
+var file_proto_legacy_proto_depIdxs = []int32{
+	0, // 0: testserver.LegacyRequest.level:type_name -> testserver.LegacyLevel
+	4, // 1: testserver.LegacyRequest.options:type_name -> testserver.LegacyRequest.Options
+	1, // 2: testserver.LegacyRequest.filter:type_name -> testserver.LegacyFilter
+	2, // 3: testserver.trace_tag:extendee -> testserver.LegacyRequest
+	2, // 4: testserver.retry_budget:extendee -> testserver.LegacyRequest
+	2, // 5: testserver.LegacyService.Describe:input_type -> testserver.LegacyRequest
+	3, // 6: testserver.LegacyService.Describe:output_type -> testserver.LegacyResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	3, // [3:5] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_legacy_proto_init() }
+func file_proto_legacy_proto_init() {
+	if File_proto_legacy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_legacy_proto_rawDesc), len(file_proto_legacy_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 2,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_legacy_proto_goTypes,
+		DependencyIndexes: file_proto_legacy_proto_depIdxs,
+		EnumInfos:         file_proto_legacy_proto_enumTypes,
+		MessageInfos:      file_proto_legacy_proto_msgTypes,
+		ExtensionInfos:    file_proto_legacy_proto_extTypes,
+	}.Build()
+	File_proto_legacy_proto = out.File
+	file_proto_legacy_proto_goTypes = nil
+	file_proto_legacy_proto_depIdxs = nil
+}
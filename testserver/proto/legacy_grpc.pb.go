@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v6.33.5
+// source: proto/legacy.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	LegacyService_Describe_FullMethodName = "/testserver.LegacyService/Describe"
+)
+
+// LegacyServiceClient is the client API for LegacyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LegacyServiceClient interface {
+	Describe(ctx context.Context, in *LegacyRequest, opts ...grpc.CallOption) (*LegacyResponse, error)
+}
+
+type legacyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLegacyServiceClient(cc grpc.ClientConnInterface) LegacyServiceClient {
+	return &legacyServiceClient{cc}
+}
+
+func (c *legacyServiceClient) Describe(ctx context.Context, in *LegacyRequest, opts ...grpc.CallOption) (*LegacyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LegacyResponse)
+	err := c.cc.Invoke(ctx, LegacyService_Describe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LegacyServiceServer is the server API for LegacyService service.
+// All implementations must embed UnimplementedLegacyServiceServer
+// for forward compatibility.
+type LegacyServiceServer interface {
+	Describe(context.Context, *LegacyRequest) (*LegacyResponse, error)
+	mustEmbedUnimplementedLegacyServiceServer()
+}
+
+// UnimplementedLegacyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLegacyServiceServer struct{}
+
+func (UnimplementedLegacyServiceServer) Describe(context.Context, *LegacyRequest) (*LegacyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Describe not implemented")
+}
+func (UnimplementedLegacyServiceServer) mustEmbedUnimplementedLegacyServiceServer() {}
+func (UnimplementedLegacyServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeLegacyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LegacyServiceServer will
+// result in compilation errors.
+type UnsafeLegacyServiceServer interface {
+	mustEmbedUnimplementedLegacyServiceServer()
+}
+
+func RegisterLegacyServiceServer(s grpc.ServiceRegistrar, srv LegacyServiceServer) {
+	// If the following call panics, it indicates UnimplementedLegacyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&LegacyService_ServiceDesc, srv)
+}
+
+func _LegacyService_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LegacyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LegacyServiceServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LegacyService_Describe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LegacyServiceServer).Describe(ctx, req.(*LegacyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LegacyService_ServiceDesc is the grpc.ServiceDesc for LegacyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LegacyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "testserver.LegacyService",
+	HandlerType: (*LegacyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Describe",
+			Handler:    _LegacyService_Describe_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/legacy.proto",
+}
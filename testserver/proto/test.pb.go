@@ -7,10 +7,16 @@
 package proto
 
 import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -1260,33 +1266,202 @@ func (x *ScheduleResponse) GetMessage() string {
 	return ""
 }
 
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	User          *User                   `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask  `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Comment       *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Version       *wrapperspb.Int64Value  `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Notify        *wrapperspb.BoolValue   `protobuf:"bytes,5,opt,name=notify,proto3" json:"notify,omitempty"`
+	Score         *wrapperspb.DoubleValue `protobuf:"bytes,6,opt,name=score,proto3" json:"score,omitempty"`
+	Signature     *wrapperspb.BytesValue  `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	Attributes    *structpb.Struct        `protobuf:"bytes,8,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	Nothing       structpb.NullValue      `protobuf:"varint,9,opt,name=nothing,proto3,enum=google.protobuf.NullValue" json:"nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_proto_test_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_test_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_test_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetComment() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetVersion() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetNotify() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Notify
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetScore() *wrapperspb.DoubleValue {
+	if x != nil {
+		return x.Score
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetSignature() *wrapperspb.BytesValue {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetNothing() structpb.NullValue {
+	if x != nil {
+		return x.Nothing
+	}
+	return structpb.NullValue(0)
+}
+
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Payload       *anypb.Any             `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Details       []*anypb.Any           `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_proto_test_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_test_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_proto_test_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Event) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event) GetPayload() *anypb.Any {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Event) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
 var File_proto_test_proto protoreflect.FileDescriptor
 
 const file_proto_test_proto_rawDesc = "" +
 	"\n" +
 	"\x10proto/test.proto\x12\n" +
-	"testserver\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"j\n" +
+	"testserver\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x19google/protobuf/any.proto\x1a google/protobuf/field_mask.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17validate/validate.proto\"j\n" +
 	"\aAddress\x12\x16\n" +
 	"\x06street\x18\x01 \x01(\tR\x06street\x12\x12\n" +
 	"\x04city\x18\x02 \x01(\tR\x04city\x12\x18\n" +
 	"\acountry\x18\x03 \x01(\tR\acountry\x12\x19\n" +
-	"\bzip_code\x18\x04 \x01(\x05R\azipCode\"\xf9\x05\n" +
+	"\bzip_code\x18\x04 \x01(\x05R\azipCode\"\x99\x06\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\x05email\x18\x03 \x01(\tB\a\xfaB\x04r\x02`\x01R\x05email\x12\x16\n" +
 	"\x06active\x18\x04 \x01(\bR\x06active\x12\x18\n" +
 	"\abalance\x18\x05 \x01(\x01R\abalance\x12\x14\n" +
-	"\x05score\x18\x06 \x01(\x02R\x05score\x12\x10\n" +
-	"\x03age\x18\a \x01(\x05R\x03age\x12\x16\n" +
+	"\x05score\x18\x06 \x01(\x02R\x05score\x12\x1b\n" +
+	"\x03age\x18\a \x01(\x05B\t\xfaB\x06\x1a\x04\x18x(\x12R\x03age\x12\x16\n" +
 	"\x06points\x18\b \x01(\rR\x06points\x12#\n" +
 	"\rsigned_points\x18\t \x01(\x11R\fsignedPoints\x12\x19\n" +
 	"\bfixed_id\x18\n" +
 	" \x01(\x06R\afixedId\x12!\n" +
 	"\fsfixed_value\x18\v \x01(\x0fR\vsfixedValue\x12\x16\n" +
 	"\x06avatar\x18\f \x01(\fR\x06avatar\x12*\n" +
-	"\x06status\x18\r \x01(\x0e2\x12.testserver.StatusR\x06status\x12\x12\n" +
-	"\x04tags\x18\x0e \x03(\tR\x04tags\x12\x18\n" +
+	"\x06status\x18\r \x01(\x0e2\x12.testserver.StatusR\x06status\x12\x1e\n" +
+	"\x04tags\x18\x0e \x03(\tB\n" +
+	"\xfaB\a\x92\x01\x04\b\x01\x10\x05R\x04tags\x12\x18\n" +
 	"\anumbers\x18\x0f \x03(\x05R\anumbers\x12-\n" +
 	"\aaddress\x18\x10 \x01(\v2\x13.testserver.AddressR\aaddress\x12:\n" +
 	"\bmetadata\x18\x11 \x03(\v2\x1e.testserver.User.MetadataEntryR\bmetadata\x12A\n" +
@@ -1398,7 +1573,24 @@ const file_proto_test_proto_rawDesc = "" +
 	"\tstarts_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12H\n" +
 	"\x12estimated_duration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x11estimatedDuration\x12A\n" +
 	"\x11assigned_priority\x18\x06 \x01(\x0e2\x14.testserver.PriorityR\x10assignedPriority\x12\x18\n" +
-	"\amessage\x18\a \x01(\tR\amessage*I\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"\xf7\x03\n" +
+	"\x11UpdateUserRequest\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.testserver.UserR\x04user\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x126\n" +
+	"\acomment\x18\x03 \x01(\v2\x1c.google.protobuf.StringValueR\acomment\x125\n" +
+	"\aversion\x18\x04 \x01(\v2\x1b.google.protobuf.Int64ValueR\aversion\x122\n" +
+	"\x06notify\x18\x05 \x01(\v2\x1a.google.protobuf.BoolValueR\x06notify\x122\n" +
+	"\x05score\x18\x06 \x01(\v2\x1c.google.protobuf.DoubleValueR\x05score\x129\n" +
+	"\tsignature\x18\a \x01(\v2\x1b.google.protobuf.BytesValueR\tsignature\x127\n" +
+	"\n" +
+	"attributes\x18\b \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x124\n" +
+	"\anothing\x18\t \x01(\x0e2\x1a.google.protobuf.NullValueR\anothing\"w\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\apayload\x18\x02 \x01(\v2\x14.google.protobuf.AnyR\apayload\x12.\n" +
+	"\adetails\x18\x03 \x03(\v2\x14.google.protobuf.AnyR\adetails*I\n" +
 	"\x06Status\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aPENDING\x10\x01\x12\n" +
@@ -1420,11 +1612,15 @@ const file_proto_test_proto_rawDesc = "" +
 	"\fScheduleTask\x12\x1b.testserver.ScheduleRequest\x1a\x1c.testserver.ScheduleResponse\x12G\n" +
 	"\fServerStream\x12\x19.testserver.SimpleRequest\x1a\x1a.testserver.StreamResponse0\x01\x12H\n" +
 	"\fClientStream\x12\x19.testserver.StreamRequest\x1a\x1b.testserver.ComplexResponse(\x01\x12P\n" +
-	"\x13BidirectionalStream\x12\x19.testserver.StreamRequest\x1a\x1a.testserver.StreamResponse(\x010\x012\xd3\x01\n" +
-	"\x0eAnotherService\x126\n" +
-	"\aGetUser\x12\x19.testserver.SimpleRequest\x1a\x10.testserver.User\x12A\n" +
-	"\bGetUsers\x12\x18.testserver.EmptyRequest\x1a\x1b.testserver.ComplexResponse\x12F\n" +
-	"\fUpdateStatus\x12\x1a.testserver.ComplexRequest\x1a\x1a.testserver.SimpleResponseB\x1bZ\x19grpc-gui/testserver/protob\x06proto3"
+	"\x13BidirectionalStream\x12\x19.testserver.StreamRequest\x1a\x1a.testserver.StreamResponse(\x010\x012\xf9\x02\n" +
+	"\x0eAnotherService\x12S\n" +
+	"\aGetUser\x12\x19.testserver.SimpleRequest\x1a\x10.testserver.User\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/users/{message}\x12A\n" +
+	"\bGetUsers\x12\x18.testserver.EmptyRequest\x1a\x1b.testserver.ComplexResponse\x12=\n" +
+	"\n" +
+	"UpdateUser\x12\x1d.testserver.UpdateUserRequest\x1a\x10.testserver.User\x12\x8f\x01\n" +
+	"\fUpdateStatus\x12\x1a.testserver.ComplexRequest\x1a\x1a.testserver.SimpleResponse\"G\x82\xd3\xe4\x93\x02A:\x01*Z$:\x06status2\x1a/v1/users/{user.id}/status\"\x16/v1/users:updateStatus2?\n" +
+	"\fEventService\x12/\n" +
+	"\aPublish\x12\x11.testserver.Event\x1a\x11.testserver.EventB\x1bZ\x19grpc-gui/testserver/protob\x06proto3"
 
 var (
 	file_proto_test_proto_rawDescOnce sync.Once
@@ -1439,102 +1635,128 @@ func file_proto_test_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_test_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_test_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_test_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_proto_test_proto_goTypes = []any{
-	(Status)(0),                   // 0: testserver.Status
-	(Priority)(0),                 // 1: testserver.Priority
-	(*Address)(nil),               // 2: testserver.Address
-	(*User)(nil),                  // 3: testserver.User
-	(*NestedMessage)(nil),         // 4: testserver.NestedMessage
-	(*ComplexRequest)(nil),        // 5: testserver.ComplexRequest
-	(*ComplexResponse)(nil),       // 6: testserver.ComplexResponse
-	(*SimpleRequest)(nil),         // 7: testserver.SimpleRequest
-	(*SimpleResponse)(nil),        // 8: testserver.SimpleResponse
-	(*StreamRequest)(nil),         // 9: testserver.StreamRequest
-	(*StreamResponse)(nil),        // 10: testserver.StreamResponse
-	(*EmptyRequest)(nil),          // 11: testserver.EmptyRequest
-	(*EmptyResponse)(nil),         // 12: testserver.EmptyResponse
-	(*ScheduleRequest)(nil),       // 13: testserver.ScheduleRequest
-	(*ScheduleResponse)(nil),      // 14: testserver.ScheduleResponse
-	nil,                           // 15: testserver.User.MetadataEntry
-	nil,                           // 16: testserver.User.AddressMapEntry
-	nil,                           // 17: testserver.ComplexRequest.UserMapEntry
-	nil,                           // 18: testserver.ComplexRequest.StatusMapEntry
-	nil,                           // 19: testserver.ComplexRequest.IdToNameEntry
-	nil,                           // 20: testserver.ComplexResponse.ResultsEntry
-	nil,                           // 21: testserver.ComplexResponse.NodesEntry
-	nil,                           // 22: testserver.ScheduleRequest.PhaseDurationsEntry
-	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 24: google.protobuf.Duration
+	(Status)(0),                    // 0: testserver.Status
+	(Priority)(0),                  // 1: testserver.Priority
+	(*Address)(nil),                // 2: testserver.Address
+	(*User)(nil),                   // 3: testserver.User
+	(*NestedMessage)(nil),          // 4: testserver.NestedMessage
+	(*ComplexRequest)(nil),         // 5: testserver.ComplexRequest
+	(*ComplexResponse)(nil),        // 6: testserver.ComplexResponse
+	(*SimpleRequest)(nil),          // 7: testserver.SimpleRequest
+	(*SimpleResponse)(nil),         // 8: testserver.SimpleResponse
+	(*StreamRequest)(nil),          // 9: testserver.StreamRequest
+	(*StreamResponse)(nil),         // 10: testserver.StreamResponse
+	(*EmptyRequest)(nil),           // 11: testserver.EmptyRequest
+	(*EmptyResponse)(nil),          // 12: testserver.EmptyResponse
+	(*ScheduleRequest)(nil),        // 13: testserver.ScheduleRequest
+	(*ScheduleResponse)(nil),       // 14: testserver.ScheduleResponse
+	(*UpdateUserRequest)(nil),      // 15: testserver.UpdateUserRequest
+	(*Event)(nil),                  // 16: testserver.Event
+	nil,                            // 17: testserver.User.MetadataEntry
+	nil,                            // 18: testserver.User.AddressMapEntry
+	nil,                            // 19: testserver.ComplexRequest.UserMapEntry
+	nil,                            // 20: testserver.ComplexRequest.StatusMapEntry
+	nil,                            // 21: testserver.ComplexRequest.IdToNameEntry
+	nil,                            // 22: testserver.ComplexResponse.ResultsEntry
+	nil,                            // 23: testserver.ComplexResponse.NodesEntry
+	nil,                            // 24: testserver.ScheduleRequest.PhaseDurationsEntry
+	(*timestamppb.Timestamp)(nil),  // 25: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 26: google.protobuf.Duration
+	(*fieldmaskpb.FieldMask)(nil),  // 27: google.protobuf.FieldMask
+	(*wrapperspb.StringValue)(nil), // 28: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 29: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),   // 30: google.protobuf.BoolValue
+	(*wrapperspb.DoubleValue)(nil), // 31: google.protobuf.DoubleValue
+	(*wrapperspb.BytesValue)(nil),  // 32: google.protobuf.BytesValue
+	(*structpb.Struct)(nil),        // 33: google.protobuf.Struct
+	(structpb.NullValue)(0),        // 34: google.protobuf.NullValue
+	(*anypb.Any)(nil),              // 35: google.protobuf.Any
 }
 var file_proto_test_proto_depIdxs = []int32{
 	0,  // 0: testserver.User.status:type_name -> testserver.Status
 	2,  // 1: testserver.User.address:type_name -> testserver.Address
-	15, // 2: testserver.User.metadata:type_name -> testserver.User.MetadataEntry
-	16, // 3: testserver.User.address_map:type_name -> testserver.User.AddressMapEntry
+	17, // 2: testserver.User.metadata:type_name -> testserver.User.MetadataEntry
+	18, // 3: testserver.User.address_map:type_name -> testserver.User.AddressMapEntry
 	2,  // 4: testserver.User.addresses:type_name -> testserver.Address
 	4,  // 5: testserver.NestedMessage.nested:type_name -> testserver.NestedMessage
 	4,  // 6: testserver.NestedMessage.children:type_name -> testserver.NestedMessage
 	3,  // 7: testserver.ComplexRequest.user:type_name -> testserver.User
 	3,  // 8: testserver.ComplexRequest.users:type_name -> testserver.User
-	17, // 9: testserver.ComplexRequest.user_map:type_name -> testserver.ComplexRequest.UserMapEntry
+	19, // 9: testserver.ComplexRequest.user_map:type_name -> testserver.ComplexRequest.UserMapEntry
 	4,  // 10: testserver.ComplexRequest.nested:type_name -> testserver.NestedMessage
 	0,  // 11: testserver.ComplexRequest.status:type_name -> testserver.Status
 	0,  // 12: testserver.ComplexRequest.statuses:type_name -> testserver.Status
-	18, // 13: testserver.ComplexRequest.status_map:type_name -> testserver.ComplexRequest.StatusMapEntry
+	20, // 13: testserver.ComplexRequest.status_map:type_name -> testserver.ComplexRequest.StatusMapEntry
 	3,  // 14: testserver.ComplexRequest.user_payload:type_name -> testserver.User
-	19, // 15: testserver.ComplexRequest.id_to_name:type_name -> testserver.ComplexRequest.IdToNameEntry
+	21, // 15: testserver.ComplexRequest.id_to_name:type_name -> testserver.ComplexRequest.IdToNameEntry
 	3,  // 16: testserver.ComplexResponse.user:type_name -> testserver.User
 	3,  // 17: testserver.ComplexResponse.users:type_name -> testserver.User
-	20, // 18: testserver.ComplexResponse.results:type_name -> testserver.ComplexResponse.ResultsEntry
+	22, // 18: testserver.ComplexResponse.results:type_name -> testserver.ComplexResponse.ResultsEntry
 	0,  // 19: testserver.ComplexResponse.status:type_name -> testserver.Status
 	4,  // 20: testserver.ComplexResponse.nested:type_name -> testserver.NestedMessage
 	4,  // 21: testserver.ComplexResponse.tree:type_name -> testserver.NestedMessage
-	21, // 22: testserver.ComplexResponse.nodes:type_name -> testserver.ComplexResponse.NodesEntry
+	23, // 22: testserver.ComplexResponse.nodes:type_name -> testserver.ComplexResponse.NodesEntry
 	0,  // 23: testserver.StreamResponse.status:type_name -> testserver.Status
 	1,  // 24: testserver.ScheduleRequest.priority:type_name -> testserver.Priority
-	23, // 25: testserver.ScheduleRequest.scheduled_at:type_name -> google.protobuf.Timestamp
-	24, // 26: testserver.ScheduleRequest.timeout:type_name -> google.protobuf.Duration
-	23, // 27: testserver.ScheduleRequest.deadline:type_name -> google.protobuf.Timestamp
+	25, // 25: testserver.ScheduleRequest.scheduled_at:type_name -> google.protobuf.Timestamp
+	26, // 26: testserver.ScheduleRequest.timeout:type_name -> google.protobuf.Duration
+	25, // 27: testserver.ScheduleRequest.deadline:type_name -> google.protobuf.Timestamp
 	0,  // 28: testserver.ScheduleRequest.status:type_name -> testserver.Status
-	23, // 29: testserver.ScheduleRequest.checkpoints:type_name -> google.protobuf.Timestamp
-	22, // 30: testserver.ScheduleRequest.phase_durations:type_name -> testserver.ScheduleRequest.PhaseDurationsEntry
+	25, // 29: testserver.ScheduleRequest.checkpoints:type_name -> google.protobuf.Timestamp
+	24, // 30: testserver.ScheduleRequest.phase_durations:type_name -> testserver.ScheduleRequest.PhaseDurationsEntry
 	0,  // 31: testserver.ScheduleResponse.status:type_name -> testserver.Status
-	23, // 32: testserver.ScheduleResponse.created_at:type_name -> google.protobuf.Timestamp
-	23, // 33: testserver.ScheduleResponse.starts_at:type_name -> google.protobuf.Timestamp
-	24, // 34: testserver.ScheduleResponse.estimated_duration:type_name -> google.protobuf.Duration
+	25, // 32: testserver.ScheduleResponse.created_at:type_name -> google.protobuf.Timestamp
+	25, // 33: testserver.ScheduleResponse.starts_at:type_name -> google.protobuf.Timestamp
+	26, // 34: testserver.ScheduleResponse.estimated_duration:type_name -> google.protobuf.Duration
 	1,  // 35: testserver.ScheduleResponse.assigned_priority:type_name -> testserver.Priority
-	2,  // 36: testserver.User.AddressMapEntry.value:type_name -> testserver.Address
-	3,  // 37: testserver.ComplexRequest.UserMapEntry.value:type_name -> testserver.User
-	0,  // 38: testserver.ComplexRequest.StatusMapEntry.value:type_name -> testserver.Status
-	3,  // 39: testserver.ComplexResponse.ResultsEntry.value:type_name -> testserver.User
-	4,  // 40: testserver.ComplexResponse.NodesEntry.value:type_name -> testserver.NestedMessage
-	24, // 41: testserver.ScheduleRequest.PhaseDurationsEntry.value:type_name -> google.protobuf.Duration
-	7,  // 42: testserver.TestService.SimpleCall:input_type -> testserver.SimpleRequest
-	5,  // 43: testserver.TestService.ComplexCall:input_type -> testserver.ComplexRequest
-	11, // 44: testserver.TestService.EmptyCall:input_type -> testserver.EmptyRequest
-	13, // 45: testserver.TestService.ScheduleTask:input_type -> testserver.ScheduleRequest
-	7,  // 46: testserver.TestService.ServerStream:input_type -> testserver.SimpleRequest
-	9,  // 47: testserver.TestService.ClientStream:input_type -> testserver.StreamRequest
-	9,  // 48: testserver.TestService.BidirectionalStream:input_type -> testserver.StreamRequest
-	7,  // 49: testserver.AnotherService.GetUser:input_type -> testserver.SimpleRequest
-	11, // 50: testserver.AnotherService.GetUsers:input_type -> testserver.EmptyRequest
-	5,  // 51: testserver.AnotherService.UpdateStatus:input_type -> testserver.ComplexRequest
-	8,  // 52: testserver.TestService.SimpleCall:output_type -> testserver.SimpleResponse
-	6,  // 53: testserver.TestService.ComplexCall:output_type -> testserver.ComplexResponse
-	12, // 54: testserver.TestService.EmptyCall:output_type -> testserver.EmptyResponse
-	14, // 55: testserver.TestService.ScheduleTask:output_type -> testserver.ScheduleResponse
-	10, // 56: testserver.TestService.ServerStream:output_type -> testserver.StreamResponse
-	6,  // 57: testserver.TestService.ClientStream:output_type -> testserver.ComplexResponse
-	10, // 58: testserver.TestService.BidirectionalStream:output_type -> testserver.StreamResponse
-	3,  // 59: testserver.AnotherService.GetUser:output_type -> testserver.User
-	6,  // 60: testserver.AnotherService.GetUsers:output_type -> testserver.ComplexResponse
-	8,  // 61: testserver.AnotherService.UpdateStatus:output_type -> testserver.SimpleResponse
-	52, // [52:62] is the sub-list for method output_type
-	42, // [42:52] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	42, // [42:42] is the sub-list for extension extendee
-	0,  // [0:42] is the sub-list for field type_name
+	3,  // 36: testserver.UpdateUserRequest.user:type_name -> testserver.User
+	27, // 37: testserver.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	28, // 38: testserver.UpdateUserRequest.comment:type_name -> google.protobuf.StringValue
+	29, // 39: testserver.UpdateUserRequest.version:type_name -> google.protobuf.Int64Value
+	30, // 40: testserver.UpdateUserRequest.notify:type_name -> google.protobuf.BoolValue
+	31, // 41: testserver.UpdateUserRequest.score:type_name -> google.protobuf.DoubleValue
+	32, // 42: testserver.UpdateUserRequest.signature:type_name -> google.protobuf.BytesValue
+	33, // 43: testserver.UpdateUserRequest.attributes:type_name -> google.protobuf.Struct
+	34, // 44: testserver.UpdateUserRequest.nothing:type_name -> google.protobuf.NullValue
+	35, // 45: testserver.Event.payload:type_name -> google.protobuf.Any
+	35, // 46: testserver.Event.details:type_name -> google.protobuf.Any
+	2,  // 47: testserver.User.AddressMapEntry.value:type_name -> testserver.Address
+	3,  // 48: testserver.ComplexRequest.UserMapEntry.value:type_name -> testserver.User
+	0,  // 49: testserver.ComplexRequest.StatusMapEntry.value:type_name -> testserver.Status
+	3,  // 50: testserver.ComplexResponse.ResultsEntry.value:type_name -> testserver.User
+	4,  // 51: testserver.ComplexResponse.NodesEntry.value:type_name -> testserver.NestedMessage
+	26, // 52: testserver.ScheduleRequest.PhaseDurationsEntry.value:type_name -> google.protobuf.Duration
+	7,  // 53: testserver.TestService.SimpleCall:input_type -> testserver.SimpleRequest
+	5,  // 54: testserver.TestService.ComplexCall:input_type -> testserver.ComplexRequest
+	11, // 55: testserver.TestService.EmptyCall:input_type -> testserver.EmptyRequest
+	13, // 56: testserver.TestService.ScheduleTask:input_type -> testserver.ScheduleRequest
+	7,  // 57: testserver.TestService.ServerStream:input_type -> testserver.SimpleRequest
+	9,  // 58: testserver.TestService.ClientStream:input_type -> testserver.StreamRequest
+	9,  // 59: testserver.TestService.BidirectionalStream:input_type -> testserver.StreamRequest
+	7,  // 60: testserver.AnotherService.GetUser:input_type -> testserver.SimpleRequest
+	11, // 61: testserver.AnotherService.GetUsers:input_type -> testserver.EmptyRequest
+	15, // 62: testserver.AnotherService.UpdateUser:input_type -> testserver.UpdateUserRequest
+	5,  // 63: testserver.AnotherService.UpdateStatus:input_type -> testserver.ComplexRequest
+	16, // 64: testserver.EventService.Publish:input_type -> testserver.Event
+	8,  // 65: testserver.TestService.SimpleCall:output_type -> testserver.SimpleResponse
+	6,  // 66: testserver.TestService.ComplexCall:output_type -> testserver.ComplexResponse
+	12, // 67: testserver.TestService.EmptyCall:output_type -> testserver.EmptyResponse
+	14, // 68: testserver.TestService.ScheduleTask:output_type -> testserver.ScheduleResponse
+	10, // 69: testserver.TestService.ServerStream:output_type -> testserver.StreamResponse
+	6,  // 70: testserver.TestService.ClientStream:output_type -> testserver.ComplexResponse
+	10, // 71: testserver.TestService.BidirectionalStream:output_type -> testserver.StreamResponse
+	3,  // 72: testserver.AnotherService.GetUser:output_type -> testserver.User
+	6,  // 73: testserver.AnotherService.GetUsers:output_type -> testserver.ComplexResponse
+	3,  // 74: testserver.AnotherService.UpdateUser:output_type -> testserver.User
+	8,  // 75: testserver.AnotherService.UpdateStatus:output_type -> testserver.SimpleResponse
+	16, // 76: testserver.EventService.Publish:output_type -> testserver.Event
+	65, // [65:77] is the sub-list for method output_type
+	53, // [53:65] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_proto_test_proto_init() }
@@ -1554,9 +1776,9 @@ func file_proto_test_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_test_proto_rawDesc), len(file_proto_test_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   21,
+			NumMessages:   23,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   3,
 		},
 		GoTypes:           file_proto_test_proto_goTypes,
 		DependencyIndexes: file_proto_test_proto_depIdxs,
@@ -342,6 +342,7 @@ var TestService_ServiceDesc = grpc.ServiceDesc{
 const (
 	AnotherService_GetUser_FullMethodName      = "/testserver.AnotherService/GetUser"
 	AnotherService_GetUsers_FullMethodName     = "/testserver.AnotherService/GetUsers"
+	AnotherService_UpdateUser_FullMethodName   = "/testserver.AnotherService/UpdateUser"
 	AnotherService_UpdateStatus_FullMethodName = "/testserver.AnotherService/UpdateStatus"
 )
 
@@ -351,6 +352,7 @@ const (
 type AnotherServiceClient interface {
 	GetUser(ctx context.Context, in *SimpleRequest, opts ...grpc.CallOption) (*User, error)
 	GetUsers(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ComplexResponse, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
 	UpdateStatus(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*SimpleResponse, error)
 }
 
@@ -382,6 +384,16 @@ func (c *anotherServiceClient) GetUsers(ctx context.Context, in *EmptyRequest, o
 	return out, nil
 }
 
+func (c *anotherServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, AnotherService_UpdateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *anotherServiceClient) UpdateStatus(ctx context.Context, in *ComplexRequest, opts ...grpc.CallOption) (*SimpleResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SimpleResponse)
@@ -398,6 +410,7 @@ func (c *anotherServiceClient) UpdateStatus(ctx context.Context, in *ComplexRequ
 type AnotherServiceServer interface {
 	GetUser(context.Context, *SimpleRequest) (*User, error)
 	GetUsers(context.Context, *EmptyRequest) (*ComplexResponse, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
 	UpdateStatus(context.Context, *ComplexRequest) (*SimpleResponse, error)
 	mustEmbedUnimplementedAnotherServiceServer()
 }
@@ -415,6 +428,9 @@ func (UnimplementedAnotherServiceServer) GetUser(context.Context, *SimpleRequest
 func (UnimplementedAnotherServiceServer) GetUsers(context.Context, *EmptyRequest) (*ComplexResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUsers not implemented")
 }
+func (UnimplementedAnotherServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateUser not implemented")
+}
 func (UnimplementedAnotherServiceServer) UpdateStatus(context.Context, *ComplexRequest) (*SimpleResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateStatus not implemented")
 }
@@ -475,6 +491,24 @@ func _AnotherService_GetUsers_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnotherService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnotherServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnotherService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnotherServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AnotherService_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ComplexRequest)
 	if err := dec(in); err != nil {
@@ -508,6 +542,10 @@ var AnotherService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUsers",
 			Handler:    _AnotherService_GetUsers_Handler,
 		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _AnotherService_UpdateUser_Handler,
+		},
 		{
 			MethodName: "UpdateStatus",
 			Handler:    _AnotherService_UpdateStatus_Handler,
@@ -516,3 +554,105 @@ var AnotherService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/test.proto",
 }
+
+const (
+	EventService_Publish_FullMethodName = "/testserver.EventService/Publish"
+)
+
+// EventServiceClient is the client API for EventService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EventServiceClient interface {
+	Publish(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error)
+}
+
+type eventServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventServiceClient(cc grpc.ClientConnInterface) EventServiceClient {
+	return &eventServiceClient{cc}
+}
+
+func (c *eventServiceClient) Publish(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, EventService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EventServiceServer is the server API for EventService service.
+// All implementations must embed UnimplementedEventServiceServer
+// for forward compatibility.
+type EventServiceServer interface {
+	Publish(context.Context, *Event) (*Event, error)
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+// UnimplementedEventServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEventServiceServer struct{}
+
+func (UnimplementedEventServiceServer) Publish(context.Context, *Event) (*Event, error) {
+	return nil, status.Error(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedEventServiceServer) mustEmbedUnimplementedEventServiceServer() {}
+func (UnimplementedEventServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeEventServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventServiceServer will
+// result in compilation errors.
+type UnsafeEventServiceServer interface {
+	mustEmbedUnimplementedEventServiceServer()
+}
+
+func RegisterEventServiceServer(s grpc.ServiceRegistrar, srv EventServiceServer) {
+	// If the following call panics, it indicates UnimplementedEventServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EventService_ServiceDesc, srv)
+}
+
+func _EventService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Event)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EventServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EventService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EventServiceServer).Publish(ctx, req.(*Event))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EventService_ServiceDesc is the grpc.ServiceDesc for EventService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "testserver.EventService",
+	HandlerType: (*EventServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Publish",
+			Handler:    _EventService_Publish_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/test.proto",
+}
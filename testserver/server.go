@@ -1,4 +1,4 @@
-//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/test.proto
+//go:generate protoc -I . -I ./third_party/googleapis -I ./third_party/protoc-gen-validate --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/test.proto proto/audit.proto proto/legacy.proto proto/editions.proto
 
 package main
 
@@ -10,7 +10,12 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"grpc-gui/testserver/proto"
@@ -24,6 +29,18 @@ type anotherServer struct {
 	proto.UnimplementedAnotherServiceServer
 }
 
+type legacyServer struct {
+	proto.UnimplementedLegacyServiceServer
+}
+
+type editionsServer struct {
+	proto.UnimplementedEditionsServiceServer
+}
+
+type eventServer struct {
+	proto.UnimplementedEventServiceServer
+}
+
 func (s *testServer) SimpleCall(ctx context.Context, req *proto.SimpleRequest) (*proto.SimpleResponse, error) {
 	return &proto.SimpleResponse{
 		Result:    fmt.Sprintf("Echo: %s", req.Message),
@@ -54,7 +71,7 @@ func (s *testServer) EmptyCall(ctx context.Context, req *proto.EmptyRequest) (*p
 
 func (s *testServer) ScheduleTask(ctx context.Context, req *proto.ScheduleRequest) (*proto.ScheduleResponse, error) {
 	now := time.Now()
-	
+
 	return &proto.ScheduleResponse{
 		TaskId:            12345,
 		Status:            req.Status,
@@ -134,6 +151,29 @@ func (s *anotherServer) GetUsers(ctx context.Context, req *proto.EmptyRequest) (
 	}, nil
 }
 
+func (s *anotherServer) UpdateUser(ctx context.Context, req *proto.UpdateUserRequest) (*proto.User, error) {
+	user := &proto.User{Id: 1, Name: "User1", Email: "user1@example.com", Status: proto.Status_ACTIVE}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return req.User, nil
+	}
+
+	src := req.GetUser().ProtoReflect()
+	dst := user.ProtoReflect()
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		fd := dst.Descriptor().Fields().ByName(protoreflect.Name(path))
+		if fd == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown field %q in update_mask", path)
+		}
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+	}
+
+	return user, nil
+}
+
 func (s *anotherServer) UpdateStatus(ctx context.Context, req *proto.ComplexRequest) (*proto.SimpleResponse, error) {
 	return &proto.SimpleResponse{
 		Result:    "Status updated",
@@ -141,6 +181,43 @@ func (s *anotherServer) UpdateStatus(ctx context.Context, req *proto.ComplexRequ
 	}, nil
 }
 
+func (s *legacyServer) Describe(ctx context.Context, req *proto.LegacyRequest) (*proto.LegacyResponse, error) {
+	return &proto.LegacyResponse{
+		Summary:     protobuf.String(fmt.Sprintf("%s (%s, %s)", req.GetName(), req.GetLocale(), req.GetLevel())),
+		PageSize:    protobuf.Int32(req.GetPageSize()),
+		TraceTag:    protobuf.String(protobuf.GetExtension(req, proto.E_TraceTag).(string)),
+		RetryBudget: protobuf.Int32(protobuf.GetExtension(req, proto.E_RetryBudget).(int32)),
+	}, nil
+}
+
+func (s *editionsServer) Echo(ctx context.Context, req *proto.EditionsRequest) (*proto.EditionsResponse, error) {
+	return &proto.EditionsResponse{
+		Id:          protobuf.String(req.GetId()),
+		Count:       protobuf.Int32(req.GetCount()),
+		Label:       protobuf.String(req.GetLabel()),
+		NestedValue: protobuf.String(req.GetNested().GetValue()),
+		Limit:       protobuf.Int32(req.GetLimit()),
+		HasId:       protobuf.Bool(req.Id != nil),
+	}, nil
+}
+
+func (s *eventServer) Publish(ctx context.Context, req *proto.Event) (*proto.Event, error) {
+	audit, err := anypb.New(&proto.AuditRecord{
+		Actor:  "testserver",
+		Action: "publish",
+		At:     timestamppb.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Event{
+		Id:      req.Id,
+		Payload: req.Payload,
+		Details: append(req.Details, audit),
+	}, nil
+}
+
 func main() {
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
@@ -151,6 +228,9 @@ func main() {
 
 	proto.RegisterTestServiceServer(s, &testServer{})
 	proto.RegisterAnotherServiceServer(s, &anotherServer{})
+	proto.RegisterEventServiceServer(s, &eventServer{})
+	proto.RegisterLegacyServiceServer(s, &legacyServer{})
+	proto.RegisterEditionsServiceServer(s, &editionsServer{})
 
 	reflection.Register(s)
 